@@ -2,9 +2,10 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -16,7 +17,10 @@ import (
 	"github.com/diogovalentte/mantium/api/src/config"
 	"github.com/diogovalentte/mantium/api/src/dashboard"
 	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/jobs"
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/routes"
+	"github.com/diogovalentte/mantium/api/src/scheduler"
 	"github.com/diogovalentte/mantium/api/src/sources"
 	"github.com/diogovalentte/mantium/api/src/sources/mangadex"
 	"github.com/diogovalentte/mantium/api/src/sources/mangahub"
@@ -107,6 +111,7 @@ func init() {
 		}
 	}
 
+	setPullAniListProgressPeriodicallyJob(log)
 	setUpdateMangasMetadataPeriodicallyJob(log)
 	dashboard.UpdateDashboard()
 
@@ -120,6 +125,16 @@ func init() {
 	} else {
 		log.Info().Msg("Will not use the Tranga integration")
 	}
+	if config.GlobalConfigs.AniList.Valid {
+		log.Info().Msg("Will use the AniList integration")
+	} else {
+		log.Info().Msg("Will not use the AniList integration")
+	}
+	if config.GlobalConfigs.MangaUpdates.Valid {
+		log.Info().Msg("Will use the MangaUpdates integration")
+	} else {
+		log.Info().Msg("Will not use the MangaUpdates integration")
+	}
 }
 
 func main() {
@@ -140,10 +155,49 @@ func updateMangasTLDs() error {
 	return nil
 }
 
-// setUpdateMangasMetadataPeriodicallyJob sets a job to update mangas metadata periodically
-// based on the configs set in the .env file in another goroutine.
+// updateMangasMetadataJobName is the name of the scheduler job that updates the mangas metadata.
+const updateMangasMetadataJobName = "update_mangas_metadata"
+
+// setUpdateMangasMetadataPeriodicallyJob registers the job that updates the mangas metadata
+// in the scheduler, based on the configs set in the .env file.
+// If updating periodically is disabled, the job is registered paused, so it can still be triggered manually.
 func setUpdateMangasMetadataPeriodicallyJob(log *zerolog.Logger) {
 	configs := config.GlobalConfigs.PeriodicallyUpdateMangas
+
+	spec := configs.Cron
+	if spec == "" {
+		spec = fmt.Sprintf("@every %dm", configs.Minutes)
+	}
+
+	err := scheduler.DefaultScheduler.AddJob(updateMangasMetadataJobName, spec, func(ctx context.Context) error {
+		log.Info().Msg("Updating mangas metadata...")
+		job, jobCtx := jobs.NewJob(ctx, routes.UpdateMangasMetadataJobType)
+		errors, err := routes.RunUpdateMangasMetadataJob(jobCtx, job, configs.Notify, true)
+		if err == nil {
+			for _, errSlice := range errors {
+				if len(errSlice) > 0 {
+					errorsJSON, _ := json.Marshal(errors)
+					err = fmt.Errorf("some errors occured while updating the mangas metadata: %s", errorsJSON)
+					break
+				}
+			}
+		}
+		if err != nil {
+			errMessage := fmt.Sprintf("Error updating mangas metadata in background: %s", err)
+			log.Error().Msg(errMessage)
+			dashboard.SetLastBackgroundError(errMessage)
+			return err
+		}
+
+		log.Info().Msg("Mangas metadata updated")
+		dashboard.ResetConsecutiveErrors()
+
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
 	if configs.Update {
 		log.Info().Msg("Starting to update mangas metadata periodically...")
 
@@ -153,43 +207,55 @@ func setUpdateMangasMetadataPeriodicallyJob(log *zerolog.Logger) {
 			log.Info().Msg("Will not notify when updating mangas metadata")
 		}
 
-		log.Info().Msgf("Will update mangas metadata every %d minutes", configs.Minutes)
-		log.Info().Msgf("First update in %d minutes", configs.Minutes)
-
-		go func() {
-			for {
-				time.Sleep(time.Duration(configs.Minutes) * time.Minute)
-
-				log.Info().Msg("Updating mangas metadata...")
-				res, err := util.RequestUpdateMangasMetadata(configs.Notify)
-				if err != nil {
-					errMessage := fmt.Sprintf("Error updating mangas metadata in background: %s", err)
-					log.Error().Msg(errMessage)
-					log.Error().Msgf("Request response: %v", res)
-
-					if res != nil {
-						var respMessage string
-						body, err := io.ReadAll(res.Body)
-						if err != nil {
-							respMessage = fmt.Sprintf("Error while reading response body: %s", err)
-						} else {
-							respMessage = fmt.Sprintf("Request response text: %s", string(body))
-						}
-						log.Error().Msg(respMessage)
-						dashboard.SetLastBackgroundError(fmt.Sprintf("%s\n%s", errMessage, respMessage))
-						res.Body.Close() // cannot be defer because it's an infinite loop
-					} else {
-						dashboard.SetLastBackgroundError(fmt.Sprintf("%s\n%s", errMessage, "No response to get the body"))
-					}
-				} else {
-					log.Info().Msg("Mangas metadata updated")
-					dashboard.ResetConsecutiveErrors()
-				}
-			}
-		}()
+		log.Info().Msgf("Will update mangas metadata using the schedule '%s'", spec)
 	} else {
+		err = scheduler.DefaultScheduler.PauseJob(updateMangasMetadataJobName)
+		if err != nil {
+			panic(err)
+		}
 		log.Info().Msg("Not updating mangas metadata periodically")
 	}
+
+	scheduler.DefaultScheduler.Start()
+
+	job, err := scheduler.DefaultScheduler.GetJob(updateMangasMetadataJobName)
+	if err == nil && !job.NextRun.IsZero() {
+		log.Info().Msgf("First update at %s", job.NextRun.Format(time.RFC3339))
+	}
+}
+
+// pullAniListProgressJobName is the name of the scheduler job that pulls the progress from AniList.
+const pullAniListProgressJobName = "pull_anilist_progress"
+
+// setPullAniListProgressPeriodicallyJob registers the job that pulls the progress of the multimangas
+// linked to AniList in the scheduler, if the AniList integration is enabled with a pull interval.
+// The scheduler is started by setUpdateMangasMetadataPeriodicallyJob.
+func setPullAniListProgressPeriodicallyJob(log *zerolog.Logger) {
+	configs := config.GlobalConfigs.AniList
+	if !configs.Valid || configs.PullProgressMinutes == 0 {
+		log.Info().Msg("Not pulling the progress from AniList periodically")
+		return
+	}
+
+	spec := fmt.Sprintf("@every %dm", configs.PullProgressMinutes)
+	err := scheduler.DefaultScheduler.AddJob(pullAniListProgressJobName, spec, func(_ context.Context) error {
+		log.Info().Msg("Pulling progress from AniList...")
+		updated, err := routes.RunPullAniListProgress()
+		if err != nil {
+			errMessage := fmt.Sprintf("Error pulling progress from AniList in background: %s", err)
+			log.Error().Msg(errMessage)
+			dashboard.SetLastBackgroundError(errMessage)
+			return err
+		}
+		log.Info().Msgf("Progress pulled from AniList, %d multimangas updated", updated)
+
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	log.Info().Msgf("Will pull the progress from AniList using the schedule '%s'", spec)
 }
 
 // Migration to be applied if current version stored in DB is lower than the field Version.
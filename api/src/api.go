@@ -2,17 +2,24 @@
 package api
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	docs "github.com/diogovalentte/mantium/api/docs"
+	"github.com/diogovalentte/mantium/api/src/auth"
 	"github.com/diogovalentte/mantium/api/src/routes"
 )
 
 // SetupRouter sets up the routes for the API
 func SetupRouter() *gin.Engine {
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.LoggerWithFormatter(logFormatter), gin.Recovery())
 
 	docs.SwaggerInfo.Title = "Mantium API"
 	docs.SwaggerInfo.Description = "API for Mantium, a manga dashboard."
@@ -20,6 +27,7 @@ func SetupRouter() *gin.Engine {
 	docs.SwaggerInfo.BasePath = "/v1"
 
 	v1 := router.Group("/v1")
+	v1.Use(auth.Middleware())
 	{
 		routes.HealthCheckRoute(v1)
 	}
@@ -29,8 +37,70 @@ func SetupRouter() *gin.Engine {
 	{
 		routes.DashboardRoutes(v1)
 	}
+	{
+		routes.SchedulerRoutes(v1)
+	}
+	{
+		routes.JobsRoutes(v1)
+	}
+	{
+		routes.SourcesRoutes(v1)
+	}
+	{
+		routes.AuthRoutes(v1)
+	}
+	{
+		routes.LibraryRoutes(v1)
+	}
+	{
+		routes.AniListRoutes(v1)
+		routes.MangaUpdatesRoutes(v1)
+	}
 
 	v1.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 	return router
 }
+
+// logFormatter is gin's default log formatter, but with the
+// API token in the request's query redacted.
+func logFormatter(param gin.LogFormatterParams) string {
+	var statusColor, methodColor, resetColor string
+	if param.IsOutputColor() {
+		statusColor = param.StatusCodeColor()
+		methodColor = param.MethodColor()
+		resetColor = param.ResetColor()
+	}
+
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		statusColor, param.StatusCode, resetColor,
+		param.Latency,
+		param.ClientIP,
+		methodColor, param.Method, resetColor,
+		redactTokenQuery(param.Path),
+		param.ErrorMessage,
+	)
+}
+
+// redactTokenQuery returns the path with the value of the token query parameter redacted.
+// If the query can't be parsed, the whole query is redacted.
+func redactTokenQuery(path string) string {
+	path, rawQuery, found := strings.Cut(path, "?")
+	if !found {
+		return path
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path + "?REDACTED"
+	}
+	if query.Has("token") {
+		query.Set("token", "REDACTED")
+	}
+
+	return path + "?" + query.Encode()
+}
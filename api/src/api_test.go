@@ -0,0 +1,26 @@
+package api
+
+import "testing"
+
+func TestRedactTokenQuery(t *testing.T) {
+	t.Run("Should redact the token query parameter", func(t *testing.T) {
+		path := redactTokenQuery("/v1/mangas/iframe?theme=dark&token=mantium_0123456789abcdef")
+		expected := "/v1/mangas/iframe?theme=dark&token=REDACTED"
+		if path != expected {
+			t.Fatalf("Expected path '%s', got '%s'", expected, path)
+		}
+	})
+	t.Run("Should not change a path without a query", func(t *testing.T) {
+		path := redactTokenQuery("/v1/mangas")
+		if path != "/v1/mangas" {
+			t.Fatalf("Expected path '/v1/mangas', got '%s'", path)
+		}
+	})
+	t.Run("Should redact the whole query if it can't be parsed", func(t *testing.T) {
+		path := redactTokenQuery("/v1/mangas?token=mantium_0123456789abcdef&bad=%zz")
+		expected := "/v1/mangas?REDACTED"
+		if path != expected {
+			t.Fatalf("Expected path '%s', got '%s'", expected, path)
+		}
+	})
+}
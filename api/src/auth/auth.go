@@ -0,0 +1,235 @@
+// Package auth implements the optional API authentication using
+// the admin password, users, and API tokens with scopes.
+package auth
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+)
+
+// Scope is the access level of an API token.
+type Scope string
+
+const (
+	// ScopeIframe allows only the routes used by the mangas iFrame to show the mangas.
+	ScopeIframe Scope = "iframe"
+	// ScopeRead allows all read-only routes.
+	ScopeRead Scope = "read"
+	// ScopeWrite allows all routes, except the admin routes.
+	ScopeWrite Scope = "write"
+	// ScopeAdmin allows all routes.
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes are the valid scopes, from the lowest to the highest access level.
+var Scopes = []Scope{ScopeIframe, ScopeRead, ScopeWrite, ScopeAdmin}
+
+// Allows returns true if the scope has the access level of the required scope.
+func (s Scope) Allows(required Scope) bool {
+	scopeLevel := slices.Index(Scopes, s)
+	requiredLevel := slices.Index(Scopes, required)
+	if scopeLevel == -1 || requiredLevel == -1 {
+		return false
+	}
+
+	return scopeLevel >= requiredLevel
+}
+
+// ValidateScope returns true if the scope is valid.
+func ValidateScope(scope Scope) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+const (
+	// scopeContextKey is the gin context key of the request's scope.
+	scopeContextKey = "auth_scope"
+	// userIDContextKey is the gin context key of the request's user ID.
+	userIDContextKey = "auth_user_id"
+)
+
+// publicRoutes are the routes that don't require authentication.
+var publicRoutes = []string{
+	"/v1/health",
+	"/v1/auth/status",
+	"/v1/swagger/*any",
+}
+
+// iframeRoutes are the GET routes used by the mangas iFrame.
+var iframeRoutes = []string{
+	"/v1/mangas/iframe",
+	"/v1/dashboard/last_update",
+	"/v1/dashboard/last_background_error",
+}
+
+// adminRoutes are the non-GET routes that change the global state, like the scheduler
+// or all mangas, and so can't be used by write tokens or users.
+var adminRoutes = []string{
+	"/v1/library/import",
+	"/v1/scheduler/job/trigger",
+	"/v1/scheduler/job/pause",
+	"/v1/scheduler/job/resume",
+	"/v1/mangas/update_interval",
+	"/v1/mangas/metadata",
+	"/v1/jobs/:id",
+}
+
+// RequiredScope returns the scope required to access the route.
+// The route should be the route path registered in the router, like "/v1/jobs/:id".
+func RequiredScope(method, route string) Scope {
+	isReadMethod := method == http.MethodGet || method == http.MethodHead
+
+	switch {
+	case strings.HasPrefix(route, "/v1/auth/"):
+		return ScopeAdmin
+	case !isReadMethod && slices.Contains(adminRoutes, route):
+		return ScopeAdmin
+	case isReadMethod && slices.Contains(iframeRoutes, route):
+		return ScopeIframe
+	case isReadMethod:
+		return ScopeRead
+	default:
+		return ScopeWrite
+	}
+}
+
+// CheckAdminPassword returns true if the password is the configured admin password.
+func CheckAdminPassword(password string) bool {
+	hash := config.GlobalConfigs.Auth.AdminPasswordHash
+	if hash == "" {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Middleware returns a middleware that authenticates the requests if the authentication is enabled.
+// The requests can be authenticated with an API token in the "Authorization: Bearer <token>" header
+// or in the "token" query parameter, or using basic auth with the admin password (username "admin"),
+// which has the admin scope, or with a user's username and password.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.GlobalConfigs.Auth.Enabled {
+			c.Set(scopeContextKey, ScopeAdmin)
+			c.Next()
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		if slices.Contains(publicRoutes, route) {
+			c.Next()
+			return
+		}
+
+		scope, userID, err := authenticate(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+		if scope == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "missing or invalid credentials"})
+			return
+		}
+
+		requiredScope := RequiredScope(c.Request.Method, route)
+		if !scope.Allows(requiredScope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "the credentials scope '" + string(scope) + "' can't access this route, it requires the '" + string(requiredScope) + "' scope"})
+			return
+		}
+
+		c.Set(scopeContextKey, scope)
+		if userID > 0 {
+			c.Set(userIDContextKey, userID)
+		}
+		c.Next()
+	}
+}
+
+// authenticate returns the scope and the user ID of the request's credentials.
+// If the credentials don't belong to a user, the user ID is 0.
+// If there are no credentials or they're invalid, it returns an empty scope.
+func authenticate(c *gin.Context) (Scope, int, error) {
+	if username, password, ok := c.Request.BasicAuth(); ok {
+		if username == adminUsername {
+			if CheckAdminPassword(password) {
+				return ScopeAdmin, 0, nil
+			}
+			return "", 0, nil
+		}
+
+		user, err := getUserByCredentialsFromDB(username, password)
+		if err != nil || user == nil {
+			return "", 0, err
+		}
+		return user.Scope, user.ID, nil
+	}
+
+	tokenValue := c.Query("token")
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		var found bool
+		tokenValue, found = strings.CutPrefix(authHeader, "Bearer ")
+		if !found {
+			return "", 0, nil
+		}
+	}
+	tokenValue = strings.TrimSpace(tokenValue)
+	if tokenValue == "" {
+		return "", 0, nil
+	}
+
+	token, err := GetTokenByValueFromDB(tokenValue)
+	if err != nil {
+		if err == errTokenNotValid {
+			return "", 0, nil
+		}
+		return "", 0, err
+	}
+	if token.UserID == nil {
+		return token.Scope, 0, nil
+	}
+
+	// The user's scope could be lowered after the token was created
+	user, err := GetUserFromDB(*token.UserID)
+	if err != nil {
+		return "", 0, err
+	}
+	if !user.Scope.Allows(token.Scope) {
+		return user.Scope, user.ID, nil
+	}
+
+	return token.Scope, user.ID, nil
+}
+
+// GetScope returns the scope of the request's credentials set by the Middleware.
+// If the authentication is disabled, the scope is admin.
+func GetScope(c *gin.Context) Scope {
+	scope, ok := c.Get(scopeContextKey)
+	if !ok {
+		if config.GlobalConfigs.Auth.Enabled {
+			return ""
+		}
+		return ScopeAdmin
+	}
+
+	return scope.(Scope)
+}
+
+// GetUserID returns the ID of the user that made the request, set by the Middleware.
+// If the request wasn't made by a user, like when using the admin password or
+// an API token without a user, it returns 0, meaning the global library should be used.
+func GetUserID(c *gin.Context) int {
+	userID, ok := c.Get(userIDContextKey)
+	if !ok {
+		return 0
+	}
+
+	return userID.(int)
+}
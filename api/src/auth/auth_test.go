@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+)
+
+func TestScopeAllows(t *testing.T) {
+	t.Run("Higher scopes allow lower scopes", func(t *testing.T) {
+		for i, scope := range Scopes {
+			for j, required := range Scopes {
+				if scope.Allows(required) != (i >= j) {
+					t.Fatalf("expected %s.Allows(%s) to be %v", scope, required, i >= j)
+				}
+			}
+		}
+	})
+	t.Run("Invalid scopes allow nothing", func(t *testing.T) {
+		if Scope("root").Allows(ScopeIframe) {
+			t.Fatal("expected invalid scope to not allow anything")
+		}
+	})
+}
+
+func TestRequiredScope(t *testing.T) {
+	testCases := []struct {
+		method   string
+		route    string
+		expected Scope
+	}{
+		{http.MethodGet, "/v1/mangas/iframe", ScopeIframe},
+		{http.MethodGet, "/v1/dashboard/last_update", ScopeIframe},
+		{http.MethodDelete, "/v1/dashboard/last_background_error", ScopeWrite},
+		{http.MethodGet, "/v1/multimangas", ScopeRead},
+		{http.MethodDelete, "/v1/multimanga", ScopeWrite},
+		{http.MethodPatch, "/v1/multimanga/last_read_chapter", ScopeWrite},
+		{http.MethodGet, "/v1/dashboard/configs", ScopeRead},
+		{http.MethodPost, "/v1/dashboard/configs", ScopeWrite},
+		{http.MethodGet, "/v1/auth/tokens", ScopeAdmin},
+		{http.MethodPost, "/v1/auth/users", ScopeAdmin},
+		{http.MethodGet, "/v1/library/export", ScopeRead},
+		{http.MethodPost, "/v1/library/import", ScopeAdmin},
+		{http.MethodPost, "/v1/library/import/list", ScopeWrite},
+		{http.MethodGet, "/v1/scheduler/jobs", ScopeRead},
+		{http.MethodPost, "/v1/scheduler/job/trigger", ScopeAdmin},
+		{http.MethodPatch, "/v1/scheduler/job/pause", ScopeAdmin},
+		{http.MethodPatch, "/v1/scheduler/job/resume", ScopeAdmin},
+		{http.MethodGet, "/v1/mangas/update_interval", ScopeRead},
+		{http.MethodPatch, "/v1/mangas/update_interval", ScopeAdmin},
+		{http.MethodPatch, "/v1/mangas/metadata", ScopeAdmin},
+		{http.MethodPatch, "/v1/multimanga/metadata", ScopeWrite},
+		{http.MethodGet, "/v1/jobs/:id", ScopeRead},
+		{http.MethodDelete, "/v1/jobs/:id", ScopeAdmin},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.method+" "+tc.route, func(t *testing.T) {
+			if scope := RequiredScope(tc.method, tc.route); scope != tc.expected {
+				t.Fatalf("expected scope %s, got %s", tc.expected, scope)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	originalConfigs := *config.GlobalConfigs.Auth
+	t.Cleanup(func() {
+		*config.GlobalConfigs.Auth = originalConfigs
+	})
+
+	router := gin.New()
+	v1 := router.Group("/v1")
+	v1.Use(Middleware())
+	v1.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	v1.GET("/multimangas", func(c *gin.Context) { c.String(http.StatusOK, string(GetScope(c))) })
+	v1.POST("/dashboard/configs", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	doRequest := func(method, path string, setAuth func(req *http.Request)) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		if setAuth != nil {
+			setAuth(req)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Allow all requests if the authentication is disabled", func(t *testing.T) {
+		config.GlobalConfigs.Auth.Enabled = false
+		w := doRequest(http.MethodPost, "/v1/dashboard/configs", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	})
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.GlobalConfigs.Auth.Enabled = true
+	config.GlobalConfigs.Auth.AdminPasswordHash = string(hash)
+
+	t.Run("Allow public routes without credentials", func(t *testing.T) {
+		w := doRequest(http.MethodGet, "/v1/health", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	})
+	t.Run("Reject requests without credentials", func(t *testing.T) {
+		w := doRequest(http.MethodGet, "/v1/multimangas", nil)
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
+	t.Run("Reject wrong admin password", func(t *testing.T) {
+		w := doRequest(http.MethodGet, "/v1/multimangas", func(req *http.Request) { req.SetBasicAuth("admin", "wrong") })
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
+	t.Run("Allow admin password with admin scope", func(t *testing.T) {
+		w := doRequest(http.MethodGet, "/v1/multimangas", func(req *http.Request) { req.SetBasicAuth("admin", "password") })
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if w.Body.String() != string(ScopeAdmin) {
+			t.Fatalf("expected scope %s, got %s", ScopeAdmin, w.Body.String())
+		}
+		w = doRequest(http.MethodPost, "/v1/dashboard/configs", func(req *http.Request) { req.SetBasicAuth("admin", "password") })
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// tokenPrefix is the prefix of all API tokens, used to identify them.
+const tokenPrefix = "mantium_"
+
+// errTokenNotValid is returned when a token doesn't exist or is revoked.
+var errTokenNotValid = errors.New("API token not valid")
+
+// Token is an API token. Only the token's hash is stored, so the
+// token value is only available when the token is created.
+type Token struct {
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	// UserID is the ID of the user that owns the token. If nil, the token
+	// isn't owned by a user and accesses the global library.
+	UserID *int   `json:"userID"`
+	Name   string `json:"name"`
+	Scope  Scope  `json:"scope"`
+	// Prefix is the beginning of the token value, used to identify the token.
+	Prefix string `json:"prefix"`
+	ID     int    `json:"id"`
+}
+
+// CreateTokenInDB creates a new API token with the name and scope in the database.
+// If userID is greater than 0, the token is owned by the user and its scope can't
+// be higher than the user's scope.
+// It returns the token value, which can't be retrieved later.
+func CreateTokenInDB(name string, scope Scope, userID int) (string, *Token, error) {
+	contextError := "error creating API token '%s' in DB"
+
+	if !ValidateScope(scope) {
+		return "", nil, util.AddErrorContext(fmt.Sprintf(contextError, name), fmt.Errorf("invalid scope '%s', should be one of %v", scope, Scopes))
+	}
+
+	var tokenUserID *int
+	if userID > 0 {
+		user, err := GetUserFromDB(userID)
+		if err != nil {
+			return "", nil, util.AddErrorContext(fmt.Sprintf(contextError, name), err)
+		}
+		if !user.Scope.Allows(scope) {
+			return "", nil, util.AddErrorContext(fmt.Sprintf(contextError, name), fmt.Errorf("scope '%s' is higher than the user scope '%s'", scope, user.Scope))
+		}
+		tokenUserID = &userID
+	}
+
+	randomBytes := make([]byte, 32)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", nil, util.AddErrorContext(fmt.Sprintf(contextError, name), err)
+	}
+	value := tokenPrefix + hex.EncodeToString(randomBytes)
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return "", nil, util.AddErrorContext(fmt.Sprintf(contextError, name), err)
+	}
+	defer db.Close()
+
+	token := &Token{
+		Name:      name,
+		Scope:     scope,
+		UserID:    tokenUserID,
+		Prefix:    value[:len(tokenPrefix)+8],
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	err = db.QueryRow(`
+        INSERT INTO api_tokens
+            (name, scope, user_id, prefix, token_hash, created_at)
+        VALUES
+            ($1, $2, $3, $4, $5, $6)
+        RETURNING
+            id;
+    `, token.Name, token.Scope, token.UserID, token.Prefix, hashToken(value), token.CreatedAt).Scan(&token.ID)
+	if err != nil {
+		return "", nil, util.AddErrorContext(fmt.Sprintf(contextError, name), err)
+	}
+
+	return value, token, nil
+}
+
+// GetTokensFromDB gets all API tokens, including the revoked ones, from the database.
+func GetTokensFromDB() ([]*Token, error) {
+	contextError := "error getting API tokens from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+        SELECT
+            id, name, scope, user_id, prefix, created_at, last_used_at, revoked_at
+        FROM
+            api_tokens
+        ORDER BY
+            id ASC;
+    `)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer rows.Close()
+
+	tokens := []*Token{}
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, util.AddErrorContext(contextError, err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+
+	return tokens, nil
+}
+
+// GetTokenByValueFromDB gets a valid (not revoked) API token by its value from the database
+// and updates its last used time.
+func GetTokenByValueFromDB(value string) (*Token, error) {
+	contextError := "error getting API token from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	row := db.QueryRow(`
+        UPDATE api_tokens
+        SET last_used_at = $1
+        WHERE token_hash = $2 AND revoked_at IS NULL
+        RETURNING
+            id, name, scope, user_id, prefix, created_at, last_used_at, revoked_at;
+    `, time.Now().Truncate(time.Second), hashToken(value))
+	token, err := scanToken(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errTokenNotValid
+		}
+		return nil, util.AddErrorContext(contextError, err)
+	}
+
+	return token, nil
+}
+
+// RevokeTokenInDB revokes the API token with the ID in the database.
+func RevokeTokenInDB(id int) error {
+	contextError := "error revoking API token with ID '%d' in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+	defer db.Close()
+
+	result, err := db.Exec(`
+        UPDATE api_tokens
+        SET revoked_at = $1
+        WHERE id = $2 AND revoked_at IS NULL;
+    `, time.Now().Truncate(time.Second), id)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+	if rowsAffected == 0 {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), errordefs.ErrAPITokenNotFoundDB)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanToken(row rowScanner) (*Token, error) {
+	var token Token
+	var lastUsedAt, revokedAt sql.NullTime
+	var userID sql.NullInt64
+	err := row.Scan(&token.ID, &token.Name, &token.Scope, &userID, &token.Prefix, &token.CreatedAt, &lastUsedAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	if userID.Valid {
+		id := int(userID.Int64)
+		token.UserID = &id
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return &token, nil
+}
+
+func hashToken(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// adminUsername is the username used to authenticate with the admin password.
+// It can't be used by the users.
+const adminUsername = "admin"
+
+// UserScopes are the valid scopes of the users. Users can't have the admin scope.
+var UserScopes = []Scope{ScopeIframe, ScopeRead, ScopeWrite}
+
+// User is a user of the API. Each user has its own library, with its own multimangas
+// status and last read chapter, and its own dashboard configs.
+type User struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Username  string    `json:"username"`
+	// Scope is the highest scope of the user's requests, including the requests
+	// made with the user's API tokens.
+	Scope Scope `json:"scope"`
+	ID    int   `json:"id"`
+}
+
+// CreateUserInDB creates a new user with the username, password, and scope in the database.
+func CreateUserInDB(username, password string, scope Scope) (*User, error) {
+	contextError := "error creating user '%s' in DB"
+
+	err := validateUser(username, password, scope)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, username), err)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, username), err)
+	}
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, username), err)
+	}
+	defer db.Close()
+
+	user := &User{
+		Username:  username,
+		Scope:     scope,
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	err = db.QueryRow(`
+        INSERT INTO users
+            (username, password_hash, scope, created_at)
+        VALUES
+            ($1, $2, $3, $4)
+        RETURNING
+            id;
+    `, user.Username, string(passwordHash), user.Scope, user.CreatedAt).Scan(&user.ID)
+	if err != nil {
+		if err.Error() == `pq: duplicate key value violates unique constraint "users_username_key"` {
+			return nil, util.AddErrorContext(fmt.Sprintf(contextError, username), errordefs.ErrUserAlreadyInDB)
+		}
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, username), err)
+	}
+
+	return user, nil
+}
+
+func validateUser(username, password string, scope Scope) error {
+	if username == "" || strings.ContainsAny(username, ": ") {
+		return fmt.Errorf("username can't be empty or contain spaces or colons")
+	}
+	if username == adminUsername {
+		return fmt.Errorf("username '%s' is reserved", adminUsername)
+	}
+	if password == "" {
+		return fmt.Errorf("password can't be empty")
+	}
+	if !slices.Contains(UserScopes, scope) {
+		return fmt.Errorf("invalid user scope '%s', should be one of %v", scope, UserScopes)
+	}
+
+	return nil
+}
+
+// GetUsersFromDB gets all users from the database.
+func GetUsersFromDB() ([]*User, error) {
+	contextError := "error getting users from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+        SELECT
+            id, username, scope, created_at
+        FROM
+            users
+        ORDER BY
+            id ASC;
+    `)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer rows.Close()
+
+	users := []*User{}
+	for rows.Next() {
+		var user User
+		err = rows.Scan(&user.ID, &user.Username, &user.Scope, &user.CreatedAt)
+		if err != nil {
+			return nil, util.AddErrorContext(contextError, err)
+		}
+		users = append(users, &user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+
+	return users, nil
+}
+
+// GetUserFromDB gets the user with the ID from the database.
+func GetUserFromDB(id int) (*User, error) {
+	contextError := "error getting user with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+	defer db.Close()
+
+	var user User
+	err = db.QueryRow(`
+        SELECT
+            id, username, scope, created_at
+        FROM
+            users
+        WHERE
+            id = $1;
+    `, id).Scan(&user.ID, &user.Username, &user.Scope, &user.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, util.AddErrorContext(fmt.Sprintf(contextError, id), errordefs.ErrUserNotFoundDB)
+		}
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+
+	return &user, nil
+}
+
+// DeleteUserFromDB deletes the user with the ID, its library, configs, and API tokens from the database.
+// The multimangas are not deleted, as they're shared by all users.
+func DeleteUserFromDB(id int) error {
+	contextError := "error deleting user with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+	defer db.Close()
+
+	result, err := db.Exec(`
+        DELETE FROM users
+        WHERE id = $1;
+    `, id)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), err)
+	}
+	if rowsAffected == 0 {
+		return util.AddErrorContext(fmt.Sprintf(contextError, id), errordefs.ErrUserNotFoundDB)
+	}
+
+	return nil
+}
+
+// getUserByCredentialsFromDB returns the user with the username and password.
+// If the credentials are invalid, it returns nil.
+func getUserByCredentialsFromDB(username, password string) (*User, error) {
+	contextError := "error getting user '%s' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, username), err)
+	}
+	defer db.Close()
+
+	var user User
+	var passwordHash string
+	err = db.QueryRow(`
+        SELECT
+            id, username, scope, created_at, password_hash
+        FROM
+            users
+        WHERE
+            username = $1;
+    `, username).Scan(&user.ID, &user.Username, &user.Scope, &user.CreatedAt, &passwordHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, username), err)
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)) != nil {
+		return nil, nil
+	}
+
+	return &user, nil
+}
@@ -1,12 +1,17 @@
 package config
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"sync"
 
 	"github.com/diogovalentte/mantium/api/src/db"
 	"github.com/diogovalentte/mantium/api/src/util"
 )
 
+// LoadConfigsFromDB loads the configs from the DB. If the configs are the global
+// dashboard configs, the sources' proxies are set too (see SetSourcesProxies).
 func LoadConfigsFromDB(configs *DashboardConfigs) error {
 	contextError := "error loading configs from DB"
 
@@ -16,10 +21,14 @@ func LoadConfigsFromDB(configs *DashboardConfigs) error {
 	}
 	defer db.Close()
 
+	var sourcesProxies string
 	err = db.QueryRow(`
 		SELECT
 			columns, show_background_error_warning, search_results_limit, display_mode,
-			add_all_multimanga_mangas_to_download_integrations, enqueue_all_suwayomi_chapters_to_download
+			add_all_multimanga_mangas_to_download_integrations, enqueue_all_suwayomi_chapters_to_download,
+			notification_title_template, notification_message_template, notification_tags_template,
+			notification_priority_template, notification_click_url_template,
+			sources_proxy, sources_proxies
 		FROM
 			configs;
 	`).Scan(
@@ -27,10 +36,25 @@ func LoadConfigsFromDB(configs *DashboardConfigs) error {
 		&configs.Display.SearchResultsLimit, &configs.Display.DisplayMode,
 		&configs.Integrations.AddAllMultiMangaMangasToDownloadIntegrations,
 		&configs.Integrations.EnqueueAllSuwayomiChaptersToDownload,
+		&configs.Notifications.Title, &configs.Notifications.Message, &configs.Notifications.Tags,
+		&configs.Notifications.Priority, &configs.Notifications.ClickURL,
+		&configs.Proxies.Proxy, &sourcesProxies,
 	)
 	if err != nil {
 		return util.AddErrorContext(contextError, err)
 	}
+	configs.Proxies.Sources = map[string]string{}
+	err = json.Unmarshal([]byte(sourcesProxies), &configs.Proxies.Sources)
+	if err != nil {
+		return util.AddErrorContext(contextError, util.AddErrorContext("error decoding sources proxies", err))
+	}
+
+	if configs == GlobalConfigs.DashboardConfigs {
+		err = SetSourcesProxies()
+		if err != nil {
+			return util.AddErrorContext(contextError, err)
+		}
+	}
 
 	return nil
 }
@@ -76,6 +100,14 @@ func SaveConfigsToDB(configs *DashboardConfigs) error {
 	updateDBConfigsMutex.Lock()
 	defer updateDBConfigsMutex.Unlock()
 
+	if configs.Proxies.Sources == nil {
+		configs.Proxies.Sources = map[string]string{}
+	}
+	sourcesProxies, err := json.Marshal(configs.Proxies.Sources)
+	if err != nil {
+		return util.AddErrorContext(contextError, util.AddErrorContext("error encoding sources proxies", err))
+	}
+
 	db, err := db.OpenConn()
 	if err != nil {
 		return util.AddErrorContext(contextError, err)
@@ -92,12 +124,18 @@ func SaveConfigsToDB(configs *DashboardConfigs) error {
 			configs
 		SET
 			columns = $1, show_background_error_warning = $2, search_results_limit = $3, display_mode = $4,
-			add_all_multimanga_mangas_to_download_integrations = $5, enqueue_all_suwayomi_chapters_to_download = $6
+			add_all_multimanga_mangas_to_download_integrations = $5, enqueue_all_suwayomi_chapters_to_download = $6,
+			notification_title_template = $7, notification_message_template = $8, notification_tags_template = $9,
+			notification_priority_template = $10, notification_click_url_template = $11,
+			sources_proxy = $12, sources_proxies = $13
 		;
 	`, configs.Display.Columns, configs.Display.ShowBackgroundErrorWarning,
 		configs.Display.SearchResultsLimit, configs.Display.DisplayMode,
 		configs.Integrations.AddAllMultiMangaMangasToDownloadIntegrations,
 		configs.Integrations.EnqueueAllSuwayomiChaptersToDownload,
+		configs.Notifications.Title, configs.Notifications.Message, configs.Notifications.Tags,
+		configs.Notifications.Priority, configs.Notifications.ClickURL,
+		configs.Proxies.Proxy, string(sourcesProxies),
 	)
 	if err != nil {
 		tx.Rollback()
@@ -111,3 +149,77 @@ func SaveConfigsToDB(configs *DashboardConfigs) error {
 
 	return nil
 }
+
+// LoadUserConfigsFromDB loads the user's display configs from the DB into configs.
+// The other configs are shared by all users, so they're not changed. If the user
+// didn't save its configs yet, configs is not changed.
+func LoadUserConfigsFromDB(userID int, configs *DashboardConfigs) error {
+	contextError := "error loading configs of user with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+	defer db.Close()
+
+	err = db.QueryRow(`
+		SELECT
+			columns, show_background_error_warning, search_results_limit, display_mode
+		FROM
+			user_configs
+		WHERE
+			user_id = $1;
+	`, userID).Scan(
+		&configs.Display.Columns, &configs.Display.ShowBackgroundErrorWarning,
+		&configs.Display.SearchResultsLimit, &configs.Display.DisplayMode,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	return nil
+}
+
+// SaveUserConfigsToDB saves the user's display configs to the DB.
+// The other configs are shared by all users, so they're not saved.
+func SaveUserConfigsToDB(userID int, configs *DashboardConfigs) error {
+	contextError := "error saving configs of user with ID '%d' to DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO user_configs
+			(user_id, columns, show_background_error_warning, search_results_limit, display_mode)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			columns = EXCLUDED.columns, show_background_error_warning = EXCLUDED.show_background_error_warning,
+			search_results_limit = EXCLUDED.search_results_limit, display_mode = EXCLUDED.display_mode
+		;
+	`, userID, configs.Display.Columns, configs.Display.ShowBackgroundErrorWarning,
+		configs.Display.SearchResultsLimit, configs.Display.DisplayMode,
+	)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	return nil
+}
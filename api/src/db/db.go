@@ -66,7 +66,8 @@ func CreateTables(db *sql.DB, log *zerolog.Logger) error {
           "cover_img" bytea,
           "cover_img_resized" bool,
           "cover_img_url" text,
-          "preferred_group" varchar(30),
+          "preferred_group" varchar(255),
+          "preferred_language" varchar(255) NOT NULL DEFAULT '',
           "last_released_chapter" integer,
           "last_read_chapter" integer,
 		  "last_released_chapter_name_selector" text,
@@ -76,7 +77,12 @@ func CreateTables(db *sql.DB, log *zerolog.Logger) error {
 		  "last_released_chapter_url_selector" text,
 		  "last_released_chapter_url_attribute" varchar(30),
 		  "last_released_chapter_url_get_first" boolean NOT NULL DEFAULT FALSE,
-		  "last_released_chapter_selector_use_browser" boolean NOT NULL DEFAULT FALSE
+		  "last_released_chapter_selector_use_browser" boolean NOT NULL DEFAULT FALSE,
+          "update_last_attempt_at" timestamp,
+          "update_last_success_at" timestamp,
+          "update_failing_since" timestamp,
+          "update_last_error" text NOT NULL DEFAULT '',
+          "update_consecutive_failures" integer NOT NULL DEFAULT 0
         );
 
         CREATE INDEX IF NOT EXISTS "mangas_id_idx" ON "mangas" ("id");
@@ -89,7 +95,12 @@ func CreateTables(db *sql.DB, log *zerolog.Logger) error {
           "cover_img" bytea NOT NULL DEFAULT '',
           "cover_img_resized" bool NOT NULL DEFAULT FALSE,
           "cover_img_url" text NOT NULL DEFAULT '',
-          "cover_img_fixed" boolean NOT NULL DEFAULT FALSE
+          "cover_img_fixed" boolean NOT NULL DEFAULT FALSE,
+          "update_last_attempt_at" timestamp,
+          "update_last_success_at" timestamp,
+          "update_failing_since" timestamp,
+          "update_last_error" text NOT NULL DEFAULT '',
+          "update_consecutive_failures" integer NOT NULL DEFAULT 0
         );
 
         CREATE INDEX IF NOT EXISTS "multimangas_id_idx" ON "multimangas" ("id");
@@ -100,23 +111,123 @@ func CreateTables(db *sql.DB, log *zerolog.Logger) error {
           "multimanga_id" integer,
           "url" text,
           "chapter" varchar(255),
+          "volume" varchar(50) NOT NULL DEFAULT '',
           "name" varchar(255),
           "internal_id" VARCHAR(100) NOT NULL DEFAULT '',
           "updated_at" timestamp,
           "type" smallint NOT NULL CONSTRAINT chapter_type_check CHECK (type IN (1, 2)),
-          "from_source_site" bool NOT NULL DEFAULT TRUE,
-          PRIMARY KEY ("url", "type")
+          "from_source_site" bool NOT NULL DEFAULT TRUE
         );
 
         CREATE INDEX IF NOT EXISTS "chapters_id_idx" ON "chapters" ("id");
 
+        CREATE TABLE IF NOT EXISTS "chapters_history" (
+          "id" serial UNIQUE,
+          "manga_id" integer NOT NULL REFERENCES mangas(id) ON DELETE CASCADE,
+          "url" text NOT NULL,
+          "chapter" varchar(255) NOT NULL,
+          "volume" varchar(50) NOT NULL DEFAULT '',
+          "name" varchar(255) NOT NULL,
+          "internal_id" VARCHAR(100) NOT NULL DEFAULT '',
+          "updated_at" timestamp,
+          "first_seen_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+          PRIMARY KEY ("manga_id", "url")
+        );
+
+        CREATE INDEX IF NOT EXISTS "chapters_history_manga_id_idx" ON "chapters_history" ("manga_id");
+
+        CREATE TABLE IF NOT EXISTS "status_update_intervals" (
+          "status" integer PRIMARY KEY CHECK ("status" >= 1 AND "status" <= 5),
+          "update_interval" integer NOT NULL DEFAULT 0
+        );
+
+        CREATE TABLE IF NOT EXISTS "notification_rules" (
+          "multimanga_id" integer PRIMARY KEY REFERENCES multimangas(id) ON DELETE CASCADE,
+          "muted" boolean NOT NULL DEFAULT FALSE,
+          "only_numeric_increase" boolean NOT NULL DEFAULT FALSE,
+          "min_chapter_gap" real NOT NULL DEFAULT 0 CHECK ("min_chapter_gap" >= 0),
+          "notifier" varchar(30) NOT NULL DEFAULT '',
+          "topic" varchar(255) NOT NULL DEFAULT '',
+          "quiet_hours_start" smallint NOT NULL DEFAULT -1 CHECK ("quiet_hours_start" >= -1 AND "quiet_hours_start" <= 23),
+          "quiet_hours_end" smallint NOT NULL DEFAULT -1 CHECK ("quiet_hours_end" >= -1 AND "quiet_hours_end" <= 23),
+          "last_notified_chapter" varchar(255) NOT NULL DEFAULT ''
+        );
+
+        CREATE TABLE IF NOT EXISTS "pending_notifications" (
+          "id" serial PRIMARY KEY,
+          "manga_id" integer NOT NULL REFERENCES mangas(id) ON DELETE CASCADE,
+          "manga_name" varchar(255) NOT NULL,
+          "chapter" varchar(255) NOT NULL,
+          "url" text NOT NULL,
+          "notifier" varchar(30) NOT NULL DEFAULT '',
+          "topic" varchar(255) NOT NULL DEFAULT '',
+          "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+          "send_after" timestamp
+        );
+
+        CREATE TABLE IF NOT EXISTS "users" (
+          "id" serial PRIMARY KEY,
+          "username" varchar(50) NOT NULL UNIQUE,
+          "password_hash" text NOT NULL,
+          "scope" varchar(10) NOT NULL DEFAULT 'write' CHECK ("scope" IN ('iframe', 'read', 'write')),
+          "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+        );
+
+        CREATE TABLE IF NOT EXISTS "user_multimangas" (
+          "user_id" integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+          "multimanga_id" integer NOT NULL REFERENCES multimangas(id) ON DELETE CASCADE,
+          "status" smallint NOT NULL CHECK ("status" >= 1 AND "status" <= 5),
+          "last_read_chapter_url" text,
+          "last_read_chapter" varchar(255),
+          "last_read_chapter_volume" varchar(50),
+          "last_read_chapter_name" varchar(255),
+          "last_read_chapter_internal_id" varchar(100),
+          "last_read_chapter_updated_at" timestamp,
+          "last_read_chapter_from_source_site" boolean,
+          PRIMARY KEY ("user_id", "multimanga_id")
+        );
+
+        CREATE INDEX IF NOT EXISTS "user_multimangas_multimanga_id_idx" ON "user_multimangas" ("multimanga_id");
+
+        CREATE TABLE IF NOT EXISTS "user_configs" (
+          "user_id" integer PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+          "columns" integer NOT NULL DEFAULT 5,
+          "show_background_error_warning" boolean NOT NULL DEFAULT TRUE,
+          "search_results_limit" integer NOT NULL DEFAULT 20,
+          "display_mode" varchar(50) NOT NULL DEFAULT 'Grid View' CHECK ("display_mode" IN ('Grid View', 'List View'))
+        );
+
+        CREATE TABLE IF NOT EXISTS "anilist_links" (
+          "multimanga_id" integer PRIMARY KEY REFERENCES multimangas(id) ON DELETE CASCADE,
+          "media_id" integer NOT NULL
+        );
+
+        CREATE TABLE IF NOT EXISTS "api_tokens" (
+          "id" serial PRIMARY KEY,
+          "name" varchar(255) NOT NULL,
+          "scope" varchar(10) NOT NULL CHECK ("scope" IN ('iframe', 'read', 'write', 'admin')),
+          "user_id" integer REFERENCES users(id) ON DELETE CASCADE,
+          "prefix" varchar(30) NOT NULL,
+          "token_hash" char(64) NOT NULL UNIQUE,
+          "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+          "last_used_at" timestamp,
+          "revoked_at" timestamp
+        );
+
 		CREATE TABLE IF NOT EXISTS "configs" (
 			"columns" integer NOT NULL DEFAULT 5,
 			"show_background_error_warning" boolean NOT NULL DEFAULT TRUE,
 			"search_results_limit" integer NOT NULL DEFAULT 20,
 			"display_mode" varchar(50) NOT NULL DEFAULT 'Grid View' CHECK ("display_mode" IN ('Grid View', 'List View')),
 			"add_all_multimanga_mangas_to_download_integrations" boolean NOT NULL DEFAULT FALSE,
-			"enqueue_all_suwayomi_chapters_to_download" boolean NOT NULL DEFAULT TRUE
+			"enqueue_all_suwayomi_chapters_to_download" boolean NOT NULL DEFAULT TRUE,
+			"notification_title_template" text NOT NULL DEFAULT '',
+			"notification_message_template" text NOT NULL DEFAULT '',
+			"notification_tags_template" text NOT NULL DEFAULT '',
+			"notification_priority_template" text NOT NULL DEFAULT '',
+			"notification_click_url_template" text NOT NULL DEFAULT '',
+			"sources_proxy" text NOT NULL DEFAULT '',
+			"sources_proxies" text NOT NULL DEFAULT '{}'
 		);
 
 		CREATE TABLE IF NOT EXISTS "version" (
@@ -224,6 +335,8 @@ func CreateTables(db *sql.DB, log *zerolog.Logger) error {
         ALTER TABLE "mangas" ALTER COLUMN "last_read_chapter" TYPE integer;
         ALTER TABLE "mangas" ALTER COLUMN "url" TYPE text;
         ALTER TABLE "mangas" ALTER COLUMN "cover_img_url" TYPE text;
+        ALTER TABLE "mangas" ALTER COLUMN "preferred_group" TYPE varchar(255);
+        ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "preferred_language" varchar(255) NOT NULL DEFAULT '';
 		ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "last_released_chapter_name_selector" text;
 		ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "last_released_chapter_name_attribute" varchar(30);
 		ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "last_released_chapter_name_regex" varchar(255);
@@ -237,7 +350,41 @@ func CreateTables(db *sql.DB, log *zerolog.Logger) error {
 		ALTER TABLE "chapters" ADD COLUMN IF NOT EXISTS "from_source_site" boolean NOT NULL DEFAULT TRUE;
         ALTER TABLE "chapters" ALTER COLUMN "manga_id" DROP NOT NULL;
         ALTER TABLE "chapters" ALTER COLUMN "url" TYPE text;
+        ALTER TABLE "chapters" ADD COLUMN IF NOT EXISTS "volume" varchar(50) NOT NULL DEFAULT '';
+        ALTER TABLE "chapters" DROP CONSTRAINT IF EXISTS "chapters_pkey";
+        CREATE UNIQUE INDEX IF NOT EXISTS "chapters_url_type_unique" ON "chapters" ("url", "type") WHERE "url" <> '';
+        ALTER TABLE "chapters_history" ADD COLUMN IF NOT EXISTS "volume" varchar(50) NOT NULL DEFAULT '';
+        ALTER TABLE "user_multimangas" ADD COLUMN IF NOT EXISTS "last_read_chapter_volume" varchar(50);
         ALTER TABLE "multimangas" ALTER COLUMN "cover_img_url" TYPE text;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "update_interval" integer NOT NULL DEFAULT 0;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "release_weekday" smallint NOT NULL DEFAULT -1;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "release_day_update_interval" integer NOT NULL DEFAULT 0;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "last_checked_at" timestamp;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "update_last_attempt_at" timestamp;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "update_last_success_at" timestamp;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "update_failing_since" timestamp;
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "update_last_error" text NOT NULL DEFAULT '';
+        ALTER TABLE "multimangas" ADD COLUMN IF NOT EXISTS "update_consecutive_failures" integer NOT NULL DEFAULT 0;
+        ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "update_last_attempt_at" timestamp;
+        ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "update_last_success_at" timestamp;
+        ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "update_failing_since" timestamp;
+        ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "update_last_error" text NOT NULL DEFAULT '';
+        ALTER TABLE "mangas" ADD COLUMN IF NOT EXISTS "update_consecutive_failures" integer NOT NULL DEFAULT 0;
+		ALTER TABLE "configs" ADD COLUMN IF NOT EXISTS "notification_title_template" text NOT NULL DEFAULT '';
+		ALTER TABLE "configs" ADD COLUMN IF NOT EXISTS "notification_message_template" text NOT NULL DEFAULT '';
+		ALTER TABLE "configs" ADD COLUMN IF NOT EXISTS "notification_tags_template" text NOT NULL DEFAULT '';
+		ALTER TABLE "configs" ADD COLUMN IF NOT EXISTS "notification_priority_template" text NOT NULL DEFAULT '';
+		ALTER TABLE "configs" ADD COLUMN IF NOT EXISTS "notification_click_url_template" text NOT NULL DEFAULT '';
+		ALTER TABLE "configs" ADD COLUMN IF NOT EXISTS "sources_proxy" text NOT NULL DEFAULT '';
+		ALTER TABLE "configs" ADD COLUMN IF NOT EXISTS "sources_proxies" text NOT NULL DEFAULT '{}';
+		ALTER TABLE "api_tokens" ADD COLUMN IF NOT EXISTS "user_id" integer REFERENCES users(id) ON DELETE CASCADE;
+		ALTER TABLE "pending_notifications" ADD COLUMN IF NOT EXISTS "send_after" timestamp;
+
+        INSERT INTO chapters_history (manga_id, url, chapter, name, internal_id, updated_at, first_seen_at)
+        SELECT manga_id, url, chapter, name, internal_id, updated_at, COALESCE(updated_at, CURRENT_TIMESTAMP)
+        FROM chapters
+        WHERE type = 1 AND manga_id IS NOT NULL AND url IS NOT NULL AND chapter IS NOT NULL AND name IS NOT NULL
+        ON CONFLICT (manga_id, url) DO NOTHING;
 
         do $$
        	begin
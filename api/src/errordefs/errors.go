@@ -17,6 +17,21 @@ var (
 	ErrChapterNotFoundDB                    = &CustomError{Message: "chapter not found in DB"}
 	ErrAttemptedToRemoveLastMultiMangaManga = &CustomError{Message: "attempted to remove the last manga from a multimanga"}
 	ErrMultiMangaMangaListIsEmpty           = &CustomError{Message: "multimanga manga list is empty"}
+
+	ErrJobNotFound       = &CustomError{Message: "job not found"}
+	ErrJobAlreadyRunning = &CustomError{Message: "job is already running"}
+	ErrJobNotRunning     = &CustomError{Message: "job is not running"}
+
+	ErrAPITokenNotFoundDB             = &CustomError{Message: "API token not found in DB or already revoked"}
+	ErrUserNotFoundDB                 = &CustomError{Message: "user not found in DB"}
+	ErrUserAlreadyInDB                = &CustomError{Message: "user already exists in DB"}
+	ErrMultiMangaNotInUserLibrary     = &CustomError{Message: "multimanga not found in the user library"}
+	ErrMultiMangaAlreadyInUserLibrary = &CustomError{Message: "multimanga already exists in the user library"}
+
+	ErrInvalidLibraryDocument = &CustomError{Message: "invalid library document"}
+	ErrInvalidTrackerList     = &CustomError{Message: "invalid tracker list"}
+
+	ErrAniListLinkNotFoundDB = &CustomError{Message: "multimanga is not linked to an AniList media"}
 )
 
 // CustomError is a custom error
@@ -0,0 +1,21 @@
+// Package anilist implements the AniList tracker integration, which syncs
+// the status and progress of the multimangas linked to AniList medias.
+package anilist
+
+import (
+	"net/http"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+)
+
+type AniList struct {
+	c       *http.Client
+	Address string
+	Token   string
+}
+
+func (a *AniList) Init() {
+	a.c = &http.Client{}
+	a.Address = config.GlobalConfigs.AniList.Address
+	a.Token = config.GlobalConfigs.AniList.Token
+}
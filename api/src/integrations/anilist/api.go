@@ -0,0 +1,129 @@
+package anilist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+func (a *AniList) baseRequest(query string, variables map[string]any, target any) error {
+	errorContext := "error while making request"
+
+	payload := map[string]any{
+		"query":     query,
+		"variables": variables,
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return util.AddErrorContext(errorContext, util.AddErrorContext("error while marshalling payload", err))
+	}
+
+	req, err := http.NewRequest("POST", a.Address, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return util.AddErrorContext(errorContext, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+
+	resp, err := a.c.Do(req)
+	if err != nil {
+		return util.AddErrorContext(errorContext, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return util.AddErrorContext(errorContext, util.AddErrorContext("error while reading response body", err))
+	}
+
+	// AniList returns the GraphQL errors in the body, usually with a non-200 status code
+	var errorsResponse struct {
+		Errors []*graphQLError `json:"errors"`
+	}
+	if json.Unmarshal(body, &errorsResponse) == nil && len(errorsResponse.Errors) > 0 {
+		messages := make([]string, 0, len(errorsResponse.Errors))
+		for _, e := range errorsResponse.Errors {
+			messages = append(messages, e.Message)
+		}
+		return util.AddErrorContext(errorContext, fmt.Errorf("GraphQL errors (status code %d): %s", resp.StatusCode, strings.Join(messages, "; ")))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return util.AddErrorContext(errorContext, fmt.Errorf("non-200 status code -> (%d). Body: %s", resp.StatusCode, string(body)))
+	}
+
+	if target != nil {
+		err = json.Unmarshal(body, target)
+		if err != nil {
+			return util.AddErrorContext(errorContext, fmt.Errorf("error while decoding response: '%s'. Body: %s", err.Error(), string(body)))
+		}
+	}
+
+	return nil
+}
+
+// GetMediaListEntry returns the authenticated user's list entry of the manga media.
+// If the media is not in the user's list, returns nil.
+func (a *AniList) GetMediaListEntry(mediaID int) (*MediaListEntry, error) {
+	errorContext := "error while getting list entry of media '%d'"
+
+	query := `
+query ($mediaId: Int) {
+  Media(id: $mediaId, type: MANGA) {
+    id
+    mediaListEntry {
+      id
+      status
+      progress
+    }
+  }
+}
+	`
+	var response mediaResponse
+	err := a.baseRequest(query, map[string]any{"mediaId": mediaID}, &response)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mediaID), err)
+	}
+	if response.Data.Media == nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mediaID), fmt.Errorf("manga media not found"))
+	}
+
+	return response.Data.Media.MediaListEntry, nil
+}
+
+// SaveMediaListEntry creates or updates the authenticated user's list entry of the manga media.
+func (a *AniList) SaveMediaListEntry(mediaID int, status string, progress int) (*MediaListEntry, error) {
+	errorContext := "error while saving list entry of media '%d'"
+
+	query := `
+mutation ($mediaId: Int, $status: MediaListStatus, $progress: Int) {
+  SaveMediaListEntry(mediaId: $mediaId, status: $status, progress: $progress) {
+    id
+    status
+    progress
+  }
+}
+	`
+	variables := map[string]any{
+		"mediaId":  mediaID,
+		"status":   status,
+		"progress": progress,
+	}
+	var response saveMediaListEntryResponse
+	err := a.baseRequest(query, variables, &response)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mediaID), err)
+	}
+	if response.Data.SaveMediaListEntry == nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mediaID), fmt.Errorf("empty response"))
+	}
+
+	return response.Data.SaveMediaListEntry, nil
+}
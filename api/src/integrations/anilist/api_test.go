@@ -0,0 +1,230 @@
+package anilist
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/manga"
+)
+
+// mockServer is a mocked AniList GraphQL API with the list entries of a single media.
+type mockServer struct {
+	entry *MediaListEntry
+	// saves are the variables of the SaveMediaListEntry mutations received.
+	saves []map[string]any
+	// errorMessage, if set, is returned as a GraphQL error.
+	errorMessage string
+}
+
+func (s *mockServer) start(t *testing.T) *AniList {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization header '%s'", r.Header.Get("Authorization"))
+		}
+
+		var payload struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			t.Errorf("error decoding request payload: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if s.errorMessage != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{
+				"errors": []map[string]any{{"message": s.errorMessage, "status": 400}},
+				"data":   nil,
+			})
+			return
+		}
+
+		if strings.Contains(payload.Query, "SaveMediaListEntry") {
+			s.saves = append(s.saves, payload.Variables)
+			s.entry = &MediaListEntry{
+				ID:       1,
+				Status:   payload.Variables["status"].(string),
+				Progress: int(payload.Variables["progress"].(float64)),
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"SaveMediaListEntry": s.entry}})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"Media": map[string]any{
+			"id":             payload.Variables["mediaId"],
+			"mediaListEntry": s.entry,
+		}}})
+	}))
+	t.Cleanup(server.Close)
+
+	return &AniList{c: server.Client(), Address: server.URL, Token: "token"}
+}
+
+func TestGetMediaListEntry(t *testing.T) {
+	t.Run("Should get the media list entry", func(t *testing.T) {
+		server := &mockServer{entry: &MediaListEntry{ID: 1, Status: "CURRENT", Progress: 12}}
+		a := server.start(t)
+
+		entry, err := a.GetMediaListEntry(30013)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if entry == nil || entry.Status != "CURRENT" || entry.Progress != 12 {
+			t.Fatalf("Unexpected entry: %+v", entry)
+		}
+	})
+	t.Run("Should return nil if the media is not in the list", func(t *testing.T) {
+		server := &mockServer{}
+		a := server.start(t)
+
+		entry, err := a.GetMediaListEntry(30013)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if entry != nil {
+			t.Fatalf("Expected no entry, got %+v", entry)
+		}
+	})
+	t.Run("Should return the GraphQL errors", func(t *testing.T) {
+		server := &mockServer{errorMessage: "Invalid token"}
+		a := server.start(t)
+
+		_, err := a.GetMediaListEntry(30013)
+		if err == nil || !strings.Contains(err.Error(), "Invalid token") {
+			t.Fatalf("Expected the GraphQL error, got %v", err)
+		}
+	})
+}
+
+func TestPushMultiManga(t *testing.T) {
+	newMultiManga := func(status manga.Status, lastReadChapter string) *manga.MultiManga {
+		mm := &manga.MultiManga{ID: 1, Status: status}
+		if lastReadChapter != "" {
+			mm.LastReadChapter = &manga.Chapter{Chapter: lastReadChapter, Type: 2}
+		}
+		return mm
+	}
+
+	t.Run("Should create the list entry", func(t *testing.T) {
+		server := &mockServer{}
+		a := server.start(t)
+
+		err := a.PushMultiManga(newMultiManga(1, "10.5"), 30013)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(server.saves) != 1 || server.saves[0]["status"] != "CURRENT" || server.saves[0]["progress"] != float64(10) {
+			t.Fatalf("Unexpected saves: %v", server.saves)
+		}
+	})
+	t.Run("Should not decrease the progress", func(t *testing.T) {
+		server := &mockServer{entry: &MediaListEntry{ID: 1, Status: "CURRENT", Progress: 20}}
+		a := server.start(t)
+
+		err := a.PushMultiManga(newMultiManga(3, "15"), 30013)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(server.saves) != 1 || server.saves[0]["status"] != "PAUSED" || server.saves[0]["progress"] != float64(20) {
+			t.Fatalf("Unexpected saves: %v", server.saves)
+		}
+	})
+	t.Run("Should not save an entry that is already synced", func(t *testing.T) {
+		server := &mockServer{entry: &MediaListEntry{ID: 1, Status: "COMPLETED", Progress: 20}}
+		a := server.start(t)
+
+		err := a.PushMultiManga(newMultiManga(2, "20"), 30013)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(server.saves) != 0 {
+			t.Fatalf("Expected no saves, got %v", server.saves)
+		}
+	})
+	t.Run("Should return an error for an invalid status", func(t *testing.T) {
+		server := &mockServer{}
+		a := server.start(t)
+
+		err := a.PushMultiManga(newMultiManga(0, "1"), 30013)
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestPullMultiManga(t *testing.T) {
+	t.Run("Should not update the last read chapter if it's not lower than the progress", func(t *testing.T) {
+		server := &mockServer{entry: &MediaListEntry{ID: 1, Status: "CURRENT", Progress: 10}}
+		a := server.start(t)
+
+		mm := &manga.MultiManga{ID: 1, Status: 1, LastReadChapter: &manga.Chapter{Chapter: "10.5", Type: 2}}
+		updated, err := a.PullMultiManga(mm, 30013)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if updated {
+			t.Fatal("Expected the last read chapter to not be updated")
+		}
+	})
+	t.Run("Should not update the last read chapter if the media is not in the list", func(t *testing.T) {
+		server := &mockServer{}
+		a := server.start(t)
+
+		updated, err := a.PullMultiManga(&manga.MultiManga{ID: 1, Status: 1}, 30013)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if updated {
+			t.Fatal("Expected the last read chapter to not be updated")
+		}
+	})
+}
+
+func TestProgressChapter(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	mm := &manga.MultiManga{CurrentManga: &manga.Manga{
+		Source: "mangadex",
+		LastReleasedChapter: &manga.Chapter{
+			Chapter: "12.0",
+			Name:    "The End",
+			URL:     "https://mangadex.org/chapter/12",
+			Type:    1,
+		},
+	}}
+
+	t.Run("Should use the last released chapter if it's the progress chapter", func(t *testing.T) {
+		chapter := progressChapter(mm, 12, now)
+		if chapter.URL != "https://mangadex.org/chapter/12" || chapter.Name != "The End" || !chapter.FromSourceSite || chapter.Type != 2 || !chapter.UpdatedAt.Equal(now) {
+			t.Fatalf("Unexpected chapter: %+v", chapter)
+		}
+	})
+	t.Run("Should create a chapter without a source URL", func(t *testing.T) {
+		chapter := progressChapter(mm, 11, now)
+		if chapter.Chapter != "11" || chapter.Name != "Chapter 11" || !strings.HasPrefix(chapter.URL, manga.CustomMangaURLPrefix) || chapter.FromSourceSite || chapter.Type != 2 {
+			t.Fatalf("Unexpected chapter: %+v", chapter)
+		}
+	})
+}
+
+func TestChapterProgress(t *testing.T) {
+	testCases := []struct {
+		chapter  *manga.Chapter
+		expected int
+	}{
+		{nil, 0},
+		{&manga.Chapter{Chapter: "15"}, 15},
+		{&manga.Chapter{Chapter: "15.5"}, 15},
+		{&manga.Chapter{Chapter: "Oneshot"}, 0},
+	}
+	for _, tc := range testCases {
+		if progress := ChapterProgress(tc.chapter); progress != tc.expected {
+			t.Fatalf("Expected progress of %v to be %d, got %d", tc.chapter, tc.expected, progress)
+		}
+	}
+}
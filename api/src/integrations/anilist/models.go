@@ -0,0 +1,28 @@
+package anilist
+
+// MediaListEntry is an entry of the authenticated user's AniList manga list.
+type MediaListEntry struct {
+	Status   string `json:"status"`
+	ID       int    `json:"id"`
+	Progress int    `json:"progress"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
+
+type mediaResponse struct {
+	Data struct {
+		Media *struct {
+			MediaListEntry *MediaListEntry `json:"mediaListEntry"`
+			ID             int             `json:"id"`
+		} `json:"Media"`
+	} `json:"data"`
+}
+
+type saveMediaListEntryResponse struct {
+	Data struct {
+		SaveMediaListEntry *MediaListEntry `json:"SaveMediaListEntry"`
+	} `json:"data"`
+}
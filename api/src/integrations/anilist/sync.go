@@ -0,0 +1,159 @@
+package anilist
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// statusesToAniList maps the multimanga statuses to the AniList media list statuses.
+var statusesToAniList = map[manga.Status]string{
+	1: "CURRENT",
+	2: "COMPLETED",
+	3: "PAUSED",
+	4: "DROPPED",
+	5: "PLANNING",
+}
+
+// ChapterProgress returns the AniList progress of the chapter,
+// which is the integer part of the chapter number. If the chapter is a
+// range of chapters, the last chapter is used.
+// It returns 0 if the chapter is nil or doesn't have a number.
+func ChapterProgress(chapter *manga.Chapter) int {
+	if chapter == nil {
+		return 0
+	}
+	number := manga.ParseChapterNumber(chapter.Chapter)
+	if !number.Valid {
+		return 0
+	}
+
+	return int(math.Floor(number.End))
+}
+
+// resolveProgress returns the progress that both the multimanga and AniList
+// should have. On conflicts, the higher chapter is used.
+func resolveProgress(localProgress, remoteProgress int) int {
+	return max(localProgress, remoteProgress)
+}
+
+// PushMultiManga saves the multimanga's status and last read chapter in the media's list entry.
+// The entry's progress is never decreased, so if AniList has a higher progress, only the status is saved.
+func (a *AniList) PushMultiManga(mm *manga.MultiManga, mediaID int) error {
+	errorContext := "error while pushing multimanga with ID '%d' to AniList media '%d'"
+
+	status, ok := statusesToAniList[mm.Status]
+	if !ok {
+		return util.AddErrorContext(fmt.Sprintf(errorContext, mm.ID, mediaID), fmt.Errorf("invalid status %d", mm.Status))
+	}
+	progress := ChapterProgress(mm.LastReadChapter)
+
+	entry, err := a.GetMediaListEntry(mediaID)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(errorContext, mm.ID, mediaID), err)
+	}
+	if entry != nil {
+		progress = resolveProgress(progress, entry.Progress)
+		if entry.Status == status && entry.Progress == progress {
+			return nil
+		}
+	}
+
+	_, err = a.SaveMediaListEntry(mediaID, status, progress)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(errorContext, mm.ID, mediaID), err)
+	}
+
+	return nil
+}
+
+// PullMultiManga updates the multimanga's last read chapter in the database with the
+// media's list entry progress if it's higher than the multimanga's last read chapter.
+// The multimanga's status is not changed. Returns true if the last read chapter was updated.
+func (a *AniList) PullMultiManga(mm *manga.MultiManga, mediaID int) (bool, error) {
+	errorContext := "error while pulling progress of AniList media '%d' to multimanga with ID '%d'"
+
+	entry, err := a.GetMediaListEntry(mediaID)
+	if err != nil {
+		return false, util.AddErrorContext(fmt.Sprintf(errorContext, mediaID, mm.ID), err)
+	}
+	if entry == nil {
+		return false, nil
+	}
+
+	localProgress := ChapterProgress(mm.LastReadChapter)
+	if resolveProgress(localProgress, entry.Progress) == localProgress {
+		return false, nil
+	}
+
+	chapter := progressChapter(mm, entry.Progress, time.Now())
+	err = mm.UpsertChapterIntoDB(chapter)
+	if err != nil {
+		return false, util.AddErrorContext(fmt.Sprintf(errorContext, mediaID, mm.ID), err)
+	}
+
+	return true, nil
+}
+
+// PullLinkedMultiMangas pulls the progress of all multimangas linked to AniList medias.
+// It continues if a multimanga fails. Returns the number of multimangas updated and the errors.
+func (a *AniList) PullLinkedMultiMangas() (int, []error) {
+	links, err := manga.GetAniListLinksFromDB()
+	if err != nil {
+		return 0, []error{util.AddErrorContext("error while pulling progress from AniList", err)}
+	}
+
+	var updated int
+	var errors []error
+	for _, link := range links {
+		mm, err := manga.GetMultiMangaFromDB(link.MultiMangaID)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		pulled, err := a.PullMultiManga(mm, link.MediaID)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		if pulled {
+			updated++
+		}
+	}
+
+	return updated, errors
+}
+
+// progressChapter returns the last read chapter of the AniList progress.
+// If the multimanga's current manga last released chapter is the progress chapter,
+// it's used so the chapter has the source URL.
+func progressChapter(mm *manga.MultiManga, progress int, now time.Time) *manga.Chapter {
+	chapterStr := strconv.Itoa(progress)
+	chapter := &manga.Chapter{
+		Chapter:   chapterStr,
+		Name:      "Chapter " + chapterStr,
+		URL:       manga.CustomMangaURLPrefix + "/" + uuid.New().String(),
+		Type:      2,
+		UpdatedAt: now.Truncate(time.Second),
+	}
+
+	if mm.CurrentManga != nil && mm.CurrentManga.LastReleasedChapter != nil {
+		lastReleasedChapter := mm.CurrentManga.LastReleasedChapter
+		number := manga.ParseChapterNumber(lastReleasedChapter.Chapter)
+		if number.Valid && number.End == float64(progress) {
+			chapter.Chapter = lastReleasedChapter.Chapter
+			chapter.Name = lastReleasedChapter.Name
+			chapter.URL = lastReleasedChapter.URL
+			chapter.InternalID = lastReleasedChapter.InternalID
+			chapter.FromSourceSite = mm.CurrentManga.Source != manga.CustomMangaSource
+		}
+	}
+
+	return chapter
+}
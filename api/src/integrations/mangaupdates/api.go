@@ -0,0 +1,187 @@
+package mangaupdates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// listsPerPage is the number of series requested in each list page.
+const listsPerPage = 100
+
+// login logs in to MangaUpdates and saves the session token.
+func (m *MangaUpdates) login() error {
+	errorContext := "error while logging in to MangaUpdates"
+
+	payload := map[string]string{
+		"username": m.Username,
+		"password": m.Password,
+	}
+	var response loginResponse
+	_, err := m.baseRequest(http.MethodPut, "/v1/account/login", "", payload, &response)
+	if err != nil {
+		return util.AddErrorContext(errorContext, err)
+	}
+	if response.Context.SessionToken == "" {
+		return util.AddErrorContext(errorContext, fmt.Errorf("empty session token"))
+	}
+
+	sessionTokenMu.Lock()
+	sessionToken = response.Context.SessionToken
+	sessionTokenMu.Unlock()
+
+	return nil
+}
+
+// request makes an authenticated request to the MangaUpdates API, logging in if needed.
+// If the session token is rejected, logs in again and retries the request once.
+// It returns the response status code, also on errors.
+func (m *MangaUpdates) request(method, path string, reqBody, target any) (int, error) {
+	sessionTokenMu.Lock()
+	token := sessionToken
+	sessionTokenMu.Unlock()
+
+	if token != "" {
+		statusCode, err := m.baseRequest(method, path, token, reqBody, target)
+		if statusCode != http.StatusUnauthorized {
+			return statusCode, err
+		}
+	}
+
+	err := m.login()
+	if err != nil {
+		return 0, err
+	}
+	sessionTokenMu.Lock()
+	token = sessionToken
+	sessionTokenMu.Unlock()
+
+	return m.baseRequest(method, path, token, reqBody, target)
+}
+
+func (m *MangaUpdates) baseRequest(method, path, token string, reqBody, target any) (int, error) {
+	errorContext := fmt.Sprintf("error while making '%s' request to '%s'", method, path)
+
+	var body io.Reader
+	if reqBody != nil {
+		jsonData, err := json.Marshal(reqBody)
+		if err != nil {
+			return 0, util.AddErrorContext(errorContext, util.AddErrorContext("error while marshalling payload", err))
+		}
+		body = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequest(method, m.Address+path, body)
+	if err != nil {
+		return 0, util.AddErrorContext(errorContext, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := m.c.Do(req)
+	if err != nil {
+		return 0, util.AddErrorContext(errorContext, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, util.AddErrorContext(errorContext, util.AddErrorContext("error while reading response body", err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, util.AddErrorContext(errorContext, fmt.Errorf("non-200 status code -> (%d). Body: %s", resp.StatusCode, strings.ReplaceAll(string(respBody), "\n", "")))
+	}
+
+	if target != nil {
+		err = json.Unmarshal(respBody, target)
+		if err != nil {
+			return resp.StatusCode, util.AddErrorContext(errorContext, fmt.Errorf("error while decoding response: '%s'. Body: %s", err.Error(), string(respBody)))
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// GetListSeries returns the series in the user's lists. If the series is not in a list, returns nil.
+func (m *MangaUpdates) GetListSeries(seriesID int64) (*ListSeries, error) {
+	errorContext := "error while getting series '%d' from the lists"
+
+	var series ListSeries
+	statusCode, err := m.request(http.MethodGet, fmt.Sprintf("/v1/lists/series/%d", seriesID), nil, &series)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, seriesID), err)
+	}
+
+	return &series, nil
+}
+
+// AddListSeries adds the series to the list with the chapter as the read progress.
+func (m *MangaUpdates) AddListSeries(seriesID int64, listID, chapter int) error {
+	errorContext := "error while adding series '%d' to list '%d'"
+
+	_, err := m.request(http.MethodPost, "/v1/lists/series", []*listSeriesUpdate{newListSeriesUpdate(seriesID, listID, chapter)}, nil)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(errorContext, seriesID, listID), err)
+	}
+
+	return nil
+}
+
+// UpdateListSeries moves the series to the list and sets the chapter as the read progress.
+func (m *MangaUpdates) UpdateListSeries(seriesID int64, listID, chapter int) error {
+	errorContext := "error while updating series '%d' in list '%d'"
+
+	_, err := m.request(http.MethodPost, "/v1/lists/series/update", []*listSeriesUpdate{newListSeriesUpdate(seriesID, listID, chapter)}, nil)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(errorContext, seriesID, listID), err)
+	}
+
+	return nil
+}
+
+func newListSeriesUpdate(seriesID int64, listID, chapter int) *listSeriesUpdate {
+	update := &listSeriesUpdate{ListID: listID}
+	update.Series.ID = seriesID
+	update.Status.Chapter = chapter
+
+	return update
+}
+
+// GetList returns all series in the list.
+func (m *MangaUpdates) GetList(listID int) ([]*ListSeries, error) {
+	errorContext := "error while getting list '%d'"
+
+	series := []*ListSeries{}
+	for page := 1; ; page++ {
+		payload := map[string]int{
+			"page":    page,
+			"perpage": listsPerPage,
+		}
+		var response listSearchResponse
+		_, err := m.request(http.MethodPost, fmt.Sprintf("/v1/lists/%d/search", listID), payload, &response)
+		if err != nil {
+			return nil, util.AddErrorContext(fmt.Sprintf(errorContext, listID), err)
+		}
+		for _, result := range response.Results {
+			if result.Record != nil {
+				series = append(series, result.Record)
+			}
+		}
+
+		if len(response.Results) == 0 || page*listsPerPage >= response.TotalHits {
+			break
+		}
+	}
+
+	return series, nil
+}
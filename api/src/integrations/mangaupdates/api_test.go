@@ -0,0 +1,256 @@
+package mangaupdates
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/diogovalentte/mantium/api/src/manga"
+)
+
+// mockServer is a mocked MangaUpdates API with the user's list series.
+type mockServer struct {
+	series map[int64]*ListSeries
+	// logins is the number of login requests received.
+	logins int
+	// adds and updates are the series of the add/update list series requests received.
+	adds    []*listSeriesUpdate
+	updates []*listSeriesUpdate
+	// expireToken, if true, rejects the first session token.
+	expireToken bool
+}
+
+func (s *mockServer) start(t *testing.T) *MangaUpdates {
+	sessionTokenMu.Lock()
+	sessionToken = ""
+	sessionTokenMu.Unlock()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/v1/account/login" {
+			s.logins++
+			json.NewEncoder(w).Encode(map[string]any{"context": map[string]any{"session_token": fmt.Sprintf("token%d", s.logins)}})
+			return
+		}
+
+		validToken := "Bearer token1"
+		if s.expireToken {
+			validToken = "Bearer token2"
+		}
+		if r.Header.Get("Authorization") != validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/lists/series/"):
+			for id, series := range s.series {
+				if r.URL.Path == fmt.Sprintf("/v1/lists/series/%d", id) {
+					json.NewEncoder(w).Encode(series)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/v1/lists/series" || r.URL.Path == "/v1/lists/series/update":
+			var body []*listSeriesUpdate
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil {
+				t.Errorf("error decoding request body: %v", err)
+			}
+			if r.URL.Path == "/v1/lists/series" {
+				s.adds = append(s.adds, body...)
+			} else {
+				s.updates = append(s.updates, body...)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"status": "success"})
+		case strings.HasSuffix(r.URL.Path, "/search"):
+			var body struct {
+				Page    int `json:"page"`
+				PerPage int `json:"perpage"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil {
+				t.Errorf("error decoding request body: %v", err)
+			}
+			var listID int
+			fmt.Sscanf(r.URL.Path, "/v1/lists/%d/search", &listID)
+
+			var list []*ListSeries
+			for id := int64(1); id <= int64(len(s.series)); id++ {
+				if series, ok := s.series[id]; ok && series.ListID == listID {
+					list = append(list, series)
+				}
+			}
+			results := []map[string]any{}
+			for i := (body.Page - 1) * body.PerPage; i < len(list) && i < body.Page*body.PerPage; i++ {
+				results = append(results, map[string]any{"record": list[i]})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"results": results, "total_hits": len(list), "page": body.Page, "per_page": body.PerPage})
+		default:
+			t.Errorf("unexpected request '%s %s'", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return &MangaUpdates{c: server.Client(), Address: server.URL, Username: "user", Password: "password"}
+}
+
+func newListSeries(id int64, listID, chapter int) *ListSeries {
+	series := &ListSeries{ListID: listID}
+	series.Series.ID = id
+	series.Series.Title = fmt.Sprintf("Series %d", id)
+	series.Series.URL = fmt.Sprintf("https://www.mangaupdates.com/series/%d", id)
+	series.Status.Chapter = chapter
+
+	return series
+}
+
+func newMultiManga(status manga.Status, lastReadChapter string) *manga.MultiManga {
+	mm := &manga.MultiManga{ID: 1, Status: status, Mangas: []*manga.Manga{
+		{Source: "mangadex", InternalID: "abc"},
+		{Source: "mangaupdates", InternalID: "1"},
+	}}
+	if lastReadChapter != "" {
+		mm.LastReadChapter = &manga.Chapter{Chapter: lastReadChapter, Type: 2}
+	}
+	return mm
+}
+
+func TestGetListSeries(t *testing.T) {
+	t.Run("Should get the list series", func(t *testing.T) {
+		server := &mockServer{series: map[int64]*ListSeries{1: newListSeries(1, ReadingListID, 12)}}
+		m := server.start(t)
+
+		series, err := m.GetListSeries(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if series == nil || series.ListID != ReadingListID || series.Status.Chapter != 12 {
+			t.Fatalf("Unexpected series: %+v", series)
+		}
+		if server.logins != 1 {
+			t.Fatalf("Expected 1 login, got %d", server.logins)
+		}
+	})
+	t.Run("Should return nil if the series is not in a list", func(t *testing.T) {
+		server := &mockServer{}
+		m := server.start(t)
+
+		series, err := m.GetListSeries(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if series != nil {
+			t.Fatalf("Expected no series, got %+v", series)
+		}
+	})
+	t.Run("Should log in again if the session token is rejected", func(t *testing.T) {
+		server := &mockServer{series: map[int64]*ListSeries{1: newListSeries(1, ReadingListID, 12)}}
+		m := server.start(t)
+
+		_, err := m.GetListSeries(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		server.expireToken = true
+		series, err := m.GetListSeries(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if series == nil || server.logins != 2 {
+			t.Fatalf("Expected the series after 2 logins, got %+v after %d logins", series, server.logins)
+		}
+	})
+}
+
+func TestGetList(t *testing.T) {
+	t.Run("Should get all pages of the list", func(t *testing.T) {
+		server := &mockServer{series: map[int64]*ListSeries{}}
+		for id := int64(1); id <= listsPerPage+5; id++ {
+			server.series[id] = newListSeries(id, WishListID, 0)
+		}
+		server.series[listsPerPage+6] = newListSeries(listsPerPage+6, ReadingListID, 0)
+		m := server.start(t)
+
+		series, err := m.GetList(WishListID)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(series) != listsPerPage+5 {
+			t.Fatalf("Expected %d series, got %d", listsPerPage+5, len(series))
+		}
+	})
+}
+
+func TestPushMultiManga(t *testing.T) {
+	t.Run("Should add the series to the status list", func(t *testing.T) {
+		server := &mockServer{}
+		m := server.start(t)
+
+		pushed, err := m.PushMultiManga(newMultiManga(1, "10.5"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !pushed || len(server.adds) != 1 || server.adds[0].Series.ID != 1 || server.adds[0].ListID != ReadingListID || server.adds[0].Status.Chapter != 10 {
+			t.Fatalf("Unexpected adds: %+v", server.adds)
+		}
+	})
+	t.Run("Should move the series without decreasing the progress", func(t *testing.T) {
+		server := &mockServer{series: map[int64]*ListSeries{1: newListSeries(1, ReadingListID, 20)}}
+		m := server.start(t)
+
+		_, err := m.PushMultiManga(newMultiManga(4, "15"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(server.updates) != 1 || server.updates[0].ListID != UnfinishedListID || server.updates[0].Status.Chapter != 20 {
+			t.Fatalf("Unexpected updates: %+v", server.updates)
+		}
+	})
+	t.Run("Should not update a series that is already synced", func(t *testing.T) {
+		server := &mockServer{series: map[int64]*ListSeries{1: newListSeries(1, CompleteListID, 20)}}
+		m := server.start(t)
+
+		_, err := m.PushMultiManga(newMultiManga(2, "20"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(server.adds) != 0 || len(server.updates) != 0 {
+			t.Fatalf("Expected no adds or updates, got %+v and %+v", server.adds, server.updates)
+		}
+	})
+	t.Run("Should not push a multimanga without a MangaUpdates manga", func(t *testing.T) {
+		server := &mockServer{}
+		m := server.start(t)
+
+		mm := newMultiManga(1, "1")
+		mm.Mangas = mm.Mangas[:1]
+		pushed, err := m.PushMultiManga(mm)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if pushed || server.logins != 0 {
+			t.Fatalf("Expected the multimanga to not be pushed")
+		}
+	})
+}
+
+func TestListImportResults(t *testing.T) {
+	t.Run("Should match the series to the MangaUpdates source", func(t *testing.T) {
+		results := listImportResults([]*ListSeries{newListSeries(1, OnHoldListID, 7), {}})
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(results))
+		}
+		result := results[0]
+		if result.Entry.Status != 3 || result.Entry.ChaptersRead != 7 || result.Match == nil || result.Match.Source != "mangaupdates" || result.Match.InternalID != "1" {
+			t.Fatalf("Unexpected result: %+v %+v", result.Entry, result.Match)
+		}
+		if results[1].Match != nil || results[1].Error == "" {
+			t.Fatalf("Expected the series without an ID to need review, got %+v", results[1])
+		}
+	})
+}
@@ -0,0 +1,32 @@
+// Package mangaupdates implements the MangaUpdates lists integration, which syncs the
+// multimangas with MangaUpdates mangas to the user's MangaUpdates lists and imports the lists.
+// The MangaUpdates source is implemented in the sources/mangaupdates package.
+package mangaupdates
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+)
+
+var (
+	// sessionToken is the session token of the last login. It's shared by
+	// all instances and reused until the API rejects it.
+	sessionToken   string
+	sessionTokenMu sync.Mutex
+)
+
+type MangaUpdates struct {
+	c        *http.Client
+	Address  string
+	Username string
+	Password string
+}
+
+func (m *MangaUpdates) Init() {
+	m.c = &http.Client{}
+	m.Address = config.GlobalConfigs.MangaUpdates.Address
+	m.Username = config.GlobalConfigs.MangaUpdates.Username
+	m.Password = config.GlobalConfigs.MangaUpdates.Password
+}
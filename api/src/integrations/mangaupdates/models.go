@@ -0,0 +1,41 @@
+package mangaupdates
+
+// ListSeries is a series in one of the user's MangaUpdates lists.
+type ListSeries struct {
+	Series struct {
+		URL   string `json:"url"`
+		Title string `json:"title"`
+		ID    int64  `json:"id"`
+	} `json:"series"`
+	Status struct {
+		Volume  int `json:"volume"`
+		Chapter int `json:"chapter"`
+	} `json:"status"`
+	ListID int `json:"list_id"`
+}
+
+type loginResponse struct {
+	Context struct {
+		SessionToken string `json:"session_token"`
+	} `json:"context"`
+}
+
+type listSearchResponse struct {
+	Results []struct {
+		Record *ListSeries `json:"record"`
+	} `json:"results"`
+	TotalHits int `json:"total_hits"`
+	Page      int `json:"page"`
+	PerPage   int `json:"per_page"`
+}
+
+// listSeriesUpdate is the body of a series in the add/update list series requests.
+type listSeriesUpdate struct {
+	Series struct {
+		ID int64 `json:"id"`
+	} `json:"series"`
+	Status struct {
+		Chapter int `json:"chapter"`
+	} `json:"status"`
+	ListID int `json:"list_id"`
+}
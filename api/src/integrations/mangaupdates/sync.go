@@ -0,0 +1,181 @@
+package mangaupdates
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/diogovalentte/mantium/api/src/integrations/anilist"
+	"github.com/diogovalentte/mantium/api/src/library"
+	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// mangaUpdatesSource is the name of the MangaUpdates source.
+const mangaUpdatesSource = "mangaupdates"
+
+// IDs of the MangaUpdates default lists.
+const (
+	ReadingListID    = 0
+	WishListID       = 1
+	CompleteListID   = 2
+	UnfinishedListID = 3
+	OnHoldListID     = 4
+)
+
+// ListsStatuses maps the MangaUpdates default lists to the multimanga statuses.
+var ListsStatuses = map[int]manga.Status{
+	ReadingListID:    1,
+	CompleteListID:   2,
+	OnHoldListID:     3,
+	UnfinishedListID: 4,
+	WishListID:       5,
+}
+
+// statusesToLists maps the multimanga statuses to the MangaUpdates default lists.
+var statusesToLists = map[manga.Status]int{
+	1: ReadingListID,
+	2: CompleteListID,
+	3: OnHoldListID,
+	4: UnfinishedListID,
+	5: WishListID,
+}
+
+// SeriesID returns the MangaUpdates series ID of the multimanga, which is the internal ID
+// of its current manga if it's from MangaUpdates, or else of its first manga from MangaUpdates.
+// It returns 0 if the multimanga doesn't have a manga from MangaUpdates.
+func SeriesID(mm *manga.MultiManga) int64 {
+	mangas := mm.Mangas
+	if mm.CurrentManga != nil {
+		mangas = append([]*manga.Manga{mm.CurrentManga}, mangas...)
+	}
+	for _, m := range mangas {
+		if m.Source != mangaUpdatesSource {
+			continue
+		}
+		seriesID, err := strconv.ParseInt(m.InternalID, 10, 64)
+		if err == nil && seriesID > 0 {
+			return seriesID
+		}
+	}
+
+	return 0
+}
+
+// PushMultiManga adds the multimanga to the list of its status, with its last read chapter as
+// the read progress. If the series is already in a list, it's moved to the status list, and its
+// read progress is never decreased. Returns false if the multimanga doesn't have a manga from MangaUpdates.
+func (m *MangaUpdates) PushMultiManga(mm *manga.MultiManga) (bool, error) {
+	errorContext := "error while pushing multimanga with ID '%d' to MangaUpdates lists"
+
+	seriesID := SeriesID(mm)
+	if seriesID == 0 {
+		return false, nil
+	}
+	listID, ok := statusesToLists[mm.Status]
+	if !ok {
+		return false, util.AddErrorContext(fmt.Sprintf(errorContext, mm.ID), fmt.Errorf("invalid status %d", mm.Status))
+	}
+	chapter := anilist.ChapterProgress(mm.LastReadChapter)
+
+	series, err := m.GetListSeries(seriesID)
+	if err != nil {
+		return false, util.AddErrorContext(fmt.Sprintf(errorContext, mm.ID), err)
+	}
+	if series == nil {
+		err = m.AddListSeries(seriesID, listID, chapter)
+		if err != nil {
+			return false, util.AddErrorContext(fmt.Sprintf(errorContext, mm.ID), err)
+		}
+		return true, nil
+	}
+
+	chapter = max(chapter, series.Status.Chapter)
+	if series.ListID == listID && series.Status.Chapter == chapter {
+		return true, nil
+	}
+	err = m.UpdateListSeries(seriesID, listID, chapter)
+	if err != nil {
+		return false, util.AddErrorContext(fmt.Sprintf(errorContext, mm.ID), err)
+	}
+
+	return true, nil
+}
+
+// PushMultiMangas pushes the multimangas with a manga from MangaUpdates to the lists.
+// It continues if a multimanga fails. Returns the number of multimangas pushed and the errors.
+func (m *MangaUpdates) PushMultiMangas(multimangas []*manga.MultiManga) (int, []error) {
+	var pushed int
+	var errors []error
+	for _, mm := range multimangas {
+		ok, err := m.PushMultiManga(mm)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		if ok {
+			pushed++
+		}
+	}
+
+	return pushed, errors
+}
+
+// GetDefaultLists returns the series in the default lists (reading, wish, complete, unfinished, and on hold).
+func (m *MangaUpdates) GetDefaultLists() ([]*ListSeries, error) {
+	series := []*ListSeries{}
+	for _, listID := range []int{ReadingListID, WishListID, CompleteListID, UnfinishedListID, OnHoldListID} {
+		listSeries, err := m.GetList(listID)
+		if err != nil {
+			return nil, util.AddErrorContext("error while getting the default lists", err)
+		}
+		series = append(series, listSeries...)
+	}
+
+	return series, nil
+}
+
+// ImportLists adds the series in the default lists into the global library as multimangas
+// with the status of their list and their read progress as the last read chapter.
+// The series already in the library are skipped.
+func (m *MangaUpdates) ImportLists(dryRun bool) (*library.ListImportReport, error) {
+	series, err := m.GetDefaultLists()
+	if err != nil {
+		return nil, util.AddErrorContext("error while importing the MangaUpdates lists", err)
+	}
+
+	report, err := library.ImportMatchedList(listImportResults(series), library.ListFormatMangaUpdates, library.ListImportOptions{DryRun: dryRun})
+	if err != nil {
+		return nil, util.AddErrorContext("error while importing the MangaUpdates lists", err)
+	}
+
+	return report, nil
+}
+
+// listImportResults returns the list series as list import results
+// matched to the series in the MangaUpdates source.
+func listImportResults(series []*ListSeries) []*library.ListImportResult {
+	results := make([]*library.ListImportResult, 0, len(series))
+	for _, s := range series {
+		result := &library.ListImportResult{
+			Entry: &library.ListEntry{
+				Title:        s.Series.Title,
+				Status:       ListsStatuses[s.ListID],
+				ChaptersRead: max(s.Status.Chapter, 0),
+			},
+		}
+		if s.Series.ID > 0 && s.Series.URL != "" {
+			result.Match = &library.ListMatch{
+				Source:     mangaUpdatesSource,
+				URL:        s.Series.URL,
+				Name:       s.Series.Title,
+				InternalID: strconv.FormatInt(s.Series.ID, 10),
+				Confidence: 1,
+			}
+		} else {
+			result.Error = "series without ID or URL"
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
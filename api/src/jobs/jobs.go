@@ -0,0 +1,260 @@
+// Package jobs implements in-memory asynchronous jobs, like updating the mangas metadata,
+// that can be followed and canceled by the API clients while they run.
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+)
+
+// maxFinishedJobs is the maximum number of finished jobs kept in memory.
+const maxFinishedJobs = 50
+
+// Status is the status of a job.
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Item statuses, used to report the progress of each item processed by a job.
+const (
+	ItemStatusChecked    = "checked"
+	ItemStatusNewChapter = "new_chapter"
+	ItemStatusFailed     = "failed"
+)
+
+// Item is an item (e.g. a manga) processed by a job.
+type Item struct {
+	Name   string   `json:"name"`
+	Status string   `json:"status"`
+	Errors []string `json:"errors"`
+	ID     int      `json:"id"`
+}
+
+// Job is an asynchronous job.
+type Job struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// Errors are the errors that occurred while running the job, grouped by where they occurred.
+	Errors map[string][]string `json:"errors"`
+	ID     string              `json:"id"`
+	Type   string              `json:"type"`
+	Status Status              `json:"status"`
+	// Message is a summary of the job's result, set when the job finishes.
+	Message string `json:"message"`
+	// Result is the job's result, like an import report, if the job has one.
+	Result any `json:"result,omitempty"`
+	// Items are the items already processed by the job.
+	Items []*Item `json:"items"`
+	// Total is the number of items the job will process.
+	Total int `json:"total"`
+	// Processed is the number of items already processed by the job.
+	Processed int `json:"processed"`
+	cancel    context.CancelFunc
+	mu        sync.Mutex
+}
+
+var (
+	jobs   = map[string]*Job{}
+	jobsMu sync.Mutex
+)
+
+// NewJob creates and registers a new running job.
+// The returned context is canceled when the job is canceled or the parent context is done.
+func NewJob(parent context.Context, jobType string) (*Job, context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	job := &Job{
+		ID:        uuid.New().String(),
+		Type:      jobType,
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+		Errors:    map[string][]string{},
+		Items:     []*Item{},
+		cancel:    cancel,
+	}
+
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	jobs[job.ID] = job
+	removeOldJobs()
+
+	return job, ctx
+}
+
+// GetJob returns a copy of a job.
+func GetJob(id string) (*Job, error) {
+	jobsMu.Lock()
+	job, ok := jobs[id]
+	jobsMu.Unlock()
+	if !ok {
+		return nil, errordefs.ErrJobNotFound
+	}
+
+	return job.copy(), nil
+}
+
+// GetJobs returns a copy of all jobs, sorted by the start time, desc.
+func GetJobs() []*Job {
+	jobsMu.Lock()
+	allJobs := make([]*Job, 0, len(jobs))
+	for _, job := range jobs {
+		allJobs = append(allJobs, job)
+	}
+	jobsMu.Unlock()
+
+	copies := make([]*Job, len(allJobs))
+	for i, job := range allJobs {
+		copies[i] = job.copy()
+	}
+	sort.Slice(copies, func(i, j int) bool {
+		return copies[i].StartedAt.After(copies[j].StartedAt)
+	})
+
+	return copies
+}
+
+// CancelJob cancels a running job.
+// The job stops as soon as it finishes processing its current items.
+func CancelJob(id string) error {
+	jobsMu.Lock()
+	job, ok := jobs[id]
+	jobsMu.Unlock()
+	if !ok {
+		return errordefs.ErrJobNotFound
+	}
+
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.Status != StatusRunning {
+		return errordefs.ErrJobNotRunning
+	}
+	job.cancel()
+
+	return nil
+}
+
+// SetTotal sets the number of items the job will process.
+func (j *Job) SetTotal(total int) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.Total = total
+}
+
+// SetResult sets the job's result. It should not be changed after being set.
+func (j *Job) SetResult(result any) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.Result = result
+}
+
+// AddItem reports that an item was processed by the job.
+func (j *Job) AddItem(item *Item) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.Items = append(j.Items, item)
+	j.Processed++
+}
+
+// Finish sets the job as finished. The job status is set to canceled if the job's
+// context was canceled, failed if err is not nil or there are errors, else completed.
+func (j *Job) Finish(ctx context.Context, errors map[string][]string, err error, message string) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.FinishedAt = time.Now()
+	if errors != nil {
+		j.Errors = errors
+	}
+	j.Message = message
+	j.Status = StatusCompleted
+	if err != nil {
+		j.Status = StatusFailed
+		j.Message = err.Error()
+	} else {
+		for _, errSlice := range j.Errors {
+			if len(errSlice) > 0 {
+				j.Status = StatusFailed
+				break
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		j.Status = StatusCanceled
+	}
+	j.cancel()
+}
+
+// copy returns a copy of the job that can be safely used by other goroutines.
+func (j *Job) copy() *Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job := &Job{
+		ID:         j.ID,
+		Type:       j.Type,
+		Status:     j.Status,
+		Message:    j.Message,
+		Result:     j.Result,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		Total:      j.Total,
+		Processed:  j.Processed,
+		Errors:     make(map[string][]string, len(j.Errors)),
+		Items:      make([]*Item, len(j.Items)),
+	}
+	for k, v := range j.Errors {
+		job.Errors[k] = append([]string{}, v...)
+	}
+	for i, item := range j.Items {
+		itemCopy := *item
+		itemCopy.Errors = append([]string{}, item.Errors...)
+		job.Items[i] = &itemCopy
+	}
+
+	return job
+}
+
+// removeOldJobs removes the oldest finished jobs if there are more than maxFinishedJobs.
+// jobsMu should be locked by the caller.
+func removeOldJobs() {
+	finishedJobs := []*Job{}
+	for _, job := range jobs {
+		job.mu.Lock()
+		if job.Status != StatusRunning {
+			finishedJobs = append(finishedJobs, job)
+		}
+		job.mu.Unlock()
+	}
+	if len(finishedJobs) <= maxFinishedJobs {
+		return
+	}
+
+	sort.Slice(finishedJobs, func(i, j int) bool {
+		return finishedJobs[i].StartedAt.Before(finishedJobs[j].StartedAt)
+	})
+	for _, job := range finishedJobs[:len(finishedJobs)-maxFinishedJobs] {
+		delete(jobs, job.ID)
+	}
+}
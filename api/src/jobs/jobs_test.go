@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+)
+
+func TestJobLifeCycle(t *testing.T) {
+	t.Run("Should report progress and complete job", func(t *testing.T) {
+		job, ctx := NewJob(context.Background(), "test")
+		job.SetTotal(2)
+		job.AddItem(&Item{ID: 1, Name: "manga 1", Status: ItemStatusChecked})
+		job.AddItem(&Item{ID: 2, Name: "manga 2", Status: ItemStatusNewChapter})
+
+		gotJob, err := GetJob(job.ID)
+		if err != nil {
+			t.Fatalf("error getting job: %v", err)
+		}
+		if gotJob.Status != StatusRunning || gotJob.Processed != 2 || gotJob.Total != 2 || len(gotJob.Items) != 2 {
+			t.Fatalf("unexpected job progress: %+v", gotJob)
+		}
+
+		job.SetResult("report")
+		job.Finish(ctx, map[string][]string{"manga_metadata": {}}, nil, "done")
+		gotJob, err = GetJob(job.ID)
+		if err != nil {
+			t.Fatalf("error getting job: %v", err)
+		}
+		if gotJob.Status != StatusCompleted || gotJob.FinishedAt.IsZero() {
+			t.Fatalf("expected job to be completed, got %+v", gotJob)
+		}
+		if gotJob.Result != "report" {
+			t.Fatalf("expected job result to be 'report', got %v", gotJob.Result)
+		}
+		if err = CancelJob(job.ID); err != errordefs.ErrJobNotRunning {
+			t.Fatalf("expected job not running error, got %v", err)
+		}
+	})
+	t.Run("Should set job as failed if there are errors", func(t *testing.T) {
+		job, ctx := NewJob(context.Background(), "test")
+		job.Finish(ctx, map[string][]string{"ntfy": {"error notifying"}}, nil, "done")
+		gotJob, err := GetJob(job.ID)
+		if err != nil {
+			t.Fatalf("error getting job: %v", err)
+		}
+		if gotJob.Status != StatusFailed {
+			t.Fatalf("expected job to be failed, got %s", gotJob.Status)
+		}
+	})
+	t.Run("Should cancel job", func(t *testing.T) {
+		job, ctx := NewJob(context.Background(), "test")
+		if err := CancelJob(job.ID); err != nil {
+			t.Fatalf("error canceling job: %v", err)
+		}
+		if ctx.Err() == nil {
+			t.Fatal("expected job context to be canceled")
+		}
+		job.Finish(ctx, nil, nil, "canceled")
+		gotJob, err := GetJob(job.ID)
+		if err != nil {
+			t.Fatalf("error getting job: %v", err)
+		}
+		if gotJob.Status != StatusCanceled {
+			t.Fatalf("expected job to be canceled, got %s", gotJob.Status)
+		}
+	})
+	t.Run("Should not get job that doesn't exist", func(t *testing.T) {
+		if _, err := GetJob("not-a-job"); err != errordefs.ErrJobNotFound {
+			t.Fatalf("expected job not found error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package library
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/notifiers"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// ImportMode is how the library document is imported.
+type ImportMode string
+
+const (
+	// ImportModeMerge adds the document's multimangas that are not in the library.
+	// A multimanga is in the library if one of its mangas is in the library.
+	ImportModeMerge ImportMode = "merge"
+	// ImportModeReplace deletes all multimangas in the library and adds the document's multimangas.
+	ImportModeReplace ImportMode = "replace"
+)
+
+// ImportModes are the valid import modes.
+var ImportModes = []ImportMode{ImportModeMerge, ImportModeReplace}
+
+// ImportReport is the result of a library import.
+// In a dry run, it's what would happen if the document was imported.
+type ImportReport struct {
+	Mode ImportMode `json:"mode"`
+	// Added are the names of the current mangas of the added multimangas.
+	Added []string `json:"added"`
+	// Skipped are the names of the current mangas of the multimangas
+	// not added because they're already in the library.
+	Skipped []string `json:"skipped"`
+	// Deleted is the number of multimangas deleted from the library.
+	Deleted         int  `json:"deleted"`
+	ConfigsImported bool `json:"configsImported"`
+	DryRun          bool `json:"dryRun"`
+}
+
+// Import imports the library document into the database using the mode.
+// If dryRun is true, validates the document and returns the report without changing the database.
+// Document validation errors contain errordefs.ErrInvalidLibraryDocument.
+func Import(doc *Document, mode ImportMode, dryRun bool) (*ImportReport, error) {
+	contextError := "error importing library document"
+
+	if !slices.Contains(ImportModes, mode) {
+		return nil, util.AddErrorContext(contextError, fmt.Errorf("invalid import mode '%s', should be one of %v", mode, ImportModes))
+	}
+
+	err := doc.Validate()
+	if err == nil && doc.Configs != nil {
+		err = notifiers.ValidateNotificationTemplates(doc.Configs.Notifications)
+	}
+	if err == nil && doc.Configs != nil {
+		err = config.ValidateProxiesConfigs(&doc.Configs.Proxies)
+	}
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, util.AddErrorContext(errordefs.ErrInvalidLibraryDocument.Error(), err))
+	}
+
+	existingMultiMangas, err := manga.GetMultiMangasDB(true)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	existingURLs := map[string]bool{}
+	for _, mm := range existingMultiMangas {
+		for _, m := range mm.Mangas {
+			existingURLs[m.URL] = true
+		}
+	}
+
+	report := &ImportReport{
+		Mode:            mode,
+		DryRun:          dryRun,
+		Added:           []string{},
+		Skipped:         []string{},
+		ConfigsImported: doc.Configs != nil,
+	}
+	if mode == ImportModeReplace {
+		report.Deleted = len(existingMultiMangas)
+	}
+
+	multimangas := []*manga.MultiManga{}
+	for _, docMM := range doc.MultiMangas {
+		currentMangaName := docMM.currentMangaName()
+		if mode == ImportModeMerge && docMM.hasMangaIn(existingURLs) {
+			report.Skipped = append(report.Skipped, currentMangaName)
+			continue
+		}
+		report.Added = append(report.Added, currentMangaName)
+
+		if dryRun {
+			continue
+		}
+		mm, err := docMM.toMultiManga()
+		if err != nil {
+			return nil, util.AddErrorContext(contextError, err)
+		}
+		multimangas = append(multimangas, mm)
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	err = manga.ImportMultiMangasIntoDB(multimangas, mode == ImportModeReplace)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+
+	if doc.Configs != nil {
+		err = config.SaveConfigsToDB(doc.Configs)
+		if err != nil {
+			return nil, util.AddErrorContext(contextError+": multimangas imported, but error saving configs", err)
+		}
+		err = config.LoadConfigsFromDB(config.GlobalConfigs.DashboardConfigs)
+		if err != nil {
+			return nil, util.AddErrorContext(contextError+": multimangas and configs imported, but error loading new configs", err)
+		}
+	}
+
+	return report, nil
+}
+
+func (mm *MultiManga) currentMangaName() string {
+	for _, m := range mm.Mangas {
+		if m.URL == mm.CurrentMangaURL {
+			return m.Name
+		}
+	}
+
+	return ""
+}
+
+func (mm *MultiManga) hasMangaIn(urls map[string]bool) bool {
+	for _, m := range mm.Mangas {
+		if urls[m.URL] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toMultiManga returns the document multimanga as a multimanga that can be inserted into the database.
+// Mangas without cover image get the default cover image, which is updated by the update job
+// for mangas from sources.
+func (mm *MultiManga) toMultiManga() (*manga.MultiManga, error) {
+	multimanga := &manga.MultiManga{
+		Status:                   mm.Status,
+		LastReadChapter:          mm.LastReadChapter.toChapter(2),
+		CoverImgFixed:            mm.CoverImgFixed,
+		CoverImgURL:              mm.CoverImgURL,
+		CoverImg:                 mm.CoverImg,
+		CoverImgResized:          mm.CoverImgResized,
+		UpdateInterval:           mm.UpdateInterval,
+		ReleaseWeekday:           mm.ReleaseWeekday,
+		ReleaseDayUpdateInterval: mm.ReleaseDayUpdateInterval,
+	}
+	if multimanga.CoverImg == nil {
+		multimanga.CoverImg = []byte{}
+	}
+
+	for _, m := range mm.Mangas {
+		importManga := &manga.Manga{
+			Source:                                m.Source,
+			URL:                                   m.URL,
+			Name:                                  m.Name,
+			InternalID:                            m.InternalID,
+			PreferredGroup:                        m.PreferredGroup,
+			PreferredLanguage:                     m.PreferredLanguage,
+			Status:                                mm.Status,
+			CoverImgURL:                           m.CoverImgURL,
+			CoverImg:                              m.CoverImg,
+			CoverImgResized:                       m.CoverImgResized,
+			LastReleasedChapter:                   m.LastReleasedChapter.toChapter(1),
+			LastReleasedChapterNameSelector:       m.LastReleasedChapterNameSelector,
+			LastReleasedChapterURLSelector:        m.LastReleasedChapterURLSelector,
+			LastReleasedChapterSelectorUseBrowser: m.LastReleasedChapterSelectorUseBrowser,
+		}
+		if len(importManga.CoverImg) == 0 {
+			defaultCoverImg, err := util.GetDefaultCoverImg()
+			if err != nil {
+				return nil, err
+			}
+			importManga.CoverImg = defaultCoverImg
+			importManga.CoverImgResized = true
+		}
+
+		multimanga.Mangas = append(multimanga.Mangas, importManga)
+		if m.URL == mm.CurrentMangaURL {
+			multimanga.CurrentManga = importManga
+		}
+	}
+
+	return multimanga, nil
+}
@@ -0,0 +1,250 @@
+// Package library implements the export and import of the library
+// in a versioned JSON document, used for backups and migrations between instances.
+package library
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/manga"
+)
+
+// FormatVersion is the version of the library document format.
+// It should be increased when the format changes in a way that
+// older versions of the import can't handle.
+const FormatVersion = 1
+
+// Document is the library document, with the multimangas and the dashboard configs.
+type Document struct {
+	ExportedAt time.Time `json:"exportedAt"`
+	// Configs are the dashboard configs. The allowed sources and adding methods
+	// are not imported, as they're set using environment variables.
+	Configs        *config.DashboardConfigs `json:"configs,omitempty"`
+	MantiumVersion string                   `json:"mantiumVersion"`
+	MultiMangas    []*MultiManga            `json:"multimangas"`
+	Version        int                      `json:"version"`
+}
+
+// MultiManga is a multimanga in the library document.
+type MultiManga struct {
+	LastReadChapter *Chapter `json:"lastReadChapter,omitempty"`
+	// CurrentMangaURL is the URL of the multimanga's current manga.
+	CurrentMangaURL string `json:"currentMangaURL"`
+	// CoverImgURL and CoverImg are only exported if the cover image is fixed.
+	CoverImgURL              string       `json:"coverImgURL,omitempty"`
+	CoverImg                 []byte       `json:"coverImg,omitempty"`
+	Mangas                   []*Manga     `json:"mangas"`
+	Status                   manga.Status `json:"status"`
+	UpdateInterval           int          `json:"updateInterval"`
+	ReleaseWeekday           int          `json:"releaseWeekday"`
+	ReleaseDayUpdateInterval int          `json:"releaseDayUpdateInterval"`
+	CoverImgResized          bool         `json:"coverImgResized,omitempty"`
+	CoverImgFixed            bool         `json:"coverImgFixed"`
+}
+
+// Manga is a manga of a multimanga in the library document.
+type Manga struct {
+	LastReleasedChapter             *Chapter            `json:"lastReleasedChapter,omitempty"`
+	LastReleasedChapterNameSelector *manga.HTMLSelector `json:"lastReleasedChapterNameSelector,omitempty"`
+	LastReleasedChapterURLSelector  *manga.HTMLSelector `json:"lastReleasedChapterURLSelector,omitempty"`
+	Source                          string              `json:"source"`
+	URL                             string              `json:"url"`
+	Name                            string              `json:"name"`
+	InternalID                      string              `json:"internalID,omitempty"`
+	PreferredGroup                  string              `json:"preferredGroup,omitempty"`
+	PreferredLanguage               string              `json:"preferredLanguage,omitempty"`
+	CoverImgURL                     string              `json:"coverImgURL,omitempty"`
+	// CoverImg is always exported for custom mangas, as they can't get it from a source.
+	// For other mangas, it's only exported if requested.
+	CoverImg                              []byte `json:"coverImg,omitempty"`
+	CoverImgResized                       bool   `json:"coverImgResized,omitempty"`
+	LastReleasedChapterSelectorUseBrowser bool   `json:"lastReleasedChapterSelectorUseBrowser,omitempty"`
+}
+
+// Chapter is a chapter in the library document.
+type Chapter struct {
+	UpdatedAt      time.Time `json:"updatedAt"`
+	URL            string    `json:"url"`
+	Chapter        string    `json:"chapter"`
+	Volume         string    `json:"volume,omitempty"`
+	Name           string    `json:"name"`
+	InternalID     string    `json:"internalID,omitempty"`
+	FromSourceSite bool      `json:"fromSourceSite"`
+}
+
+// Export returns the library document with the multimangas and configs.
+// If includeCoverImgs is true, the cover images of all mangas are exported,
+// else only the custom mangas and the fixed multimangas cover images are exported.
+func Export(multimangas []*manga.MultiManga, configs *config.DashboardConfigs, includeCoverImgs bool) *Document {
+	doc := &Document{
+		Version:     FormatVersion,
+		ExportedAt:  time.Now().Truncate(time.Second),
+		Configs:     configs,
+		MultiMangas: make([]*MultiManga, 0, len(multimangas)),
+	}
+	if configs != nil {
+		doc.MantiumVersion = configs.Mantium.Version
+	}
+
+	for _, mm := range multimangas {
+		exportMM := &MultiManga{
+			Status:                   mm.Status,
+			LastReadChapter:          exportChapter(mm.LastReadChapter),
+			CoverImgFixed:            mm.CoverImgFixed,
+			UpdateInterval:           mm.UpdateInterval,
+			ReleaseWeekday:           mm.ReleaseWeekday,
+			ReleaseDayUpdateInterval: mm.ReleaseDayUpdateInterval,
+			Mangas:                   make([]*Manga, 0, len(mm.Mangas)),
+		}
+		if mm.CurrentManga != nil {
+			exportMM.CurrentMangaURL = mm.CurrentManga.URL
+		}
+		if mm.CoverImgFixed {
+			exportMM.CoverImgURL = mm.CoverImgURL
+			exportMM.CoverImg = mm.CoverImg
+			exportMM.CoverImgResized = mm.CoverImgResized
+		}
+
+		for _, m := range mm.Mangas {
+			exportManga := &Manga{
+				Source:                                m.Source,
+				URL:                                   m.URL,
+				Name:                                  m.Name,
+				InternalID:                            m.InternalID,
+				PreferredGroup:                        m.PreferredGroup,
+				PreferredLanguage:                     m.PreferredLanguage,
+				CoverImgURL:                           m.CoverImgURL,
+				LastReleasedChapter:                   exportChapter(m.LastReleasedChapter),
+				LastReleasedChapterSelectorUseBrowser: m.LastReleasedChapterSelectorUseBrowser,
+			}
+			if m.Source == manga.CustomMangaSource || includeCoverImgs {
+				exportManga.CoverImg = m.CoverImg
+				exportManga.CoverImgResized = m.CoverImgResized
+			}
+			if m.LastReleasedChapterNameSelector != nil && m.LastReleasedChapterNameSelector.Selector != "" {
+				exportManga.LastReleasedChapterNameSelector = m.LastReleasedChapterNameSelector
+			}
+			if m.LastReleasedChapterURLSelector != nil && m.LastReleasedChapterURLSelector.Selector != "" {
+				exportManga.LastReleasedChapterURLSelector = m.LastReleasedChapterURLSelector
+			}
+			exportMM.Mangas = append(exportMM.Mangas, exportManga)
+		}
+
+		doc.MultiMangas = append(doc.MultiMangas, exportMM)
+	}
+
+	return doc
+}
+
+func exportChapter(chapter *manga.Chapter) *Chapter {
+	if chapter == nil {
+		return nil
+	}
+
+	return &Chapter{
+		URL:            chapter.URL,
+		Chapter:        chapter.Chapter,
+		Volume:         chapter.Volume,
+		Name:           chapter.Name,
+		InternalID:     chapter.InternalID,
+		UpdatedAt:      chapter.UpdatedAt,
+		FromSourceSite: chapter.FromSourceSite,
+	}
+}
+
+// toChapter returns the document chapter as a manga chapter of the type.
+func (c *Chapter) toChapter(chapterType manga.Type) *manga.Chapter {
+	if c == nil {
+		return nil
+	}
+
+	return &manga.Chapter{
+		URL:            c.URL,
+		Chapter:        c.Chapter,
+		Volume:         c.Volume,
+		Name:           c.Name,
+		InternalID:     c.InternalID,
+		UpdatedAt:      c.UpdatedAt,
+		FromSourceSite: c.FromSourceSite,
+		Type:           chapterType,
+	}
+}
+
+// Validate validates the document, without checking the database.
+func (doc *Document) Validate() error {
+	if doc.Version < 1 || doc.Version > FormatVersion {
+		return fmt.Errorf("unsupported library document version %d, should be between 1 and %d", doc.Version, FormatVersion)
+	}
+
+	seenURLs := map[string]bool{}
+	for i, mm := range doc.MultiMangas {
+		if mm == nil {
+			return fmt.Errorf("multimanga %d is null", i)
+		}
+		err := mm.validate(seenURLs)
+		if err != nil {
+			return fmt.Errorf("multimanga %d: %s", i, err.Error())
+		}
+	}
+
+	if doc.Configs != nil {
+		err := config.ValidateDisplayConfigs(doc.Configs)
+		if err != nil {
+			return fmt.Errorf("configs: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (mm *MultiManga) validate(seenURLs map[string]bool) error {
+	err := manga.ValidateStatus(mm.Status)
+	if err != nil {
+		return err
+	}
+	if len(mm.Mangas) == 0 {
+		return fmt.Errorf("multimanga has no mangas")
+	}
+	err = validateChapter(mm.LastReadChapter)
+	if err != nil {
+		return fmt.Errorf("last read chapter: %s", err.Error())
+	}
+
+	var currentMangaFound bool
+	for _, m := range mm.Mangas {
+		if m == nil {
+			return fmt.Errorf("manga is null")
+		}
+		if m.Source == "" || m.URL == "" || m.Name == "" {
+			return fmt.Errorf("manga source, URL, and name are required")
+		}
+		if seenURLs[m.URL] {
+			return fmt.Errorf("manga with URL '%s' is in more than one multimanga or more than once in the multimanga", m.URL)
+		}
+		seenURLs[m.URL] = true
+		err = validateChapter(m.LastReleasedChapter)
+		if err != nil {
+			return fmt.Errorf("manga '%s' last released chapter: %s", m.URL, err.Error())
+		}
+		if m.URL == mm.CurrentMangaURL {
+			currentMangaFound = true
+		}
+	}
+	if !currentMangaFound {
+		return fmt.Errorf("current manga URL '%s' is not the URL of one of the multimanga mangas", mm.CurrentMangaURL)
+	}
+
+	return nil
+}
+
+func validateChapter(chapter *Chapter) error {
+	if chapter == nil {
+		return nil
+	}
+	if chapter.Chapter == "" || chapter.Name == "" || chapter.URL == "" {
+		return fmt.Errorf("chapter, name, and URL are required")
+	}
+
+	return nil
+}
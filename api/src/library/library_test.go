@@ -0,0 +1,156 @@
+package library
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/manga"
+)
+
+func getTestMultiManga() *manga.MultiManga {
+	currentManga := &manga.Manga{
+		Source:      "mangadex",
+		URL:         "https://mangadex.org/title/1",
+		Name:        "Manga 1",
+		InternalID:  "1",
+		CoverImgURL: "https://mangadex.org/covers/1.jpg",
+		CoverImg:    []byte("source cover"),
+		LastReleasedChapter: &manga.Chapter{
+			URL:       "https://mangadex.org/chapter/10",
+			Chapter:   "10",
+			Name:      "Chapter 10",
+			UpdatedAt: time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC),
+			Type:      1,
+		},
+	}
+	otherManga := &manga.Manga{
+		Source:   manga.CustomMangaSource,
+		URL:      "https://example.com/manga-1",
+		Name:     "Manga 1 Custom",
+		CoverImg: []byte("custom cover"),
+		LastReleasedChapterNameSelector: &manga.HTMLSelector{
+			Selector: ".chapter",
+		},
+		LastReleasedChapterURLSelector: &manga.HTMLSelector{},
+	}
+
+	return &manga.MultiManga{
+		ID:           1,
+		Status:       1,
+		CurrentManga: currentManga,
+		Mangas:       []*manga.Manga{currentManga, otherManga},
+		LastReadChapter: &manga.Chapter{
+			URL:            "https://mangadex.org/chapter/9",
+			Chapter:        "9",
+			Name:           "Chapter 9",
+			Type:           2,
+			FromSourceSite: true,
+		},
+		CoverImgFixed:  true,
+		CoverImg:       []byte("fixed cover"),
+		CoverImgURL:    "https://example.com/cover.jpg",
+		UpdateInterval: 60,
+		ReleaseWeekday: -1,
+	}
+}
+
+func TestExport(t *testing.T) {
+	t.Run("Should export the multimangas", func(t *testing.T) {
+		doc := Export([]*manga.MultiManga{getTestMultiManga()}, nil, false)
+		if doc.Version != FormatVersion {
+			t.Fatalf("Expected version %d, got %d", FormatVersion, doc.Version)
+		}
+		if len(doc.MultiMangas) != 1 {
+			t.Fatalf("Expected 1 multimanga, got %d", len(doc.MultiMangas))
+		}
+
+		mm := doc.MultiMangas[0]
+		if mm.CurrentMangaURL != "https://mangadex.org/title/1" || mm.Status != 1 || mm.UpdateInterval != 60 {
+			t.Fatalf("Unexpected multimanga: %+v", mm)
+		}
+		if string(mm.CoverImg) != "fixed cover" {
+			t.Fatalf("Expected the fixed cover image to be exported")
+		}
+		if mm.LastReadChapter == nil || mm.LastReadChapter.Chapter != "9" {
+			t.Fatalf("Unexpected last read chapter: %+v", mm.LastReadChapter)
+		}
+		if mm.Mangas[0].CoverImg != nil {
+			t.Fatalf("Expected the source manga cover image to not be exported")
+		}
+		if string(mm.Mangas[1].CoverImg) != "custom cover" {
+			t.Fatalf("Expected the custom manga cover image to be exported")
+		}
+		if mm.Mangas[1].LastReleasedChapterNameSelector == nil || mm.Mangas[1].LastReleasedChapterURLSelector != nil {
+			t.Fatalf("Expected only the custom manga name selector to be exported")
+		}
+		if err := doc.Validate(); err != nil {
+			t.Fatalf("Expected the exported document to be valid: %v", err)
+		}
+	})
+	t.Run("Should export all cover images if requested", func(t *testing.T) {
+		doc := Export([]*manga.MultiManga{getTestMultiManga()}, nil, true)
+		if string(doc.MultiMangas[0].Mangas[0].CoverImg) != "source cover" {
+			t.Fatalf("Expected the source manga cover image to be exported")
+		}
+	})
+}
+
+func TestDocumentValidate(t *testing.T) {
+	testCases := []struct {
+		modify func(doc *Document)
+		name   string
+	}{
+		{name: "Unsupported version", modify: func(doc *Document) { doc.Version = FormatVersion + 1 }},
+		{name: "Invalid status", modify: func(doc *Document) { doc.MultiMangas[0].Status = 6 }},
+		{name: "No mangas", modify: func(doc *Document) { doc.MultiMangas[0].Mangas = nil }},
+		{name: "Current manga not in mangas", modify: func(doc *Document) { doc.MultiMangas[0].CurrentMangaURL = "https://example.com" }},
+		{name: "Manga without URL", modify: func(doc *Document) { doc.MultiMangas[0].Mangas[1].URL = "" }},
+		{name: "Chapter without name", modify: func(doc *Document) { doc.MultiMangas[0].LastReadChapter.Name = "" }},
+		{name: "Duplicated manga", modify: func(doc *Document) {
+			doc.MultiMangas = append(doc.MultiMangas, Export([]*manga.MultiManga{getTestMultiManga()}, nil, false).MultiMangas[0])
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := Export([]*manga.MultiManga{getTestMultiManga()}, nil, false)
+			tc.modify(doc)
+			if err := doc.Validate(); err == nil {
+				t.Fatal("Expected an error")
+			}
+		})
+	}
+}
+
+func TestToMultiManga(t *testing.T) {
+	t.Run("Should convert the exported multimanga back", func(t *testing.T) {
+		doc := Export([]*manga.MultiManga{getTestMultiManga()}, nil, false)
+		mm, err := doc.MultiMangas[0].toMultiManga()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if mm.CurrentManga == nil || mm.CurrentManga.URL != "https://mangadex.org/title/1" {
+			t.Fatalf("Unexpected current manga: %v", mm.CurrentManga)
+		}
+		if mm.LastReadChapter == nil || mm.LastReadChapter.Type != 2 {
+			t.Fatalf("Expected last read chapter with type 2, got %v", mm.LastReadChapter)
+		}
+		if mm.CurrentManga.LastReleasedChapter == nil || mm.CurrentManga.LastReleasedChapter.Type != 1 {
+			t.Fatalf("Expected last released chapter with type 1, got %v", mm.CurrentManga.LastReleasedChapter)
+		}
+		if len(mm.CurrentManga.CoverImg) == 0 || !mm.CurrentManga.CoverImgResized {
+			t.Fatal("Expected the default cover image for the source manga")
+		}
+		if string(mm.Mangas[1].CoverImg) != "custom cover" {
+			t.Fatal("Expected the custom manga cover image")
+		}
+		if !mm.CoverImgFixed || string(mm.CoverImg) != "fixed cover" {
+			t.Fatal("Expected the fixed cover image")
+		}
+		for _, m := range mm.Mangas {
+			if m.Status != mm.Status {
+				t.Fatalf("Expected manga status %d, got %d", mm.Status, m.Status)
+			}
+		}
+	})
+}
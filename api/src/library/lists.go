@@ -0,0 +1,298 @@
+package library
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// ListFormat is the format of a manga list exported from a tracker.
+type ListFormat string
+
+const (
+	// ListFormatMAL is the MyAnimeList XML export.
+	ListFormatMAL ListFormat = "mal"
+	// ListFormatAniList is the AniList GraphQL MediaListCollection JSON response.
+	ListFormatAniList ListFormat = "anilist"
+	// ListFormatKitsu is the Kitsu JSON:API library entries response, with the manga included.
+	ListFormatKitsu ListFormat = "kitsu"
+	// ListFormatMangaUpdates is the format of the lists imported by the MangaUpdates
+	// integration. It's not a file format, so it's not in ListFormats.
+	ListFormatMangaUpdates ListFormat = "mangaupdates"
+)
+
+// ListFormats are the valid list formats.
+var ListFormats = []ListFormat{ListFormatMAL, ListFormatAniList, ListFormatKitsu}
+
+// ListEntry is a manga of a tracker list.
+type ListEntry struct {
+	Title string `json:"title"`
+	// AlternativeTitles are other titles of the manga, used to search
+	// the manga when the title doesn't have a good match.
+	AlternativeTitles []string     `json:"alternativeTitles,omitempty"`
+	Status            manga.Status `json:"status"`
+	// ChaptersRead is the number of chapters read, 0 if none.
+	ChaptersRead int `json:"chaptersRead"`
+	// Year is the year the manga started, 0 if unknown.
+	Year int `json:"year,omitempty"`
+}
+
+// ParseList parses a tracker list in the format.
+// Parsing errors contain errordefs.ErrInvalidTrackerList.
+func ParseList(data []byte, format ListFormat) ([]*ListEntry, error) {
+	contextError := "error parsing '%s' list"
+
+	var entries []*ListEntry
+	var err error
+	switch format {
+	case ListFormatMAL:
+		entries, err = parseMALList(data)
+	case ListFormatAniList:
+		entries, err = parseAniListList(data)
+	case ListFormatKitsu:
+		entries, err = parseKitsuList(data)
+	default:
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, format), fmt.Errorf("invalid list format '%s', should be one of %v", format, ListFormats))
+	}
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, format), util.AddErrorContext(errordefs.ErrInvalidTrackerList.Error(), err))
+	}
+
+	return entries, nil
+}
+
+type malList struct {
+	Mangas []struct {
+		Title        string `xml:"manga_title"`
+		Status       string `xml:"my_status"`
+		ChaptersRead int    `xml:"my_read_chapters"`
+	} `xml:"manga"`
+}
+
+var malStatuses = map[string]manga.Status{
+	"reading":      1,
+	"completed":    2,
+	"on-hold":      3,
+	"dropped":      4,
+	"plan to read": 5,
+}
+
+func parseMALList(data []byte) ([]*ListEntry, error) {
+	var list malList
+	err := xml.Unmarshal(data, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*ListEntry, 0, len(list.Mangas))
+	for i, m := range list.Mangas {
+		status, ok := malStatuses[strings.ToLower(strings.TrimSpace(m.Status))]
+		if !ok {
+			return nil, fmt.Errorf("manga %d has invalid status '%s'", i, m.Status)
+		}
+		entry, err := newListEntry(m.Title, nil, status, m.ChaptersRead, 0)
+		if err != nil {
+			return nil, fmt.Errorf("manga %d: %s", i, err.Error())
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+type aniListCollection struct {
+	Lists []struct {
+		Entries []struct {
+			Status   string `json:"status"`
+			Progress int    `json:"progress"`
+			Media    struct {
+				Title struct {
+					Romaji  string `json:"romaji"`
+					English string `json:"english"`
+					Native  string `json:"native"`
+				} `json:"title"`
+				Synonyms  []string `json:"synonyms"`
+				StartDate struct {
+					Year int `json:"year"`
+				} `json:"startDate"`
+			} `json:"media"`
+		} `json:"entries"`
+	} `json:"lists"`
+}
+
+var aniListStatuses = map[string]manga.Status{
+	"CURRENT":   1,
+	"REPEATING": 1,
+	"COMPLETED": 2,
+	"PAUSED":    3,
+	"DROPPED":   4,
+	"PLANNING":  5,
+}
+
+func parseAniListList(data []byte) ([]*ListEntry, error) {
+	// The collection can be the whole GraphQL response or only the MediaListCollection object
+	var response struct {
+		Data struct {
+			MediaListCollection *aniListCollection `json:"MediaListCollection"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(data, &response)
+	if err != nil {
+		return nil, err
+	}
+	collection := response.Data.MediaListCollection
+	if collection == nil {
+		collection = &aniListCollection{}
+		err = json.Unmarshal(data, collection)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries := []*ListEntry{}
+	for i, list := range collection.Lists {
+		for j, e := range list.Entries {
+			status, ok := aniListStatuses[e.Status]
+			if !ok {
+				return nil, fmt.Errorf("list %d entry %d has invalid status '%s'", i, j, e.Status)
+			}
+			title := e.Media.Title.English
+			if title == "" {
+				title = e.Media.Title.Romaji
+			}
+			alternativeTitles := append([]string{e.Media.Title.Romaji, e.Media.Title.Native}, e.Media.Synonyms...)
+			entry, err := newListEntry(title, alternativeTitles, status, e.Progress, e.Media.StartDate.Year)
+			if err != nil {
+				return nil, fmt.Errorf("list %d entry %d: %s", i, j, err.Error())
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+type kitsuList struct {
+	Data []struct {
+		Attributes struct {
+			Status   string `json:"status"`
+			Progress int    `json:"progress"`
+		} `json:"attributes"`
+		Relationships struct {
+			Manga struct {
+				Data *kitsuResource `json:"data"`
+			} `json:"manga"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Included []struct {
+		kitsuResource
+		Attributes struct {
+			CanonicalTitle    string            `json:"canonicalTitle"`
+			Titles            map[string]string `json:"titles"`
+			AbbreviatedTitles []string          `json:"abbreviatedTitles"`
+			StartDate         string            `json:"startDate"`
+		} `json:"attributes"`
+	} `json:"included"`
+}
+
+type kitsuResource struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+var kitsuStatuses = map[string]manga.Status{
+	"current":   1,
+	"completed": 2,
+	"on_hold":   3,
+	"dropped":   4,
+	"planned":   5,
+}
+
+func parseKitsuList(data []byte) ([]*ListEntry, error) {
+	var list kitsuList
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	includedIndexes := map[kitsuResource]int{}
+	for i, resource := range list.Included {
+		includedIndexes[resource.kitsuResource] = i
+	}
+
+	entries := make([]*ListEntry, 0, len(list.Data))
+	for i, e := range list.Data {
+		status, ok := kitsuStatuses[e.Attributes.Status]
+		if !ok {
+			return nil, fmt.Errorf("library entry %d has invalid status '%s'", i, e.Attributes.Status)
+		}
+		if e.Relationships.Manga.Data == nil {
+			return nil, fmt.Errorf("library entry %d has no manga", i)
+		}
+		index, ok := includedIndexes[*e.Relationships.Manga.Data]
+		if !ok {
+			return nil, fmt.Errorf("library entry %d manga '%s' is not included", i, e.Relationships.Manga.Data.ID)
+		}
+		m := list.Included[index].Attributes
+
+		// The titles are sorted so the alternative titles order is deterministic
+		titleKeys := make([]string, 0, len(m.Titles))
+		for key := range m.Titles {
+			titleKeys = append(titleKeys, key)
+		}
+		slices.Sort(titleKeys)
+		alternativeTitles := make([]string, 0, len(m.Titles)+len(m.AbbreviatedTitles))
+		for _, key := range titleKeys {
+			alternativeTitles = append(alternativeTitles, m.Titles[key])
+		}
+		alternativeTitles = append(alternativeTitles, m.AbbreviatedTitles...)
+
+		var year int
+		if len(m.StartDate) >= 4 {
+			year, _ = strconv.Atoi(m.StartDate[:4])
+		}
+
+		entry, err := newListEntry(m.CanonicalTitle, alternativeTitles, status, e.Attributes.Progress, year)
+		if err != nil {
+			return nil, fmt.Errorf("library entry %d: %s", i, err.Error())
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// newListEntry returns a list entry with the title and the alternative titles
+// that are not empty or equal to the title or to another alternative title.
+func newListEntry(title string, alternativeTitles []string, status manga.Status, chaptersRead, year int) (*ListEntry, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("title is empty")
+	}
+	if chaptersRead < 0 {
+		return nil, fmt.Errorf("chapters read should be >= 0, instead it's %d", chaptersRead)
+	}
+
+	entry := &ListEntry{
+		Title:        title,
+		Status:       status,
+		ChaptersRead: chaptersRead,
+		Year:         year,
+	}
+	for _, alternativeTitle := range alternativeTitles {
+		alternativeTitle = strings.TrimSpace(alternativeTitle)
+		if alternativeTitle == "" || alternativeTitle == title || slices.Contains(entry.AlternativeTitles, alternativeTitle) {
+			continue
+		}
+		entry.AlternativeTitles = append(entry.AlternativeTitles, alternativeTitle)
+	}
+
+	return entry, nil
+}
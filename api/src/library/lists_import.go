@@ -0,0 +1,383 @@
+package library
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/jobs"
+	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/sources"
+	"github.com/diogovalentte/mantium/api/src/sources/models"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// DefaultMinMatchConfidence is the default minimum confidence
+// of a match for a list entry to be imported.
+const DefaultMinMatchConfidence = 0.8
+
+const (
+	// listSearchLimit is the number of search results of each source for each title.
+	listSearchLimit = 5
+	// listMaxCandidates is the maximum number of candidates of an entry in the review list.
+	listMaxCandidates = 3
+)
+
+// searchManga is the function used to search the list entries in the sources.
+var searchManga = sources.SearchManga
+
+// ListMatch is a manga from a source that matches a list entry.
+type ListMatch struct {
+	Source     string `json:"source"`
+	URL        string `json:"url"`
+	Name       string `json:"name"`
+	InternalID string `json:"internalID,omitempty"`
+	// Confidence is how similar the manga is to the list entry, from 0 to 1.
+	Confidence float64 `json:"confidence"`
+}
+
+// ListImportResult is the result of the import of a list entry.
+type ListImportResult struct {
+	Entry *ListEntry `json:"entry"`
+	// Match is the manga added for the entry.
+	Match *ListMatch `json:"match,omitempty"`
+	// Candidates are the best matches of an entry that needs review,
+	// sorted by confidence.
+	Candidates []*ListMatch `json:"candidates,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// ListImportReport is the result of a tracker list import.
+// In a dry run, it's what would happen if the list was imported.
+type ListImportReport struct {
+	Format ListFormat `json:"format"`
+	// Added are the entries added to the library.
+	Added []*ListImportResult `json:"added"`
+	// Skipped are the entries not added because the matched manga is already in the library.
+	Skipped []*ListImportResult `json:"skipped"`
+	// Review are the entries without a match with the minimum confidence
+	// or that couldn't be added. They should be reviewed and added manually.
+	Review []*ListImportResult `json:"review"`
+	DryRun bool                `json:"dryRun"`
+}
+
+// ListImportOptions are the options of a tracker list import.
+type ListImportOptions struct {
+	// Sources are the names of the sources where the entries are searched, in order of preference.
+	Sources []string
+	// MinConfidence is the minimum confidence of a match for the entry to be imported.
+	MinConfidence float64
+	// UserID is the ID of the user whose library the entries are imported into.
+	// If 0, the entries are imported into the global library.
+	UserID int
+	DryRun bool
+}
+
+// ImportList searches each list entry in the sources and adds the best match
+// into the library with the entry's status and last read chapter.
+// Entries without a good match are returned in the report's review list
+// with their best candidates instead of being skipped.
+// The search progress is reported to the job if it's not nil. If ctx is canceled
+// while the entries are searched, nothing is imported and the context error is returned.
+func ImportList(ctx context.Context, job *jobs.Job, entries []*ListEntry, format ListFormat, options ListImportOptions) (*ListImportReport, error) {
+	contextError := "error importing '%s' list"
+
+	if len(options.Sources) == 0 {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, format), fmt.Errorf("no sources to search the entries"))
+	}
+	if options.MinConfidence < 0 || options.MinConfidence > 1 {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, format), fmt.Errorf("minimum confidence should be >= 0 && <= 1, instead it's %v", options.MinConfidence))
+	}
+
+	job.SetTotal(len(entries))
+	results := make([]*ListImportResult, 0, len(entries))
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, util.AddErrorContext(fmt.Sprintf(contextError, format), ctx.Err())
+		}
+		result := &ListImportResult{Entry: entry}
+		item := &jobs.Item{Name: entry.Title, Status: jobs.ItemStatusChecked}
+
+		match, candidates, err := matchListEntry(entry, options.Sources, options.MinConfidence)
+		if err != nil {
+			result.Error = err.Error()
+			item.Status = jobs.ItemStatusFailed
+			item.Errors = []string{err.Error()}
+		} else if match == nil {
+			result.Candidates = candidates
+		}
+		result.Match = match
+		results = append(results, result)
+		job.AddItem(item)
+	}
+
+	report, err := ImportMatchedList(results, format, options)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, format), err)
+	}
+
+	return report, nil
+}
+
+// ImportMatchedList adds the list entries into the library with the manga of their match,
+// like ImportList, but without searching the entries in the sources.
+// Results without a match or with an error are returned in the report's review list.
+// The options' sources and minimum confidence are not used.
+func ImportMatchedList(results []*ListImportResult, format ListFormat, options ListImportOptions) (*ListImportReport, error) {
+	contextError := "error importing matched '%s' list"
+
+	var existingMultiMangas []*manga.MultiManga
+	var err error
+	if options.UserID > 0 {
+		existingMultiMangas, err = manga.GetUserMultiMangasDB(options.UserID, true)
+	} else {
+		existingMultiMangas, err = manga.GetMultiMangasDB(true)
+	}
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, format), err)
+	}
+	existingURLs := map[string]bool{}
+	for _, mm := range existingMultiMangas {
+		for _, m := range mm.Mangas {
+			existingURLs[m.URL] = true
+		}
+	}
+
+	report := &ListImportReport{
+		Format:  format,
+		DryRun:  options.DryRun,
+		Added:   []*ListImportResult{},
+		Skipped: []*ListImportResult{},
+		Review:  []*ListImportResult{},
+	}
+	for _, result := range results {
+		entry, match := result.Entry, result.Match
+		if result.Error != "" || match == nil {
+			report.Review = append(report.Review, result)
+			continue
+		}
+
+		if existingURLs[match.URL] {
+			report.Skipped = append(report.Skipped, result)
+			continue
+		}
+		if !options.DryRun {
+			err := addListEntryToLibrary(entry, match, options.UserID)
+			if err != nil {
+				if strings.Contains(err.Error(), errordefs.ErrMultiMangaAlreadyInUserLibrary.Error()) {
+					report.Skipped = append(report.Skipped, result)
+					continue
+				}
+				result.Error = err.Error()
+				report.Review = append(report.Review, result)
+				continue
+			}
+		}
+		existingURLs[match.URL] = true
+		report.Added = append(report.Added, result)
+	}
+
+	return report, nil
+}
+
+// matchListEntry searches the entry's titles in the sources and returns the best match
+// with at least the minimum confidence. If there isn't one, returns the best candidates.
+// The alternative titles are only searched if the previous titles don't have a match.
+// It returns an error only if all searches fail.
+func matchListEntry(entry *ListEntry, sourceNames []string, minConfidence float64) (*ListMatch, []*ListMatch, error) {
+	candidates := []*ListMatch{}
+	seenURLs := map[string]bool{}
+	var searchErrors []string
+	var searchesCount int
+
+	titles := append([]string{entry.Title}, entry.AlternativeTitles...)
+	for _, title := range titles {
+		for _, sourceName := range sourceNames {
+			searchesCount++
+			results, err := searchManga(title, sourceName, listSearchLimit)
+			if err != nil {
+				searchErrors = append(searchErrors, err.Error())
+				continue
+			}
+			for _, result := range results {
+				if seenURLs[result.URL] {
+					continue
+				}
+				seenURLs[result.URL] = true
+				candidates = append(candidates, &ListMatch{
+					Source:     result.Source,
+					URL:        result.URL,
+					Name:       result.Name,
+					InternalID: result.InternalID,
+					Confidence: matchConfidence(entry, result),
+				})
+			}
+		}
+
+		// Stable sort to keep the sources order for matches with the same confidence
+		slices.SortStableFunc(candidates, func(a, b *ListMatch) int {
+			switch {
+			case a.Confidence > b.Confidence:
+				return -1
+			case a.Confidence < b.Confidence:
+				return 1
+			default:
+				return 0
+			}
+		})
+		if len(candidates) > 0 && candidates[0].Confidence >= minConfidence {
+			return candidates[0], nil, nil
+		}
+	}
+
+	if len(searchErrors) == searchesCount {
+		return nil, nil, fmt.Errorf("error searching entry '%s': %s", entry.Title, strings.Join(searchErrors, "; "))
+	}
+	if len(candidates) > listMaxCandidates {
+		candidates = candidates[:listMaxCandidates]
+	}
+
+	return nil, candidates, nil
+}
+
+// matchConfidence returns how similar the search result is to the list entry, from 0 to 1.
+// It's the highest similarity between the result's name and the entry's titles,
+// reduced if both have a year and the years are too different.
+func matchConfidence(entry *ListEntry, result *models.MangaSearchResult) float64 {
+	confidence := titleSimilarity(entry.Title, result.Name)
+	for _, title := range entry.AlternativeTitles {
+		confidence = max(confidence, titleSimilarity(title, result.Name))
+	}
+
+	if entry.Year > 0 && result.Year > 0 && (entry.Year-result.Year > 1 || result.Year-entry.Year > 1) {
+		confidence *= 0.8
+	}
+
+	return confidence
+}
+
+// titleSimilarity returns the similarity between the titles from 0 to 1, based on
+// the Levenshtein distance of the titles without case, punctuation, and extra spaces.
+func titleSimilarity(a, b string) float64 {
+	aRunes := []rune(normalizeTitle(a))
+	bRunes := []rune(normalizeTitle(b))
+	maxLen := max(len(aRunes), len(bRunes))
+	if maxLen == 0 {
+		return 0
+	}
+
+	return 1 - float64(levenshteinDistance(aRunes, bRunes))/float64(maxLen)
+}
+
+func normalizeTitle(title string) string {
+	var builder strings.Builder
+	lastIsSpace := true
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			lastIsSpace = false
+		} else if !lastIsSpace {
+			builder.WriteRune(' ')
+			lastIsSpace = true
+		}
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+func levenshteinDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// addListEntryToLibrary adds the matched manga into the library with the entry's
+// status and last read chapter. If a user ID is provided and the manga is already
+// in the database, adds its multimanga to the user's library.
+func addListEntryToLibrary(entry *ListEntry, match *ListMatch, userID int) error {
+	contextError := "error adding list entry '%s' to the library"
+
+	currentTime := time.Now()
+	currentManga, err := sources.GetMangaMetadata(match.URL, match.InternalID)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, entry.Title), err)
+	}
+	currentManga.Status = entry.Status
+
+	if entry.ChaptersRead > 0 {
+		chapter := strconv.Itoa(entry.ChaptersRead)
+		currentManga.LastReadChapter, err = sources.GetChapterMetadata(match.URL, match.InternalID, chapter, "", "")
+		if err == nil {
+			currentManga.LastReadChapter.FromSourceSite = true
+		} else {
+			// The source doesn't have the chapter, so it's saved without a URL
+			currentManga.LastReadChapter = &manga.Chapter{
+				Chapter: chapter,
+				Name:    "Chapter " + chapter,
+			}
+		}
+		currentManga.LastReadChapter.Type = 2
+		currentManga.LastReadChapter.UpdatedAt = currentTime.Truncate(time.Second)
+	}
+
+	if len(currentManga.CoverImg) == 0 {
+		currentManga.CoverImg, err = util.GetDefaultCoverImg()
+		if err != nil {
+			return util.AddErrorContext(fmt.Sprintf(contextError, entry.Title), err)
+		}
+		currentManga.CoverImgURL = models.DefaultCoverImgURL
+		currentManga.CoverImgResized = true
+	}
+
+	if currentManga.LastReleasedChapter != nil && currentManga.LastReleasedChapter.UpdatedAt.IsZero() {
+		currentManga.LastReleasedChapter.UpdatedAt = currentTime.Truncate(time.Second)
+	}
+
+	multiManga := &manga.MultiManga{
+		CurrentManga:    currentManga,
+		LastReadChapter: currentManga.LastReadChapter,
+		Mangas:          []*manga.Manga{currentManga},
+		Status:          currentManga.Status,
+	}
+	err = multiManga.InsertIntoDB()
+	if err != nil {
+		if userID == 0 || !strings.Contains(err.Error(), errordefs.ErrMangaAlreadyInDB.Error()) {
+			return util.AddErrorContext(fmt.Sprintf(contextError, entry.Title), err)
+		}
+		// The manga is already shared by another user or by the global library
+		existingManga, err := manga.GetMangaDB(-1, currentManga.URL)
+		if err != nil {
+			return util.AddErrorContext(fmt.Sprintf(contextError, entry.Title), err)
+		}
+		multiManga.ID = existingManga.MultiMangaID
+	}
+
+	if userID > 0 {
+		err = multiManga.AddToUserLibraryInDB(userID)
+		if err != nil {
+			return util.AddErrorContext(fmt.Sprintf(contextError, entry.Title), err)
+		}
+	}
+
+	return nil
+}
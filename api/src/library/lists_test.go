@@ -0,0 +1,204 @@
+package library
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/sources/models"
+)
+
+func TestParseList(t *testing.T) {
+	t.Run("Should parse a MyAnimeList export", func(t *testing.T) {
+		data := `<?xml version="1.0" encoding="UTF-8" ?>
+<myanimelist>
+    <myinfo><user_name>user</user_name></myinfo>
+    <manga>
+        <manga_title><![CDATA[One Piece]]></manga_title>
+        <my_read_chapters>1100</my_read_chapters>
+        <my_status>Reading</my_status>
+    </manga>
+    <manga>
+        <manga_title><![CDATA[Berserk]]></manga_title>
+        <my_read_chapters>0</my_read_chapters>
+        <my_status>Plan to Read</my_status>
+    </manga>
+</myanimelist>`
+		entries, err := ParseList([]byte(data), ListFormatMAL)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("Expected 2 entries, got %d", len(entries))
+		}
+		if entries[0].Title != "One Piece" || entries[0].Status != 1 || entries[0].ChaptersRead != 1100 {
+			t.Fatalf("Unexpected first entry: %+v", entries[0])
+		}
+		if entries[1].Title != "Berserk" || entries[1].Status != 5 || entries[1].ChaptersRead != 0 {
+			t.Fatalf("Unexpected second entry: %+v", entries[1])
+		}
+	})
+	t.Run("Should parse an AniList MediaListCollection response", func(t *testing.T) {
+		data := `{"data": {"MediaListCollection": {"lists": [
+            {"entries": [{"status": "PAUSED", "progress": 42, "media": {
+                "title": {"romaji": "Shingeki no Kyojin", "english": "Attack on Titan", "native": "進撃の巨人"},
+                "synonyms": ["AoT", "Attack on Titan"], "startDate": {"year": 2009}}}]},
+            {"entries": [{"status": "REPEATING", "progress": 3, "media": {
+                "title": {"romaji": "Yotsuba to!", "english": null, "native": null},
+                "synonyms": [], "startDate": {"year": null}}}]}
+        ]}}}`
+		entries, err := ParseList([]byte(data), ListFormatAniList)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("Expected 2 entries, got %d", len(entries))
+		}
+		expectedAlternativeTitles := []string{"Shingeki no Kyojin", "進撃の巨人", "AoT"}
+		if entries[0].Title != "Attack on Titan" || entries[0].Status != 3 || entries[0].ChaptersRead != 42 || entries[0].Year != 2009 || !slices.Equal(entries[0].AlternativeTitles, expectedAlternativeTitles) {
+			t.Fatalf("Unexpected first entry: %+v", entries[0])
+		}
+		if entries[1].Title != "Yotsuba to!" || entries[1].Status != 1 || len(entries[1].AlternativeTitles) != 0 {
+			t.Fatalf("Unexpected second entry: %+v", entries[1])
+		}
+	})
+	t.Run("Should parse a Kitsu library entries response", func(t *testing.T) {
+		data := `{"data": [
+            {"type": "libraryEntries", "id": "10", "attributes": {"status": "completed", "progress": 139},
+             "relationships": {"manga": {"data": {"type": "manga", "id": "1"}}}}
+        ], "included": [
+            {"type": "manga", "id": "1", "attributes": {"canonicalTitle": "Fullmetal Alchemist",
+             "titles": {"en": "Fullmetal Alchemist", "ja_jp": "鋼の錬金術師"}, "abbreviatedTitles": ["FMA"],
+             "startDate": "2001-07-12"}}
+        ]}`
+		entries, err := ParseList([]byte(data), ListFormatKitsu)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 entry, got %d", len(entries))
+		}
+		expectedAlternativeTitles := []string{"鋼の錬金術師", "FMA"}
+		if entries[0].Title != "Fullmetal Alchemist" || entries[0].Status != 2 || entries[0].ChaptersRead != 139 || entries[0].Year != 2001 || !slices.Equal(entries[0].AlternativeTitles, expectedAlternativeTitles) {
+			t.Fatalf("Unexpected entry: %+v", entries[0])
+		}
+	})
+	t.Run("Should return an invalid tracker list error", func(t *testing.T) {
+		testCases := []struct {
+			format ListFormat
+			data   string
+		}{
+			{ListFormatMAL, `<myanimelist><manga><manga_title>A</manga_title><my_status>Watching</my_status></manga></myanimelist>`},
+			{ListFormatMAL, `<myanimelist><manga><manga_title></manga_title><my_status>Reading</my_status></manga></myanimelist>`},
+			{ListFormatAniList, `{"lists": [{"entries": [{"status": "CURRENT", "progress": -1, "media": {"title": {"romaji": "A"}}}]}]}`},
+			{ListFormatKitsu, `{"data": [{"attributes": {"status": "current"}, "relationships": {"manga": {"data": {"type": "manga", "id": "2"}}}}]}`},
+			{ListFormatKitsu, `not json`},
+		}
+		for _, tc := range testCases {
+			_, err := ParseList([]byte(tc.data), tc.format)
+			if err == nil || !strings.Contains(err.Error(), errordefs.ErrInvalidTrackerList.Error()) {
+				t.Fatalf("Expected invalid tracker list error for %s list %s, got %v", tc.format, tc.data, err)
+			}
+		}
+	})
+	t.Run("Should return an error for an invalid format", func(t *testing.T) {
+		_, err := ParseList([]byte(`{}`), "goodreads")
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"One Piece", "one piece", 1},
+		{"Kaguya-sama: Love is War", "Kaguya sama   Love Is War!", 1},
+		{"Nana", "Nani", 0.75},
+		{"Berserk", "", 0},
+		{"", "", 0},
+	}
+	for _, tc := range testCases {
+		if similarity := titleSimilarity(tc.a, tc.b); similarity != tc.expected {
+			t.Fatalf("Expected similarity of '%s' and '%s' to be %v, got %v", tc.a, tc.b, tc.expected, similarity)
+		}
+	}
+}
+
+func TestMatchListEntry(t *testing.T) {
+	defer func(original func(string, string, int) ([]*models.MangaSearchResult, error)) {
+		searchManga = original
+	}(searchManga)
+
+	results := map[string][]*models.MangaSearchResult{
+		"mangadex/Attack on Titan": {
+			{Source: "mangadex", URL: "https://mangadex.org/title/2", Name: "Attack on Titan: Before the Fall"},
+		},
+		"mangaupdates/Attack on Titan": {
+			{Source: "mangaupdates", URL: "https://mangaupdates.com/series/1", Name: "Attack on Titan", Year: 2009},
+		},
+		"mangadex/Shingeki no Kyojin": {
+			{Source: "mangadex", URL: "https://mangadex.org/title/1", Name: "Shingeki no Kyojin", Year: 2009},
+		},
+	}
+	var searches []string
+	searchManga = func(term, sourceName string, _ int) ([]*models.MangaSearchResult, error) {
+		searches = append(searches, sourceName+"/"+term)
+		if sourceName == "broken" {
+			return nil, fmt.Errorf("source is down")
+		}
+		return results[sourceName+"/"+term], nil
+	}
+
+	t.Run("Should return the best match", func(t *testing.T) {
+		searches = nil
+		entry := &ListEntry{Title: "Attack on Titan", AlternativeTitles: []string{"Shingeki no Kyojin"}, Year: 2009}
+		match, _, err := matchListEntry(entry, []string{"mangadex", "mangaupdates"}, 0.8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if match == nil || match.URL != "https://mangaupdates.com/series/1" || match.Confidence != 1 {
+			t.Fatalf("Unexpected match: %+v", match)
+		}
+		if len(searches) != 2 {
+			t.Fatalf("Expected the alternative titles to not be searched, searches: %v", searches)
+		}
+	})
+	t.Run("Should search the alternative titles", func(t *testing.T) {
+		searches = nil
+		entry := &ListEntry{Title: "Attack on Titan", AlternativeTitles: []string{"Shingeki no Kyojin"}}
+		match, _, err := matchListEntry(entry, []string{"mangadex", "broken"}, 0.8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if match == nil || match.URL != "https://mangadex.org/title/1" {
+			t.Fatalf("Unexpected match: %+v", match)
+		}
+		if len(searches) != 4 {
+			t.Fatalf("Expected 4 searches, got %v", searches)
+		}
+	})
+	t.Run("Should return the candidates if there's no match", func(t *testing.T) {
+		entry := &ListEntry{Title: "Attack on Titan", Year: 2020}
+		match, candidates, err := matchListEntry(entry, []string{"mangadex", "mangaupdates"}, 0.9)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if match != nil {
+			t.Fatalf("Expected no match, got %+v", match)
+		}
+		if len(candidates) != 2 || candidates[0].URL != "https://mangaupdates.com/series/1" || candidates[0].Confidence != 0.8 {
+			t.Fatalf("Unexpected candidates: %+v", candidates)
+		}
+	})
+	t.Run("Should return an error if all searches fail", func(t *testing.T) {
+		_, _, err := matchListEntry(&ListEntry{Title: "Attack on Titan"}, []string{"broken"}, 0.8)
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package manga
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// AniListLink links a multimanga to an AniList media,
+// used to sync the multimanga's status and progress with AniList.
+type AniListLink struct {
+	MultiMangaID ID  `json:"multimangaID"`
+	MediaID      int `json:"mediaID"`
+}
+
+// GetAniListLinkFromDB gets the AniList link of a multimanga from the database.
+func GetAniListLinkFromDB(multimangaID ID) (*AniListLink, error) {
+	contextError := "error getting AniList link of multimanga with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+	defer db.Close()
+
+	link := &AniListLink{MultiMangaID: multimangaID}
+	err = db.QueryRow(`
+        SELECT
+            media_id
+        FROM
+            anilist_links
+        WHERE
+            multimanga_id = $1;
+    `, multimangaID).Scan(&link.MediaID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), errordefs.ErrAniListLinkNotFoundDB)
+		}
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+
+	return link, nil
+}
+
+// GetAniListLinksFromDB gets all AniList links from the database.
+func GetAniListLinksFromDB() ([]*AniListLink, error) {
+	contextError := "error getting AniList links from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+        SELECT
+            multimanga_id, media_id
+        FROM
+            anilist_links
+        ORDER BY
+            multimanga_id ASC;
+    `)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer rows.Close()
+
+	links := []*AniListLink{}
+	for rows.Next() {
+		var link AniListLink
+		err = rows.Scan(&link.MultiMangaID, &link.MediaID)
+		if err != nil {
+			return nil, util.AddErrorContext(contextError, err)
+		}
+		links = append(links, &link)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+
+	return links, nil
+}
+
+// UpsertIntoDB links the multimanga to the AniList media in the database.
+// If the multimanga is already linked, the media is replaced.
+func (l *AniListLink) UpsertIntoDB() error {
+	contextError := "error linking multimanga with ID '%d' to AniList media '%d' in DB"
+
+	if l.MediaID <= 0 {
+		return util.AddErrorContext(fmt.Sprintf(contextError, l.MultiMangaID, l.MediaID), fmt.Errorf("media ID should be > 0"))
+	}
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, l.MultiMangaID, l.MediaID), err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+        INSERT INTO anilist_links
+            (multimanga_id, media_id)
+        VALUES
+            ($1, $2)
+        ON CONFLICT (multimanga_id) DO UPDATE
+        SET
+            media_id = EXCLUDED.media_id;
+    `, l.MultiMangaID, l.MediaID)
+	if err != nil {
+		if err.Error() == `pq: insert or update on table "anilist_links" violates foreign key constraint "anilist_links_multimanga_id_fkey"` {
+			return util.AddErrorContext(fmt.Sprintf(contextError, l.MultiMangaID, l.MediaID), errordefs.ErrMultiMangaNotFoundDB)
+		}
+		return util.AddErrorContext(fmt.Sprintf(contextError, l.MultiMangaID, l.MediaID), err)
+	}
+
+	return nil
+}
+
+// DeleteAniListLinkFromDB unlinks the multimanga from its AniList media in the database.
+func DeleteAniListLinkFromDB(multimangaID ID) error {
+	contextError := "error deleting AniList link of multimanga with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+	defer db.Close()
+
+	result, err := db.Exec(`
+        DELETE FROM anilist_links
+        WHERE multimanga_id = $1;
+    `, multimangaID)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+	if rowsAffected == 0 {
+		return util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), errordefs.ErrAniListLinkNotFoundDB)
+	}
+
+	return nil
+}
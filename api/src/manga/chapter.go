@@ -28,6 +28,8 @@ type Chapter struct {
 	URL string
 	// Chapter usually is the chapter number, but in some cases it can be a one-shot or a special chapter
 	Chapter string
+	// Volume is the volume (tankobon) of the chapter, empty if the source doesn't provide it
+	Volume string
 	// Name is the name of the chapter
 	Name string
 	// InteralID is a unique identifier for the chapter in the source
@@ -38,7 +40,112 @@ type Chapter struct {
 }
 
 func (c Chapter) String() string {
-	return fmt.Sprintf("Chapter{URL: %s, Chapter: %s, Name: %s, InternalID: %s, UpdatedAt: %s, Type: %d, FromSourceSite: %t}", c.URL, c.Chapter, c.Name, c.InternalID, c.UpdatedAt, c.Type, c.FromSourceSite)
+	return fmt.Sprintf("Chapter{URL: %s, Chapter: %s, Volume: %s, Name: %s, InternalID: %s, UpdatedAt: %s, Type: %d, FromSourceSite: %t}", c.URL, c.Chapter, c.Volume, c.Name, c.InternalID, c.UpdatedAt, c.Type, c.FromSourceSite)
+}
+
+// HistoryChapter is a chapter released by a source and stored in the chapters history.
+// Unlike the chapters table, which only keeps the last released/read chapter of a manga,
+// the chapters history keeps every chapter Mantium has seen for a manga.
+type HistoryChapter struct {
+	Chapter
+	// FirstSeenAt is the time when Mantium first saw the chapter in the source.
+	FirstSeenAt time.Time
+	// MangaID is the ID of the manga the chapter belongs to.
+	MangaID ID
+}
+
+func (c HistoryChapter) String() string {
+	return fmt.Sprintf("HistoryChapter{MangaID: %d, FirstSeenAt: %s, Chapter: %s}", c.MangaID, c.FirstSeenAt, c.Chapter)
+}
+
+// CountUnreadChapters returns the number of chapters released after the last read chapter.
+// The chapters should be sorted from the newest to the oldest, like the chapters returned by the sources.
+// Chapters with the same chapter number (e.g. uploaded by different scanlation groups or
+// written differently, like "12" and "Ch. 12") are counted once.
+// If the last read chapter has a number, the chapters with a greater number are counted, so
+// the count is right even if the list doesn't have the last read chapter or isn't sorted.
+// Else, the chapters before the last read chapter in the list are counted. If the last read
+// chapter is not in the list, only the newest chapter is considered unread.
+func CountUnreadChapters(chapters []*Chapter, lastReadChapter *Chapter) int {
+	uniqueChapters := []*Chapter{}
+	uniqueNumbers := []ChapterNumber{}
+	seen := map[string]bool{}
+	for _, chapter := range chapters {
+		if chapter == nil {
+			continue
+		}
+		number := ParseChapterNumber(chapter.Chapter)
+		if seen[number.String()] {
+			continue
+		}
+		seen[number.String()] = true
+		uniqueChapters = append(uniqueChapters, chapter)
+		uniqueNumbers = append(uniqueNumbers, number)
+	}
+
+	if lastReadChapter == nil {
+		return len(uniqueChapters)
+	}
+
+	lastReadChapterNumber := ParseChapterNumber(lastReadChapter.Chapter)
+	if !lastReadChapterNumber.Valid {
+		for i, chapter := range uniqueChapters {
+			if uniqueNumbers[i].Compare(lastReadChapterNumber) == 0 || (chapter.URL != "" && chapter.URL == lastReadChapter.URL) {
+				return i
+			}
+		}
+		if len(uniqueChapters) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	unread := 0
+	for _, number := range uniqueNumbers {
+		if number.Valid && number.Compare(lastReadChapterNumber) > 0 {
+			unread++
+		}
+	}
+
+	return unread
+}
+
+// MayHaveChaptersBetween returns true if other chapters could have been released between the old and
+// the new last released chapters, like "10" and "13", or chapters without a number.
+// It's false for consecutive chapters, like "10" and "11", as there is no chapter between them.
+func MayHaveChaptersBetween(oldChapter, newChapter *Chapter) bool {
+	if newChapter == nil {
+		return false
+	}
+	if oldChapter == nil {
+		return true
+	}
+	oldNumber, newNumber := ParseChapterNumber(oldChapter.Chapter), ParseChapterNumber(newChapter.Chapter)
+	if !oldNumber.Valid || !newNumber.Valid {
+		return true
+	}
+
+	return newNumber.Number-oldNumber.End > 1
+}
+
+// ChaptersBetween returns the chapters released after the old chapter, up to the new chapter, compared by
+// their chapter numbers. If the old chapter is nil, returns all chapters up to the new chapter.
+func ChaptersBetween(chapters []*Chapter, oldChapter, newChapter *Chapter) []*Chapter {
+	between := []*Chapter{}
+	for _, chapter := range chapters {
+		if chapter == nil {
+			continue
+		}
+		if newChapter != nil && CompareChapterNumbers(chapter.Chapter, newChapter.Chapter) > 0 {
+			continue
+		}
+		if oldChapter != nil && CompareChapterNumbers(chapter.Chapter, oldChapter.Chapter) <= 0 {
+			continue
+		}
+		between = append(between, chapter)
+	}
+
+	return between
 }
 
 func getChapterDB(id int, db *sql.DB) (*Chapter, error) {
@@ -47,12 +154,12 @@ func getChapterDB(id int, db *sql.DB) (*Chapter, error) {
 	var chapter Chapter
 	err := db.QueryRow(`
         SELECT
-            url, chapter, name, internal_id, updated_at, type, from_source_site
+            url, chapter, volume, name, internal_id, updated_at, type, from_source_site
         FROM
             chapters
         WHERE
             id = $1;
-    `, id).Scan(&chapter.URL, &chapter.Chapter, &chapter.Name, &chapter.InternalID, &chapter.UpdatedAt, &chapter.Type, &chapter.FromSourceSite)
+    `, id).Scan(&chapter.URL, &chapter.Chapter, &chapter.Volume, &chapter.Name, &chapter.InternalID, &chapter.UpdatedAt, &chapter.Type, &chapter.FromSourceSite)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, util.AddErrorContext(fmt.Sprintf(contextError, id), errordefs.ErrChapterNotFoundDB)
@@ -80,19 +187,24 @@ func upsertMangaChapter(mangaID ID, chapter *Chapter, tx *sql.Tx) error {
 
 	var chapterID int
 	err = tx.QueryRow(`
-        INSERT INTO chapters (manga_id, url, chapter, name, internal_id, updated_at, type, from_source_site)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        INSERT INTO chapters (manga_id, url, chapter, volume, name, internal_id, updated_at, type, from_source_site)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         ON CONFLICT ON CONSTRAINT chapters_manga_id_type_unique
         DO UPDATE
-            SET url = EXCLUDED.url, chapter = EXCLUDED.chapter, name = EXCLUDED.name, internal_id = EXCLUDED.internal_id, updated_at = EXCLUDED.updated_at, from_source_site = EXCLUDED.from_source_site
+            SET url = EXCLUDED.url, chapter = EXCLUDED.chapter, volume = EXCLUDED.volume, name = EXCLUDED.name, internal_id = EXCLUDED.internal_id, updated_at = EXCLUDED.updated_at, from_source_site = EXCLUDED.from_source_site
         RETURNING id;
-    `, mangaID, chapter.URL, chapter.Chapter, chapter.Name, chapter.InternalID, chapter.UpdatedAt, chapter.Type, chapter.FromSourceSite).Scan(&chapterID)
+    `, mangaID, chapter.URL, chapter.Chapter, chapter.Volume, chapter.Name, chapter.InternalID, chapter.UpdatedAt, chapter.Type, chapter.FromSourceSite).Scan(&chapterID)
 	if err != nil {
 		return util.AddErrorContext(contextError, err)
 	}
 
 	var query string
 	if chapter.Type == 1 {
+		err = upsertMangaChaptersHistory(mangaID, []*Chapter{chapter}, tx)
+		if err != nil {
+			return util.AddErrorContext(contextError, err)
+		}
+
 		query = `
             UPDATE mangas
             SET last_released_chapter = $1
@@ -138,13 +250,13 @@ func upsertMultiMangaChapter(multiMangaID ID, chapter *Chapter, tx *sql.Tx) erro
 
 	var chapterID int
 	err = tx.QueryRow(`
-        INSERT INTO chapters (multimanga_id, url, chapter, name, internal_id, updated_at, type, from_source_site)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        INSERT INTO chapters (multimanga_id, url, chapter, volume, name, internal_id, updated_at, type, from_source_site)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
         ON CONFLICT ON CONSTRAINT chapters_multimanga_id_type_unique
         DO UPDATE
-            SET url = EXCLUDED.url, chapter = EXCLUDED.chapter, name = EXCLUDED.name, internal_id = EXCLUDED.internal_id, updated_at = EXCLUDED.updated_at, from_source_site = EXCLUDED.from_source_site
+            SET url = EXCLUDED.url, chapter = EXCLUDED.chapter, volume = EXCLUDED.volume, name = EXCLUDED.name, internal_id = EXCLUDED.internal_id, updated_at = EXCLUDED.updated_at, from_source_site = EXCLUDED.from_source_site
         RETURNING id;
-    `, multiMangaID, chapter.URL, chapter.Chapter, chapter.Name, chapter.InternalID, chapter.UpdatedAt, chapter.Type, chapter.FromSourceSite).Scan(&chapterID)
+    `, multiMangaID, chapter.URL, chapter.Chapter, chapter.Volume, chapter.Name, chapter.InternalID, chapter.UpdatedAt, chapter.Type, chapter.FromSourceSite).Scan(&chapterID)
 	if err != nil {
 		return util.AddErrorContext(contextError, err)
 	}
@@ -223,6 +335,87 @@ func deleteMangaChapter(mangaID ID, chapter *Chapter, tx *sql.Tx) error {
 	return nil
 }
 
+// upsertMangaChaptersHistory inserts the chapters into the manga chapters history.
+// If a chapter is already in the history (same manga and URL), its metadata is updated,
+// but the time it was first seen is kept.
+// Chapters without chapter, name, or URL are skipped, as they can't be identified later.
+func upsertMangaChaptersHistory(mangaID ID, chapters []*Chapter, tx *sql.Tx) error {
+	contextError := "error upserting manga chapters history in the database"
+
+	stmt, err := tx.Prepare(`
+        INSERT INTO chapters_history (manga_id, url, chapter, volume, name, internal_id, updated_at, first_seen_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (manga_id, url)
+        DO UPDATE
+            SET chapter = EXCLUDED.chapter, volume = EXCLUDED.volume, name = EXCLUDED.name, internal_id = EXCLUDED.internal_id, updated_at = EXCLUDED.updated_at;
+    `)
+	if err != nil {
+		return util.AddErrorContext(contextError, err)
+	}
+	defer stmt.Close()
+
+	firstSeenAt := time.Now().Truncate(time.Second)
+	for _, chapter := range chapters {
+		if chapter == nil || chapter.URL == "" || chapter.Chapter == "" || chapter.Name == "" {
+			continue
+		}
+
+		var updatedAt sql.NullTime
+		if !chapter.UpdatedAt.IsZero() {
+			updatedAt = sql.NullTime{Time: chapter.UpdatedAt, Valid: true}
+		}
+
+		_, err = stmt.Exec(mangaID, chapter.URL, chapter.Chapter, chapter.Volume, chapter.Name, chapter.InternalID, updatedAt, firstSeenAt)
+		if err != nil {
+			return util.AddErrorContext(contextError, err)
+		}
+	}
+
+	return nil
+}
+
+// getMangaChaptersHistoryDB gets the chapters history of a manga.
+// The chapters are sorted by release date and then by the time they were first seen, desc.
+func getMangaChaptersHistoryDB(mangaID ID, db *sql.DB) ([]*HistoryChapter, error) {
+	rows, err := db.Query(`
+        SELECT
+            manga_id, url, chapter, volume, name, internal_id, updated_at, first_seen_at
+        FROM
+            chapters_history
+        WHERE
+            manga_id = $1
+        ORDER BY
+            COALESCE(updated_at, first_seen_at) DESC, first_seen_at DESC, id DESC;
+    `, mangaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chapters := []*HistoryChapter{}
+	for rows.Next() {
+		var chapter HistoryChapter
+		var updatedAt sql.NullTime
+
+		err = rows.Scan(&chapter.MangaID, &chapter.URL, &chapter.Chapter.Chapter, &chapter.Volume, &chapter.Name, &chapter.InternalID, &updatedAt, &chapter.FirstSeenAt)
+		if err != nil {
+			return nil, err
+		}
+		if updatedAt.Valid {
+			chapter.UpdatedAt = updatedAt.Time
+		}
+		chapter.Type = 1
+		chapter.FromSourceSite = true
+
+		chapters = append(chapters, &chapter)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return chapters, nil
+}
+
 // valdiateChapter should be used every time the API interacts with
 // the mangas and chapter table in the database
 func validateChapter(c *Chapter) error {
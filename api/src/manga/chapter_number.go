@@ -0,0 +1,258 @@
+package manga
+
+import (
+	"cmp"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ChapterNumber is a chapter number parsed from a chapter's Chapter field, like "10.5",
+// "Ch. 12", "Vol.3 Ch.20", "12-13", or "第十二話". It should be created using ParseChapterNumber.
+type ChapterNumber struct {
+	// Number is the chapter number. If the chapter is a range of chapters, it's the first chapter.
+	Number float64
+	// End is the last chapter of a range of chapters, like 13 in "12-13".
+	// If the chapter is not a range, it's equal to Number.
+	End float64
+	// Volume is the volume number, 0 if the chapter doesn't have a volume.
+	Volume float64
+	// Valid is false if the chapter doesn't have a number, like "Extra" or "Oneshot".
+	Valid bool
+	// numberText and endText are the normalized Number and End, used to tell
+	// apart chapters like "1.1" and "1.10", which are the same float.
+	numberText, endText string
+	// text is the normalized chapter, used to compare chapters without a number.
+	text string
+}
+
+var (
+	// chapterVolumeRegex matches the volume of a chapter, like "Vol. 3" or "3巻".
+	chapterVolumeRegex = regexp.MustCompile(`\b(?:volume|vol|v)\.?\s*(\d+(?:\.\d+)?)|第?\s*(\d+(?:\.\d+)?)\s*[巻卷]`)
+	// chapterMarkedNumberRegex matches a chapter number with a marker, like "Ch. 12", "#12", or "第12話".
+	chapterMarkedNumberRegex = regexp.MustCompile(`(?:\b(?:chapter|chap|ch|c|episode|ep)\.?|#|第)\s*(\d+(?:\.\d+)?)(?:\s*[-~–]\s*(\d+(?:\.\d+)?))?|(\d+(?:\.\d+)?)\s*[話话章回]`)
+	// chapterNumberRegex matches a chapter that is only a number, like "12" or "12-13".
+	chapterNumberRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)(?:\s*[-~–]\s*(\d+(?:\.\d+)?))?$`)
+)
+
+// ParseChapterNumber parses the chapter number of a chapter's Chapter field.
+// Full-width digits and Japanese numerals are converted to numbers.
+// A number without a chapter marker, like "Ch." or "#", is only used if it's the whole chapter,
+// so numbers in titles like "The 100 Knights" are not chapter numbers.
+// If the chapter doesn't have a number, the returned chapter number is not valid.
+func ParseChapterNumber(chapter string) ChapterNumber {
+	text := normalizeChapterText(chapter)
+	number := ChapterNumber{text: text}
+
+	if match := chapterVolumeRegex.FindStringSubmatchIndex(text); match != nil {
+		number.Volume, _ = parseChapterNumberMatch(text, match, 2, 4)
+		text = strings.Join(strings.Fields(text[:match[0]]+" "+text[match[1]:]), " ")
+		number.text = text
+	}
+
+	match := chapterMarkedNumberRegex.FindStringSubmatchIndex(text)
+	startGroup, endGroup := 2, 4
+	if match != nil && match[2] < 0 {
+		startGroup, endGroup = 6, -1
+	}
+	if match == nil {
+		match = chapterNumberRegex.FindStringSubmatchIndex(text)
+		startGroup, endGroup = 2, 4
+	}
+	if match == nil {
+		return number
+	}
+
+	number.Number, number.numberText = parseChapterNumberMatch(text, match, startGroup)
+	number.End, number.endText = number.Number, number.numberText
+	if endGroup > 0 {
+		if end, endText := parseChapterNumberMatch(text, match, endGroup); end > number.Number {
+			number.End, number.endText = end, endText
+		}
+	}
+	number.Valid = true
+
+	return number
+}
+
+// parseChapterNumberMatch returns the number of the first group of the regex match that matched
+// and the number normalized by normalizeDecimal.
+func parseChapterNumberMatch(text string, match []int, groups ...int) (float64, string) {
+	for _, group := range groups {
+		if group >= len(match) || match[group] < 0 {
+			continue
+		}
+		numberText := text[match[group]:match[group+1]]
+		number, err := strconv.ParseFloat(numberText, 64)
+		if err == nil {
+			return number, normalizeDecimal(numberText)
+		}
+	}
+
+	return 0, ""
+}
+
+// normalizeDecimal removes the leading zeros of a decimal number and its fraction if it's
+// only zeros, like "010.0" to "10". Other trailing zeros are kept, as "1.10" is not "1.1".
+func normalizeDecimal(number string) string {
+	integer, fraction, _ := strings.Cut(number, ".")
+	integer = strings.TrimLeft(integer, "0")
+	if integer == "" {
+		integer = "0"
+	}
+	if strings.Trim(fraction, "0") == "" {
+		return integer
+	}
+
+	return integer + "." + fraction
+}
+
+// compareDecimals compares two decimal numbers normalized by normalizeDecimal with the same float value,
+// like "1.1" and "1.10". The number with the longer fraction is greater.
+func compareDecimals(a, b string) int {
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a, b)
+}
+
+// String returns the normalized chapter number, like "10.5" or "12-13".
+// If the chapter number is not valid, returns the normalized chapter.
+func (n ChapterNumber) String() string {
+	if !n.Valid {
+		return n.text
+	}
+	number := n.numberText
+	if n.endText != n.numberText {
+		number += "-" + n.endText
+	}
+
+	return number
+}
+
+// Compare returns -1 if the chapter number is lower than the other, 0 if they're
+// the same chapter, and +1 if it's greater. It's a total ordering:
+//   - Valid chapter numbers are greater than chapters without a number.
+//   - Valid chapter numbers are compared by their numbers and then by the end of their ranges.
+//     Numbers with the same float value but written differently, like "1.1" and "1.10", are different,
+//     and the one with the longer fraction is greater.
+//     The volumes are not compared, as most sources don't restart the chapter numbers in each
+//     volume, and the same chapter can be uploaded with and without its volume.
+//   - Chapters without a number are compared by their volumes and then by their normalized text.
+func (n ChapterNumber) Compare(other ChapterNumber) int {
+	if n.Valid != other.Valid {
+		if n.Valid {
+			return 1
+		}
+		return -1
+	}
+	if n.Valid {
+		if c := cmp.Compare(n.Number, other.Number); c != 0 {
+			return c
+		}
+		if c := compareDecimals(n.numberText, other.numberText); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(n.End, other.End); c != 0 {
+			return c
+		}
+		return compareDecimals(n.endText, other.endText)
+	}
+	if c := cmp.Compare(n.Volume, other.Volume); c != 0 {
+		return c
+	}
+
+	return strings.Compare(n.text, other.text)
+}
+
+// CompareChapterNumbers parses and compares the chapter numbers of two chapters' Chapter fields.
+// See ChapterNumber.Compare.
+func CompareChapterNumbers(a, b string) int {
+	return ParseChapterNumber(a).Compare(ParseChapterNumber(b))
+}
+
+// IsSameChapter returns true if the chapters have the same chapter number, even
+// if they're written differently, like "12", "Ch. 12", and "Vol.2 Chapter 12.0".
+// Nil chapters are only the same as other nil chapters.
+func IsSameChapter(a, b *Chapter) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return CompareChapterNumbers(a.Chapter, b.Chapter) == 0
+}
+
+// normalizeChapterText returns the chapter in lowercase, with the spaces collapsed,
+// the full-width characters converted to ASCII, and the Japanese numerals converted to numbers.
+func normalizeChapterText(chapter string) string {
+	var b strings.Builder
+	var numeral []rune
+	flushNumeral := func() {
+		if len(numeral) > 0 {
+			b.WriteString(strconv.Itoa(parseJapaneseNumeral(numeral)))
+			numeral = numeral[:0]
+		}
+	}
+	for _, r := range chapter {
+		if _, ok := japaneseDigits[r]; ok {
+			numeral = append(numeral, r)
+			continue
+		}
+		if _, ok := japaneseUnits[r]; ok {
+			numeral = append(numeral, r)
+			continue
+		}
+		flushNumeral()
+
+		// Full-width ASCII characters, like "１２．５"
+		if r >= '！' && r <= '～' {
+			r = r - '！' + '!'
+		}
+		if unicode.IsSpace(r) {
+			r = ' '
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	flushNumeral()
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+var japaneseDigits = map[rune]int{
+	'〇': 0, '零': 0, '一': 1, '二': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+var japaneseUnits = map[rune]int{
+	'十': 10, '百': 100, '千': 1000, '万': 10000,
+}
+
+// parseJapaneseNumeral parses Japanese numerals written with units, like "二十三" (23),
+// or positionally, like "二〇" (20).
+func parseJapaneseNumeral(numeral []rune) int {
+	var total, section, digits int
+	for _, r := range numeral {
+		if digit, ok := japaneseDigits[r]; ok {
+			digits = digits*10 + digit
+			continue
+		}
+		unit := japaneseUnits[r]
+		if unit == 10000 {
+			if section+digits == 0 {
+				digits = 1
+			}
+			total += (section + digits) * unit
+			section = 0
+		} else {
+			if digits == 0 {
+				digits = 1
+			}
+			section += digits * unit
+		}
+		digits = 0
+	}
+
+	return total + section + digits
+}
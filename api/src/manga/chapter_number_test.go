@@ -0,0 +1,106 @@
+package manga
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseChapterNumber(t *testing.T) {
+	testCases := []struct {
+		chapter string
+		number  float64
+		end     float64
+		volume  float64
+		valid   bool
+	}{
+		{"10", 10, 10, 0, true},
+		{"10.5", 10.5, 10.5, 0, true},
+		{"010", 10, 10, 0, true},
+		{"Ch. 12", 12, 12, 0, true},
+		{"Chapter 12: The 100 Knights", 12, 12, 0, true},
+		{"Vol.3 Ch.20", 20, 20, 3, true},
+		{"Volume 3 Chapter 20.5", 20.5, 20.5, 3, true},
+		{"12-13", 12, 13, 0, true},
+		{"Ch. 12 ~ 13", 12, 13, 0, true},
+		{"#45", 45, 45, 0, true},
+		{"Extra", 0, 0, 0, false},
+		{"Vol. 2 Extra", 0, 0, 2, false},
+		{"Oneshot", 0, 0, 0, false},
+		{"", 0, 0, 0, false},
+		{"第12話", 12, 12, 0, true},
+		{"第十二話", 12, 12, 0, true},
+		{"第三巻 第二十話", 20, 20, 3, true},
+		{"百五話", 105, 105, 0, true},
+		{"１２．５", 12.5, 12.5, 0, true},
+		{"The 100 Knights", 0, 0, 0, false},
+		{"Vol. 2 12", 12, 12, 2, true},
+	}
+	for _, tc := range testCases {
+		number := ParseChapterNumber(tc.chapter)
+		if number.Number != tc.number || number.End != tc.end || number.Volume != tc.volume || number.Valid != tc.valid {
+			t.Fatalf("Unexpected chapter number of '%s': %+v", tc.chapter, number)
+		}
+	}
+}
+
+func TestChapterNumberString(t *testing.T) {
+	testCases := map[string]string{
+		"Ch. 12.0":      "12",
+		"Vol.3 Ch.20.5": "20.5",
+		"12 - 13":       "12-13",
+		"Ch. 1.10":      "1.10",
+		"010.00":        "10",
+		"  Side  Story": "side story",
+	}
+	for chapter, expected := range testCases {
+		if s := ParseChapterNumber(chapter).String(); s != expected {
+			t.Fatalf("Expected '%s' to be normalized as '%s', got '%s'", chapter, expected, s)
+		}
+	}
+}
+
+func TestCompareChapterNumbers(t *testing.T) {
+	t.Run("Should compare chapter numbers", func(t *testing.T) {
+		testCases := []struct {
+			a, b     string
+			expected int
+		}{
+			{"9", "10", -1},
+			{"10.5", "10", 1},
+			{"12", "Ch. 12", 0},
+			{"Vol.2 Ch.12", "12.0", 0},
+			{"第十二話", "Chapter 12", 0},
+			{"12", "12-13", -1},
+			{"1.1", "1.10", -1},
+			{"1.10", "Ch. 1.10", 0},
+			{"Extra", "1", -1},
+			{"extra", "Extra", 0},
+			{"Extra", "Special", -1},
+		}
+		for _, tc := range testCases {
+			if c := CompareChapterNumbers(tc.a, tc.b); c != tc.expected {
+				t.Fatalf("Expected comparison of '%s' and '%s' to be %d, got %d", tc.a, tc.b, tc.expected, c)
+			}
+		}
+	})
+	t.Run("Should sort chapters in a total ordering", func(t *testing.T) {
+		chapters := []string{"Ch. 3", "Extra", "1", "2.5", "Vol.1 Oneshot", "12-13", "2"}
+		slices.SortFunc(chapters, CompareChapterNumbers)
+		expected := []string{"Extra", "Vol.1 Oneshot", "1", "2", "2.5", "Ch. 3", "12-13"}
+		if !slices.Equal(chapters, expected) {
+			t.Fatalf("Expected %v, got %v", expected, chapters)
+		}
+	})
+}
+
+func TestIsSameChapter(t *testing.T) {
+	if !IsSameChapter(&Chapter{Chapter: "Ch. 12"}, &Chapter{Chapter: "12"}) {
+		t.Fatal("Expected a renamed chapter to be the same chapter")
+	}
+	if IsSameChapter(&Chapter{Chapter: "12"}, &Chapter{Chapter: "13"}) {
+		t.Fatal("Expected different chapters")
+	}
+	if IsSameChapter(nil, &Chapter{Chapter: "12"}) || !IsSameChapter(nil, nil) {
+		t.Fatal("Expected nil chapters to be only the same as nil chapters")
+	}
+}
@@ -63,8 +63,14 @@ type Manga struct {
 	// InteralID is a unique identifier for the manga in the source
 	InternalID string
 	// PreferredGroup is the preferred group that translates (and more) the manga.
-	// Not all sources have multiple groups. Currently not used.
+	// It can have multiple groups separated by commas, like "Group A, Group B".
+	// Sources with multiple groups only get the chapters of the preferred groups.
+	// Not all sources have multiple groups.
 	PreferredGroup string
+	// PreferredLanguage is the preferred translated language of the manga's chapters, like "en".
+	// It can have multiple languages separated by commas, like "en, pt-br".
+	// If empty, sources with multiple languages use their default languages.
+	PreferredLanguage string
 	// CoverImgURL is the URL of the cover image
 	CoverImgURL string
 	// LastReleasedChapter is the last chapter released by the source
@@ -91,11 +97,13 @@ type Manga struct {
 	CoverImgFixed bool
 	// LastReleasedChapterSelectorUseBrowser is true if the LastReleasedChapterNameSelector and LastReleasedChapterURLSelector should be used with a browser (Rod).
 	LastReleasedChapterSelectorUseBrowser bool
+	// UpdateStatus is the result of the metadata updates of the manga. It's only tracked for custom mangas.
+	UpdateStatus UpdateStatus
 }
 
 func (m Manga) String() string {
-	return fmt.Sprintf("Manga{ID: %d, Source: %s, URL: %s, Name: %s, SearchNames: %v, InternalID: %s, Status: %d, CoverImg: []byte, CoverImgResized: %v, CoverImgURL: %s, CoverImgFixed: %v, PreferredGroup: %s, MultiMangaID: %d, LastReleasedChapter: %s, LastReadChapter: %s, LastReleasedChapterNameSelector: %s, LastReleasedChapterURLSelector: %s, LastReleasedChapterSelectorUseBrowser: %v}",
-		m.ID, m.Source, m.URL, m.Name, m.SearchNames, m.InternalID, m.Status, m.CoverImgResized, m.CoverImgURL, m.CoverImgFixed, m.PreferredGroup, m.MultiMangaID, m.LastReleasedChapter, m.LastReadChapter, m.LastReleasedChapterNameSelector, m.LastReleasedChapterURLSelector, m.LastReleasedChapterSelectorUseBrowser)
+	return fmt.Sprintf("Manga{ID: %d, Source: %s, URL: %s, Name: %s, SearchNames: %v, InternalID: %s, Status: %d, CoverImg: []byte, CoverImgResized: %v, CoverImgURL: %s, CoverImgFixed: %v, PreferredGroup: %s, PreferredLanguage: %s, MultiMangaID: %d, LastReleasedChapter: %s, LastReadChapter: %s, LastReleasedChapterNameSelector: %s, LastReleasedChapterURLSelector: %s, LastReleasedChapterSelectorUseBrowser: %v}",
+		m.ID, m.Source, m.URL, m.Name, m.SearchNames, m.InternalID, m.Status, m.CoverImgResized, m.CoverImgURL, m.CoverImgFixed, m.PreferredGroup, m.PreferredLanguage, m.MultiMangaID, m.LastReleasedChapter, m.LastReadChapter, m.LastReleasedChapterNameSelector, m.LastReleasedChapterURLSelector, m.LastReleasedChapterSelectorUseBrowser)
 }
 
 func insertMangaIntoDB(m *Manga, tx *sql.Tx) (ID, error) {
@@ -121,12 +129,12 @@ func insertMangaIntoDB(m *Manga, tx *sql.Tx) (ID, error) {
 	var mangaID ID
 	err = tx.QueryRow(`
         INSERT INTO mangas
-            (source, url, name, internal_id, status, cover_img, cover_img_resized, cover_img_url, cover_img_fixed, preferred_group, multimanga_id, last_released_chapter_name_selector, last_released_chapter_name_attribute, last_released_chapter_name_regex, last_released_chapter_name_get_first, last_released_chapter_url_selector, last_released_chapter_url_attribute, last_released_chapter_url_get_first, last_released_chapter_selector_use_browser)
+            (source, url, name, internal_id, status, cover_img, cover_img_resized, cover_img_url, cover_img_fixed, preferred_group, preferred_language, multimanga_id, last_released_chapter_name_selector, last_released_chapter_name_attribute, last_released_chapter_name_regex, last_released_chapter_name_get_first, last_released_chapter_url_selector, last_released_chapter_url_attribute, last_released_chapter_url_get_first, last_released_chapter_selector_use_browser)
         VALUES
-            ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+            ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
         RETURNING
             id;
-    `, m.Source, m.URL, m.Name, m.InternalID, m.Status, m.CoverImg, m.CoverImgResized, m.CoverImgURL, m.CoverImgFixed, m.PreferredGroup, multiMangaID, m.LastReleasedChapterNameSelector.Selector, m.LastReleasedChapterNameSelector.Attribute, m.LastReleasedChapterNameSelector.Regex, m.LastReleasedChapterNameSelector.GetFirst, m.LastReleasedChapterURLSelector.Selector, m.LastReleasedChapterURLSelector.Attribute, m.LastReleasedChapterURLSelector.GetFirst, m.LastReleasedChapterSelectorUseBrowser).Scan(&mangaID)
+    `, m.Source, m.URL, m.Name, m.InternalID, m.Status, m.CoverImg, m.CoverImgResized, m.CoverImgURL, m.CoverImgFixed, m.PreferredGroup, m.PreferredLanguage, multiMangaID, m.LastReleasedChapterNameSelector.Selector, m.LastReleasedChapterNameSelector.Attribute, m.LastReleasedChapterNameSelector.Regex, m.LastReleasedChapterNameSelector.GetFirst, m.LastReleasedChapterURLSelector.Selector, m.LastReleasedChapterURLSelector.Attribute, m.LastReleasedChapterURLSelector.GetFirst, m.LastReleasedChapterSelectorUseBrowser).Scan(&mangaID)
 	if err != nil {
 		if err.Error() == `pq: duplicate key value violates unique constraint "mangas_pkey"` {
 			return -1, errordefs.ErrMangaAlreadyInDB
@@ -137,7 +145,7 @@ func insertMangaIntoDB(m *Manga, tx *sql.Tx) (ID, error) {
 	if m.LastReleasedChapter != nil {
 		err := upsertMangaChapter(mangaID, m.LastReleasedChapter, tx)
 		if err != nil {
-			if util.ErrorContains(err, `pq: duplicate key value violates unique constraint "chapters_pkey"`) {
+			if util.ErrorContains(err, `pq: duplicate key value violates unique constraint "chapters_url_type_unique"`) {
 				return -1, errordefs.ErrMangaAlreadyInDB // trying to add the same manga with different URL's (like klmanga.rs and klmanga.is), but they have the same chapter
 			}
 			return -1, err
@@ -146,7 +154,7 @@ func insertMangaIntoDB(m *Manga, tx *sql.Tx) (ID, error) {
 	if m.LastReadChapter != nil {
 		err := upsertMangaChapter(mangaID, m.LastReadChapter, tx)
 		if err != nil {
-			if util.ErrorContains(err, `pq: duplicate key value violates unique constraint "chapters_pkey"`) {
+			if util.ErrorContains(err, `pq: duplicate key value violates unique constraint "chapters_url_type_unique"`) {
 				return -1, errordefs.ErrMangaAlreadyInDB
 			}
 			return -1, err
@@ -231,6 +239,55 @@ func (m *Manga) UpsertChapterIntoDB(chapter *Chapter) error {
 	return nil
 }
 
+// UpsertChaptersHistoryIntoDB inserts the chapters into the manga chapters history in the database.
+// Chapters already in the history are updated, but keep the time they were first seen.
+func (m *Manga) UpsertChaptersHistoryIntoDB(chapters []*Chapter) error {
+	contextError := "error upserting '%d' chapters into manga '%s' chapters history in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, len(chapters), m), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, len(chapters), m), err)
+	}
+
+	err = upsertMangaChaptersHistory(m.ID, chapters, tx)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, len(chapters), m), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, len(chapters), m), err)
+	}
+
+	return nil
+}
+
+// GetChaptersHistoryFromDB gets the manga chapters history from the database.
+// It doesn't request the source, so it works even if the source is offline.
+func (m *Manga) GetChaptersHistoryFromDB() ([]*HistoryChapter, error) {
+	contextError := "error getting manga '%s' chapters history from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, m), err)
+	}
+	defer db.Close()
+
+	chapters, err := getMangaChaptersHistoryDB(m.ID, db)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, m), err)
+	}
+
+	return chapters, nil
+}
+
 // UpdateNameInDB updates the manga name in the database
 func (m *Manga) UpdateNameInDB(name string) error {
 	contextError := "error updating manga '%s' name to '%s' in DB"
@@ -583,14 +640,16 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
 		lastReleasedChapterURLSelector, lastReleasedChapterURLAttribute                                 sql.NullString
 		lastReleasedChapterNameGetFirst, lastReleasedChapterURLGetFirst                                 sql.NullBool
 
-		lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID sql.NullString
-		lastReleasedChapterUpdatedAt                                                                               sql.NullTime
-		lastReleasedChapterType, multiMangaID                                                                      sql.NullInt32
+		lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID, lastReleasedChapterVolume sql.NullString
+		lastReleasedChapterUpdatedAt                                                                                                          sql.NullTime
+		lastReleasedChapterType, multiMangaID                                                                                                 sql.NullInt32
 
-		lastReadChapterURL, lastReadChapterChapter, lastReadChapterName, lastReadChapterInternalID sql.NullString
-		lastReadChapterUpdatedAt                                                                   sql.NullTime
-		lastReadChapterType                                                                        sql.NullInt32
-		lastReadChapterFromSourceSite                                                              sql.NullBool
+		lastReadChapterURL, lastReadChapterChapter, lastReadChapterName, lastReadChapterInternalID, lastReadChapterVolume sql.NullString
+		lastReadChapterUpdatedAt                                                                                          sql.NullTime
+		lastReadChapterType                                                                                               sql.NullInt32
+		lastReadChapterFromSourceSite                                                                                     sql.NullBool
+
+		updateStatus nullUpdateStatus
 	)
 	if mangaID > 0 {
 		query := `
@@ -601,6 +660,7 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
                 mangas.name,
                 mangas.internal_id,
                 mangas.preferred_group,
+                mangas.preferred_language,
                 mangas.cover_img_url,
                 mangas.cover_img,
                 mangas.cover_img_resized,
@@ -615,11 +675,17 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
 				mangas.last_released_chapter_url_attribute,
 				mangas.last_released_chapter_url_get_first,
 				mangas.last_released_chapter_selector_use_browser,
+                mangas.update_last_attempt_at,
+                mangas.update_last_success_at,
+                mangas.update_failing_since,
+                mangas.update_last_error,
+                mangas.update_consecutive_failures,
                 
                 last_released_chapter.url AS last_released_chapter_url,
                 last_released_chapter.chapter AS last_released_chapter,
                 last_released_chapter.name AS last_released_chapter_name,
                 last_released_chapter.internal_id AS last_released_chapter_internal_id,
+                last_released_chapter.volume AS last_released_chapter_volume,
                 last_released_chapter.updated_at AS last_released_chapter_updated_at,
                 last_released_chapter.type AS last_released_chapter_type,
 
@@ -627,6 +693,7 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
                 last_read_chapter.chapter AS last_read_chapter,
                 last_read_chapter.name AS last_read_chapter_name,
                 last_read_chapter.internal_id AS last_read_chapter_internal_id,
+                last_read_chapter.volume AS last_read_chapter_volume,
                 last_read_chapter.updated_at AS last_read_chapter_updated_at,
                 last_read_chapter.type AS last_read_chapter_type,
                 last_read_chapter.from_source_site AS last_read_chapter_from_source_site
@@ -641,20 +708,22 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
         `
 		err := db.QueryRow(query, mangaID).Scan(
 			&currentManga.ID, &currentManga.Source, &currentManga.URL, &currentManga.Name,
-			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.CoverImgURL,
+			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.PreferredLanguage, &currentManga.CoverImgURL,
 			&currentManga.CoverImg, &currentManga.CoverImgResized, &currentManga.CoverImgFixed, &currentManga.Status, &multiMangaID,
 
 			&lastReleasedChapterNameSelector, &lastReleasedChapterNameAttribute, &lastReleasedChapterNameRegex, &lastReleasedChapterNameGetFirst,
 			&lastReleasedChapterURLSelector, &lastReleasedChapterURLAttribute, &lastReleasedChapterURLGetFirst,
 			&currentManga.LastReleasedChapterSelectorUseBrowser,
+			&updateStatus.lastAttemptAt, &updateStatus.lastSuccessAt, &updateStatus.failingSince, &updateStatus.lastError, &updateStatus.consecutiveFailures,
 
 			&lastReleasedChapterURL, &lastReleasedChapterChapter, &lastReleasedChapterName,
-			&lastReleasedChapterInternalID, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
+			&lastReleasedChapterInternalID, &lastReleasedChapterVolume, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
 
 			&lastReadChapterURL, &lastReadChapterChapter, &lastReadChapterName,
-			&lastReadChapterInternalID, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
+			&lastReadChapterInternalID, &lastReadChapterVolume, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
 		)
 		currentManga.SearchNames = []string{currentManga.Name}
+		currentManga.UpdateStatus = updateStatus.toUpdateStatus()
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, errordefs.ErrMangaNotFoundDB
@@ -670,6 +739,7 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
                 mangas.name,
                 mangas.internal_id,
                 mangas.preferred_group,
+                mangas.preferred_language,
                 mangas.cover_img_url,
                 mangas.cover_img,
                 mangas.cover_img_resized,
@@ -684,11 +754,17 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
 				mangas.last_released_chapter_url_attribute,
 				mangas.last_released_chapter_url_get_first,
 				mangas.last_released_chapter_selector_use_browser,
+                mangas.update_last_attempt_at,
+                mangas.update_last_success_at,
+                mangas.update_failing_since,
+                mangas.update_last_error,
+                mangas.update_consecutive_failures,
                 
                 last_released_chapter.url AS last_released_chapter_url,
                 last_released_chapter.chapter AS last_released_chapter,
                 last_released_chapter.name AS last_released_chapter_name,
                 last_released_chapter.internal_id AS last_released_chapter_internal_id,
+                last_released_chapter.volume AS last_released_chapter_volume,
                 last_released_chapter.updated_at AS last_released_chapter_updated_at,
                 last_released_chapter.type AS last_released_chapter_type,
 
@@ -696,6 +772,7 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
                 last_read_chapter.chapter AS last_read_chapter,
                 last_read_chapter.name AS last_read_chapter_name,
                 last_read_chapter.internal_id AS last_read_chapter_internal_id,
+                last_read_chapter.volume AS last_read_chapter_volume,
                 last_read_chapter.updated_at AS last_read_chapter_updated_at,
                 last_read_chapter.type AS last_read_chapter_type,
                 last_read_chapter.from_source_site AS last_read_chapter_from_source_site
@@ -710,20 +787,22 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
         `
 		err := db.QueryRow(query, mangaURL).Scan(
 			&currentManga.ID, &currentManga.Source, &currentManga.URL, &currentManga.Name,
-			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.CoverImgURL,
+			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.PreferredLanguage, &currentManga.CoverImgURL,
 			&currentManga.CoverImg, &currentManga.CoverImgResized, &currentManga.CoverImgFixed, &currentManga.Status, &multiMangaID,
 
 			&lastReleasedChapterNameSelector, &lastReleasedChapterNameAttribute, &lastReleasedChapterNameRegex, &lastReleasedChapterNameGetFirst,
 			&lastReleasedChapterURLSelector, &lastReleasedChapterURLAttribute, &lastReleasedChapterURLGetFirst,
 			&currentManga.LastReleasedChapterSelectorUseBrowser,
+			&updateStatus.lastAttemptAt, &updateStatus.lastSuccessAt, &updateStatus.failingSince, &updateStatus.lastError, &updateStatus.consecutiveFailures,
 
 			&lastReleasedChapterURL, &lastReleasedChapterChapter, &lastReleasedChapterName,
-			&lastReleasedChapterInternalID, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
+			&lastReleasedChapterInternalID, &lastReleasedChapterVolume, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
 
 			&lastReadChapterURL, &lastReadChapterChapter, &lastReadChapterName,
-			&lastReadChapterInternalID, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
+			&lastReadChapterInternalID, &lastReadChapterVolume, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
 		)
 		currentManga.SearchNames = []string{currentManga.Name}
+		currentManga.UpdateStatus = updateStatus.toUpdateStatus()
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, errordefs.ErrMangaNotFoundDB
@@ -759,6 +838,7 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
 		lastReleasedChapter.Chapter = lastReleasedChapterChapter.String
 		lastReleasedChapter.Name = lastReleasedChapterName.String
 		lastReleasedChapter.InternalID = lastReleasedChapterInternalID.String
+		lastReleasedChapter.Volume = lastReleasedChapterVolume.String
 		lastReleasedChapter.UpdatedAt = lastReleasedChapterUpdatedAt.Time
 		lastReleasedChapter.Type = Type(lastReleasedChapterType.Int32)
 		currentManga.LastReleasedChapter = &lastReleasedChapter
@@ -769,6 +849,7 @@ func getMangaFromDB(mangaID ID, mangaURL string, db *sql.DB) (*Manga, error) {
 		lastReadChapter.Chapter = lastReadChapterChapter.String
 		lastReadChapter.Name = lastReadChapterName.String
 		lastReadChapter.InternalID = lastReadChapterInternalID.String
+		lastReadChapter.Volume = lastReadChapterVolume.String
 		lastReadChapter.UpdatedAt = lastReadChapterUpdatedAt.Time
 		lastReadChapter.Type = Type(lastReadChapterType.Int32)
 		lastReadChapter.FromSourceSite = lastReadChapterFromSourceSite.Bool
@@ -841,6 +922,7 @@ func getMangasWithoutMultiMangasFromDB(db *sql.DB, customManga bool) ([]*Manga,
             mangas.name,
             mangas.internal_id,
             mangas.preferred_group,
+            mangas.preferred_language,
             mangas.cover_img_url,
             mangas.cover_img,
             mangas.cover_img_resized,
@@ -853,11 +935,17 @@ func getMangasWithoutMultiMangasFromDB(db *sql.DB, customManga bool) ([]*Manga,
 			mangas.last_released_chapter_url_attribute,
 			mangas.last_released_chapter_url_get_first,
 			mangas.last_released_chapter_selector_use_browser,
+            mangas.update_last_attempt_at,
+            mangas.update_last_success_at,
+            mangas.update_failing_since,
+            mangas.update_last_error,
+            mangas.update_consecutive_failures,
 
             last_released_chapter.url AS last_released_chapter_url,
             last_released_chapter.chapter AS last_released_chapter,
             last_released_chapter.name AS last_released_chapter_name,
             last_released_chapter.internal_id AS last_released_chapter_internal_id,
+            last_released_chapter.volume AS last_released_chapter_volume,
             last_released_chapter.updated_at AS last_released_chapter_updated_at,
             last_released_chapter.type AS last_released_chapter_type,
 
@@ -865,6 +953,7 @@ func getMangasWithoutMultiMangasFromDB(db *sql.DB, customManga bool) ([]*Manga,
             last_read_chapter.chapter AS last_read_chapter,
             last_read_chapter.name AS last_read_chapter_name,
             last_read_chapter.internal_id AS last_read_chapter_internal_id,
+            last_read_chapter.volume AS last_read_chapter_volume,
             last_read_chapter.updated_at AS last_read_chapter_updated_at,
             last_read_chapter.type AS last_read_chapter_type,
 			last_read_chapter.from_source_site AS last_read_chapter_from_source_site
@@ -898,35 +987,39 @@ func getMangasWithoutMultiMangasFromDB(db *sql.DB, customManga bool) ([]*Manga,
 			lastReleasedChapterURLSelector, lastReleasedChapterURLAttribute                                 sql.NullString
 			lastReleasedChapterNameGetFirst, lastReleasedChapterURLGetFirst                                 sql.NullBool
 
-			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID sql.NullString
-			lastReleasedChapterUpdatedAt                                                                               sql.NullTime
-			lastReleasedChapterType                                                                                    sql.NullInt32
+			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID, lastReleasedChapterVolume sql.NullString
+			lastReleasedChapterUpdatedAt                                                                                                          sql.NullTime
+			lastReleasedChapterType                                                                                                               sql.NullInt32
+
+			lastReadChapterURL, lastReadChapterChapter, lastReadChapterName, lastReadChapterInternalID, lastReadChapterVolume sql.NullString
+			lastReadChapterUpdatedAt                                                                                          sql.NullTime
+			lastReadChapterType                                                                                               sql.NullInt32
+			lastReadChapterFromSourceSite                                                                                     sql.NullBool
 
-			lastReadChapterURL, lastReadChapterChapter, lastReadChapterName, lastReadChapterInternalID sql.NullString
-			lastReadChapterUpdatedAt                                                                   sql.NullTime
-			lastReadChapterType                                                                        sql.NullInt32
-			lastReadChapterFromSourceSite                                                              sql.NullBool
+			updateStatus nullUpdateStatus
 		)
 
 		err := rows.Scan(
 			&currentManga.ID, &currentManga.Source, &currentManga.URL, &currentManga.Name,
-			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.CoverImgURL,
+			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.PreferredLanguage, &currentManga.CoverImgURL,
 			&currentManga.CoverImg, &currentManga.CoverImgResized, &currentManga.Status,
 
 			&lastReleasedChapterNameSelector, &lastReleasedChapterNameAttribute, &lastReleasedChapterNameRegex, &lastReleasedChapterNameGetFirst,
 			&lastReleasedChapterURLSelector, &lastReleasedChapterURLAttribute, &lastReleasedChapterURLGetFirst,
 			&currentManga.LastReleasedChapterSelectorUseBrowser,
+			&updateStatus.lastAttemptAt, &updateStatus.lastSuccessAt, &updateStatus.failingSince, &updateStatus.lastError, &updateStatus.consecutiveFailures,
 
 			&lastReleasedChapterURL, &lastReleasedChapterChapter, &lastReleasedChapterName,
-			&lastReleasedChapterInternalID, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
+			&lastReleasedChapterInternalID, &lastReleasedChapterVolume, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
 
 			&lastReadChapterURL, &lastReadChapterChapter, &lastReadChapterName,
-			&lastReadChapterInternalID, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
+			&lastReadChapterInternalID, &lastReadChapterVolume, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
 		)
 		if err != nil {
 			return nil, err
 		}
 		currentManga.SearchNames = []string{currentManga.Name}
+		currentManga.UpdateStatus = updateStatus.toUpdateStatus()
 
 		if lastReleasedChapterNameSelector.Valid && lastReleasedChapterNameSelector.String != "" {
 			currentManga.LastReleasedChapterNameSelector = &HTMLSelector{
@@ -949,6 +1042,7 @@ func getMangasWithoutMultiMangasFromDB(db *sql.DB, customManga bool) ([]*Manga,
 			lastReleasedChapter.Chapter = lastReleasedChapterChapter.String
 			lastReleasedChapter.Name = lastReleasedChapterName.String
 			lastReleasedChapter.InternalID = lastReleasedChapterInternalID.String
+			lastReleasedChapter.Volume = lastReleasedChapterVolume.String
 			lastReleasedChapter.UpdatedAt = lastReleasedChapterUpdatedAt.Time
 			lastReleasedChapter.Type = Type(lastReleasedChapterType.Int32)
 			currentManga.LastReleasedChapter = &lastReleasedChapter
@@ -959,6 +1053,7 @@ func getMangasWithoutMultiMangasFromDB(db *sql.DB, customManga bool) ([]*Manga,
 			lastReadChapter.Chapter = lastReadChapterChapter.String
 			lastReadChapter.Name = lastReadChapterName.String
 			lastReadChapter.InternalID = lastReadChapterInternalID.String
+			lastReadChapter.Volume = lastReadChapterVolume.String
 			lastReadChapter.UpdatedAt = lastReadChapterUpdatedAt.Time
 			lastReadChapter.Type = Type(lastReadChapterType.Int32)
 			lastReadChapter.FromSourceSite = lastReadChapterFromSourceSite.Bool
@@ -1003,6 +1098,7 @@ func getCustomMangasFromDB(db *sql.DB) ([]*Manga, error) {
             mangas.name,
             mangas.internal_id,
             mangas.preferred_group,
+            mangas.preferred_language,
             mangas.cover_img_url,
             mangas.cover_img,
             mangas.cover_img_resized,
@@ -1015,11 +1111,17 @@ func getCustomMangasFromDB(db *sql.DB) ([]*Manga, error) {
 			mangas.last_released_chapter_url_attribute,
 			mangas.last_released_chapter_url_get_first,
 			mangas.last_released_chapter_selector_use_browser,
+            mangas.update_last_attempt_at,
+            mangas.update_last_success_at,
+            mangas.update_failing_since,
+            mangas.update_last_error,
+            mangas.update_consecutive_failures,
             
             last_released_chapter.url AS last_released_chapter_url,
             last_released_chapter.chapter AS last_released_chapter,
             last_released_chapter.name AS last_released_chapter_name,
             last_released_chapter.internal_id AS last_released_chapter_internal_id,
+            last_released_chapter.volume AS last_released_chapter_volume,
             last_released_chapter.updated_at AS last_released_chapter_updated_at,
             last_released_chapter.type AS last_released_chapter_type,
 
@@ -1027,6 +1129,7 @@ func getCustomMangasFromDB(db *sql.DB) ([]*Manga, error) {
             last_read_chapter.chapter AS last_read_chapter,
             last_read_chapter.name AS last_read_chapter_name,
             last_read_chapter.internal_id AS last_read_chapter_internal_id,
+            last_read_chapter.volume AS last_read_chapter_volume,
             last_read_chapter.updated_at AS last_read_chapter_updated_at,
             last_read_chapter.type AS last_read_chapter_type,
 			last_read_chapter.from_source_site AS last_read_chapter_from_source_site
@@ -1056,36 +1159,40 @@ func getCustomMangasFromDB(db *sql.DB) ([]*Manga, error) {
 			lastReleasedChapterURLSelector, lastReleasedChapterURLAttribute                                 sql.NullString
 			lastReleasedChapterNameGetFirst, lastReleasedChapterURLGetFirst                                 sql.NullBool
 
-			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID sql.NullString
-			lastReleasedChapterUpdatedAt                                                                               sql.NullTime
-			lastReleasedChapterType                                                                                    sql.NullInt32
+			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID, lastReleasedChapterVolume sql.NullString
+			lastReleasedChapterUpdatedAt                                                                                                          sql.NullTime
+			lastReleasedChapterType                                                                                                               sql.NullInt32
+
+			lastReadChapterURL, lastReadChapterChapter, lastReadChapterName, lastReadChapterInternalID, lastReadChapterVolume sql.NullString
+			lastReadChapterUpdatedAt                                                                                          sql.NullTime
+			lastReadChapterType                                                                                               sql.NullInt32
+			lastReadChapterFromSourceSite                                                                                     sql.NullBool
 
-			lastReadChapterURL, lastReadChapterChapter, lastReadChapterName, lastReadChapterInternalID sql.NullString
-			lastReadChapterUpdatedAt                                                                   sql.NullTime
-			lastReadChapterType                                                                        sql.NullInt32
-			lastReadChapterFromSourceSite                                                              sql.NullBool
+			updateStatus nullUpdateStatus
 		)
 
 		err := rows.Scan(
 			&currentManga.ID, &currentManga.Source, &currentManga.URL, &currentManga.Name,
-			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.CoverImgURL,
+			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.PreferredLanguage, &currentManga.CoverImgURL,
 			&currentManga.CoverImg, &currentManga.CoverImgResized, &currentManga.Status,
 
 			&lastReleasedChapterNameSelector, &lastReleasedChapterNameAttribute, &lastReleasedChapterNameRegex, &lastReleasedChapterNameGetFirst,
 			&lastReleasedChapterURLSelector, &lastReleasedChapterURLAttribute, &lastReleasedChapterURLGetFirst,
 			&currentManga.LastReleasedChapterSelectorUseBrowser,
+			&updateStatus.lastAttemptAt, &updateStatus.lastSuccessAt, &updateStatus.failingSince, &updateStatus.lastError, &updateStatus.consecutiveFailures,
 
 			&lastReleasedChapterURL, &lastReleasedChapterChapter, &lastReleasedChapterName,
-			&lastReleasedChapterInternalID, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
+			&lastReleasedChapterInternalID, &lastReleasedChapterVolume, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
 
 			&lastReadChapterURL, &lastReadChapterChapter, &lastReadChapterName,
-			&lastReadChapterInternalID, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
+			&lastReadChapterInternalID, &lastReadChapterVolume, &lastReadChapterUpdatedAt, &lastReadChapterType, &lastReadChapterFromSourceSite,
 		)
 		if err != nil {
 			return nil, err
 		}
 
 		currentManga.SearchNames = []string{currentManga.Name}
+		currentManga.UpdateStatus = updateStatus.toUpdateStatus()
 
 		if lastReleasedChapterNameSelector.Valid && lastReleasedChapterNameSelector.String != "" {
 			currentManga.LastReleasedChapterNameSelector = &HTMLSelector{
@@ -1108,6 +1215,7 @@ func getCustomMangasFromDB(db *sql.DB) ([]*Manga, error) {
 			lastReleasedChapter.Chapter = lastReleasedChapterChapter.String
 			lastReleasedChapter.Name = lastReleasedChapterName.String
 			lastReleasedChapter.InternalID = lastReleasedChapterInternalID.String
+			lastReleasedChapter.Volume = lastReleasedChapterVolume.String
 			lastReleasedChapter.UpdatedAt = lastReleasedChapterUpdatedAt.Time
 			lastReleasedChapter.Type = Type(lastReleasedChapterType.Int32)
 			currentManga.LastReleasedChapter = &lastReleasedChapter
@@ -1118,6 +1226,7 @@ func getCustomMangasFromDB(db *sql.DB) ([]*Manga, error) {
 			lastReadChapter.Chapter = lastReadChapterChapter.String
 			lastReadChapter.Name = lastReadChapterName.String
 			lastReadChapter.InternalID = lastReadChapterInternalID.String
+			lastReadChapter.Volume = lastReadChapterVolume.String
 			lastReadChapter.UpdatedAt = lastReadChapterUpdatedAt.Time
 			lastReadChapter.Type = Type(lastReadChapterType.Int32)
 			lastReadChapter.FromSourceSite = lastReadChapterFromSourceSite.Bool
@@ -1295,6 +1404,15 @@ func getLibraryStatsFromDB(db *sql.DB) (map[string]int, error) {
 	stats["Total"] = total
 	stats["Read"] = read
 
+	unreadChapters, err := getMultiMangasUnreadChaptersFromDB(-1, 0, db)
+	if err != nil {
+		return nil, err
+	}
+	stats["UnreadChapters"] = 0
+	for _, multimangaUnreadChapters := range unreadChapters {
+		stats["UnreadChapters"] += multimangaUnreadChapters
+	}
+
 	return stats, nil
 }
 
@@ -1367,6 +1485,141 @@ func updateMangaSourceDB(m *Manga, source string, tx *sql.Tx) error {
 	return nil
 }
 
+// PreferredGroups returns the manga's preferred groups, or nil if it doesn't have preferred groups.
+func (m *Manga) PreferredGroups() []string {
+	var groups []string
+	for _, group := range strings.Split(m.PreferredGroup, ",") {
+		group = strings.TrimSpace(group)
+		if group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// PreferredLanguages returns the manga's preferred translated languages, or nil if it doesn't have preferred languages.
+func (m *Manga) PreferredLanguages() []string {
+	var languages []string
+	for _, language := range strings.Split(m.PreferredLanguage, ",") {
+		language = strings.ToLower(strings.TrimSpace(language))
+		if language != "" {
+			languages = append(languages, language)
+		}
+	}
+
+	return languages
+}
+
+// ValidateLanguages validates comma-separated translated language codes, like "en, pt-br".
+func ValidateLanguages(languages string) error {
+	for _, language := range (&Manga{PreferredLanguage: languages}).PreferredLanguages() {
+		if !util.IsLanguageCode(language) {
+			return fmt.Errorf("invalid language code '%s', should be like 'en' or 'pt-br'", language)
+		}
+	}
+
+	return nil
+}
+
+// UpdateChapterPreferencesInDB updates the manga preferred group and preferred language in the database.
+// The last released chapter should be the last chapter released in the new preferred groups and languages.
+// If it's nil, the manga's last released chapter is deleted.
+func (m *Manga) UpdateChapterPreferencesInDB(preferredGroup, preferredLanguage string, lastReleasedChapter *Chapter) error {
+	contextError := "error updating manga '%s' preferred group to '%s' and preferred language to '%s' in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, m, preferredGroup, preferredLanguage), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, m, preferredGroup, preferredLanguage), err)
+	}
+
+	err = updateMangaChapterPreferencesDB(m, preferredGroup, preferredLanguage, tx)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, m, preferredGroup, preferredLanguage), err)
+	}
+
+	if lastReleasedChapter != nil {
+		err = upsertMangaChapter(m.ID, lastReleasedChapter, tx)
+		if err != nil {
+			tx.Rollback()
+			return util.AddErrorContext(fmt.Sprintf(contextError, m, preferredGroup, preferredLanguage), err)
+		}
+	} else if m.LastReleasedChapter != nil {
+		err = deleteMangaChapter(m.ID, m.LastReleasedChapter, tx)
+		if err != nil {
+			tx.Rollback()
+			return util.AddErrorContext(fmt.Sprintf(contextError, m, preferredGroup, preferredLanguage), err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, m, preferredGroup, preferredLanguage), err)
+	}
+	m.PreferredGroup = preferredGroup
+	m.PreferredLanguage = preferredLanguage
+	m.LastReleasedChapter = lastReleasedChapter
+
+	return nil
+}
+
+func updateMangaChapterPreferencesDB(m *Manga, preferredGroup, preferredLanguage string, tx *sql.Tx) error {
+	err := validateManga(m)
+	if err != nil {
+		return err
+	}
+	if len(preferredGroup) > 255 {
+		return fmt.Errorf("preferred group should have at most 255 characters, instead it has %d", len(preferredGroup))
+	}
+	if len(preferredLanguage) > 255 {
+		return fmt.Errorf("preferred language should have at most 255 characters, instead it has %d", len(preferredLanguage))
+	}
+	err = ValidateLanguages(preferredLanguage)
+	if err != nil {
+		return err
+	}
+
+	var result sql.Result
+	if m.ID > 0 {
+		result, err = tx.Exec(`
+            UPDATE mangas
+            SET preferred_group = $1, preferred_language = $2
+            WHERE id = $3;
+        `, preferredGroup, preferredLanguage, m.ID)
+		if err != nil {
+			return err
+		}
+	} else if m.URL != "" {
+		result, err = tx.Exec(`
+            UPDATE mangas
+            SET preferred_group = $1, preferred_language = $2
+            WHERE url = $3;
+        `, preferredGroup, preferredLanguage, m.URL)
+		if err != nil {
+			return err
+		}
+	} else {
+		return errordefs.ErrMangaHasNoIDOrURL
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errordefs.ErrMangaNotFoundDB
+	}
+
+	return nil
+}
+
 func (m *Manga) UpdateLastReleasedChapterSelectorsInDB(nameSelector, URLSelector *HTMLSelector, useBrowser bool) error {
 	var chapter *Chapter
 	var err error
@@ -1533,15 +1786,84 @@ func ValidateStatus(status Status) error {
 	return nil
 }
 
+// GetStatusUpdateIntervalsDB returns the update interval in minutes of each status.
+// Statuses without an update interval are not in the map.
+func GetStatusUpdateIntervalsDB() (map[Status]int, error) {
+	contextError := "error getting status update intervals from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+        SELECT
+            status, update_interval
+        FROM
+            status_update_intervals;
+    `)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer rows.Close()
+
+	intervals := map[Status]int{}
+	for rows.Next() {
+		var status Status
+		var updateInterval int
+		err = rows.Scan(&status, &updateInterval)
+		if err != nil {
+			return nil, util.AddErrorContext(contextError, err)
+		}
+		intervals[status] = updateInterval
+	}
+
+	return intervals, nil
+}
+
+// UpdateStatusUpdateIntervalDB sets the update interval in minutes of a status in the database.
+// An update interval of 0 means the multimangas with the status are checked on every run of the update job.
+func UpdateStatusUpdateIntervalDB(status Status, updateInterval int) error {
+	contextError := "error updating status '%d' update interval to '%d' in DB"
+
+	err := ValidateStatus(status)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, status, updateInterval), err)
+	}
+	if updateInterval < 0 {
+		return util.AddErrorContext(fmt.Sprintf(contextError, status, updateInterval), fmt.Errorf("update interval should be >= 0, instead it's %d", updateInterval))
+	}
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, status, updateInterval), err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+        INSERT INTO status_update_intervals (status, update_interval)
+        VALUES ($1, $2)
+        ON CONFLICT (status)
+        DO UPDATE
+            SET update_interval = EXCLUDED.update_interval;
+    `, status, updateInterval)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, status, updateInterval), err)
+	}
+
+	return nil
+}
+
 // FilterUnreadChapterMangas filters a list of mangas to return
 // mangas where the last released chapter is different from the
-// last read chapter
+// last read chapter, compared by their chapter numbers
 func FilterUnreadChapterMangas(mangas []*Manga) []*Manga {
 	unreadChapterMangas := []*Manga{}
 
 	for _, manga := range mangas {
 		if manga.LastReleasedChapter != nil && manga.LastReadChapter != nil {
-			if manga.LastReleasedChapter.Chapter != manga.LastReadChapter.Chapter {
+			if !IsSameChapter(manga.LastReleasedChapter, manga.LastReadChapter) {
 				unreadChapterMangas = append(unreadChapterMangas, manga)
 			}
 		} else if manga.LastReleasedChapter != nil && manga.LastReadChapter == nil {
@@ -1692,11 +2014,32 @@ func getSelectorFromPageUsingBrowser(url string, selector *HTMLSelector) (string
 
 	var u string
 	var err error
+	l := launcher.New()
 	if config.GlobalConfigs.API.RodBrowserPath != "" {
-		u, err = launcher.New().Bin(config.GlobalConfigs.API.RodBrowserPath).Headless(true).NoSandbox(true).Set("disable-dev-shm-usage").Set("disable-gpu").Set("no-zygote").Set("single-process").Launch()
+		l = l.Bin(config.GlobalConfigs.API.RodBrowserPath).Headless(true).NoSandbox(true).Set("disable-dev-shm-usage").Set("disable-gpu").Set("no-zygote").Set("single-process")
+	}
+	proxy := util.GetSourceProxy(CustomMangaSource)
+	if proxy != nil {
+		// The browser only takes the proxy host in its flags, the credentials are
+		// sent when the proxy asks for them, which the browser doesn't do for SOCKS proxies
+		scheme := proxy.Scheme
+		if scheme == "socks5h" {
+			scheme = "socks5"
+		}
+		if proxy.User != nil && strings.HasPrefix(scheme, "socks") {
+			return "", util.AddErrorContext(fmt.Sprintf(contextError, selector, url), fmt.Errorf("the browser doesn't support SOCKS proxies with credentials"))
+		}
+		l = l.Proxy(scheme + "://" + proxy.Host)
+	}
+	if config.GlobalConfigs.API.RodBrowserPath != "" || proxy != nil {
+		u, err = l.Launch()
 		if err != nil {
 			return "", util.AddErrorContext(fmt.Sprintf(contextError, selector, url), util.AddErrorContext("error launching browser", err))
 		}
+		defer func() {
+			l.Kill()
+			l.Cleanup()
+		}()
 	}
 
 	browser := rod.New().ControlURL(u)
@@ -1705,6 +2048,10 @@ func getSelectorFromPageUsingBrowser(url string, selector *HTMLSelector) (string
 		return "", util.AddErrorContext(fmt.Sprintf(contextError, selector, url), util.AddErrorContext("error connecting to browser", err))
 	}
 	defer browser.MustClose()
+	if proxy != nil && proxy.User != nil {
+		password, _ := proxy.User.Password()
+		go browser.HandleAuth(proxy.User.Username(), password)()
+	}
 
 	page := browser.MustPage(url).MustSetUserAgent(&proto.NetworkSetUserAgentOverride{UserAgent: userAgent})
 	defer page.MustClose()
@@ -1808,7 +2155,7 @@ func getSelectorFromPageUsingHTTPRequest(url string, selector *HTMLSelector) (st
 		return "", util.AddErrorContext(fmt.Sprintf(contextError, selector, url), fmt.Errorf("manga.HTMLSelector.Selector is empty"))
 	}
 
-	c := colly.NewCollector(colly.UserAgent(userAgent))
+	c := util.NewSourcesCollector(CustomMangaSource, colly.UserAgent(userAgent))
 
 	var selection string
 	if after, ok := strings.CutPrefix(selector.Selector, "css:"); ok {
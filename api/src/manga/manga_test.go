@@ -3,6 +3,7 @@ package manga
 import (
 	"fmt"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
@@ -80,6 +81,154 @@ func TestMangaString(t *testing.T) {
 	})
 }
 
+func TestPreferredGroups(t *testing.T) {
+	testCases := map[string][]string{
+		"":                    nil,
+		"Group A":             {"Group A"},
+		" Group A, ,Group B ": {"Group A", "Group B"},
+	}
+	for preferredGroup, expected := range testCases {
+		groups := (&Manga{PreferredGroup: preferredGroup}).PreferredGroups()
+		if !slices.Equal(groups, expected) {
+			t.Fatalf("Expected preferred groups of '%s' to be %v, got %v", preferredGroup, expected, groups)
+		}
+	}
+}
+
+func TestPreferredLanguages(t *testing.T) {
+	languages := (&Manga{PreferredLanguage: " EN, ,pt-br "}).PreferredLanguages()
+	if !slices.Equal(languages, []string{"en", "pt-br"}) {
+		t.Fatalf("Unexpected preferred languages: %v", languages)
+	}
+	for _, valid := range []string{"", "en", "pt-br, es-la"} {
+		if err := ValidateLanguages(valid); err != nil {
+			t.Fatalf("Expected '%s' to be valid, got error: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"english", "en_US", "en, 1"} {
+		if err := ValidateLanguages(invalid); err == nil {
+			t.Fatalf("Expected '%s' to be invalid", invalid)
+		}
+	}
+}
+
+func TestMangaPreferredLanguageDBLifeCycle(t *testing.T) {
+	manga := getMangaCopy(mangaTest)
+	manga.URL = "https://testingsite/manga/preferred-language-manga"
+	manga.LastReleasedChapter.URL = manga.URL + "/chapter-15"
+	manga.LastReadChapter = nil
+	manga.PreferredLanguage = "en, pt-br"
+	multiManga := &MultiManga{Status: manga.Status, CurrentManga: manga, Mangas: []*Manga{manga}}
+
+	t.Run("Should insert a multimanga with a manga with preferred languages into DB", func(t *testing.T) {
+		err := multiManga.InsertIntoDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Should get the manga's preferred languages from DB", func(t *testing.T) {
+		mangaDB, err := GetMangaDB(0, manga.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mangaDB.PreferredLanguage != manga.PreferredLanguage || mangaDB.PreferredGroup != manga.PreferredGroup {
+			t.Fatalf("Expected preferred group '%s' and language '%s', got '%s' and '%s'", manga.PreferredGroup, manga.PreferredLanguage, mangaDB.PreferredGroup, mangaDB.PreferredLanguage)
+		}
+	})
+	t.Run("Should update the manga's preferred languages in DB", func(t *testing.T) {
+		err := manga.UpdateChapterPreferencesInDB("", "es-la", manga.LastReleasedChapter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mangaDB, err := GetMangaDB(manga.ID, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mangaDB.PreferredLanguage != "es-la" {
+			t.Fatalf("Expected preferred language 'es-la', got '%s'", mangaDB.PreferredLanguage)
+		}
+	})
+	t.Run("Should delete the multimanga from DB", func(t *testing.T) {
+		err := multiManga.DeleteFromDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestMangaChaptersHistoryDBLifeCycle(t *testing.T) {
+	manga := getMangaCopy(mangaTest)
+	manga.URL = "https://testingsite/manga/chapters-history-manga"
+	manga.LastReleasedChapter.URL = manga.URL + "/chapter-15"
+	manga.LastReadChapter = nil
+	multiManga := &MultiManga{Status: manga.Status, CurrentManga: manga, Mangas: []*Manga{manga}}
+	var lastReleasedChapterFirstSeenAt time.Time
+
+	t.Run("Should insert a multimanga with a manga into DB", func(t *testing.T) {
+		err := multiManga.InsertIntoDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Should store the manga's last released chapter in the chapters history", func(t *testing.T) {
+		chapters, err := manga.GetChaptersHistoryFromDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chapters) != 1 || chapters[0].URL != manga.LastReleasedChapter.URL || chapters[0].MangaID != manga.ID {
+			t.Fatalf("Expected the chapters history to have only the last released chapter, got %v", chapters)
+		}
+		lastReleasedChapterFirstSeenAt = chapters[0].FirstSeenAt
+	})
+	t.Run("Should upsert chapters into the chapters history", func(t *testing.T) {
+		renamedChapter := *manga.LastReleasedChapter
+		renamedChapter.Name = "Chapter 15: Renamed"
+		chapters := []*Chapter{
+			{URL: manga.URL + "/chapter-16", Chapter: "16", Name: "Chapter 16", UpdatedAt: time.Now().Add(time.Hour), Type: 1},
+			&renamedChapter,
+			{URL: "", Chapter: "17", Name: "Chapter without URL", Type: 1},
+		}
+		time.Sleep(1 * time.Second)
+		err := manga.UpsertChaptersHistoryIntoDB(chapters)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Should get the chapters history from DB", func(t *testing.T) {
+		chapters, err := manga.GetChaptersHistoryFromDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chapters) != 2 {
+			t.Fatalf("Expected 2 chapters in the chapters history, got %v", chapters)
+		}
+		if chapters[0].URL != manga.URL+"/chapter-16" {
+			t.Fatalf("Expected the newest chapter to be the first, got %v", chapters)
+		}
+		if chapters[1].Name != "Chapter 15: Renamed" {
+			t.Fatalf("Expected the existing chapter to be updated, got %s", chapters[1])
+		}
+		if !chapters[1].FirstSeenAt.Equal(lastReleasedChapterFirstSeenAt) {
+			t.Fatalf("Expected the existing chapter to keep its first seen time '%s', got '%s'", lastReleasedChapterFirstSeenAt, chapters[1].FirstSeenAt)
+		}
+	})
+	t.Run("Should delete the multimanga from DB", func(t *testing.T) {
+		err := multiManga.DeleteFromDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Should delete the manga's chapters history from DB", func(t *testing.T) {
+		chapters, err := manga.GetChaptersHistoryFromDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chapters) != 0 {
+			t.Fatalf("Expected the chapters history to be empty, got %v", chapters)
+		}
+	})
+}
+
 func TestMangaDBLifeCycle(t *testing.T) {
 	manga := getMangaCopy(mangaTest)
 
@@ -447,6 +596,96 @@ func TestCustomMangaLastReleasedChapter(t *testing.T) {
 	})
 }
 
+func TestCountUnreadChapters(t *testing.T) {
+	chapters := []*Chapter{
+		{URL: "https://testingsite/manga/best-manga/chapter-15", Chapter: "15"},
+		{URL: "https://testingsite/manga/best-manga/chapter-14-other-group", Chapter: "14"},
+		{URL: "https://testingsite/manga/best-manga/chapter-14", Chapter: "14"},
+		{URL: "https://testingsite/manga/best-manga/chapter-13", Chapter: "13"},
+		{URL: "https://testingsite/manga/best-manga/chapter-12", Chapter: "12"},
+	}
+	t.Run("Should count all chapters if there is no last read chapter", func(t *testing.T) {
+		unread := CountUnreadChapters(chapters, nil)
+		if unread != 4 {
+			t.Fatalf("Expected 4 unread chapters, got %d", unread)
+		}
+	})
+	t.Run("Should count chapters before the last read chapter", func(t *testing.T) {
+		unread := CountUnreadChapters(chapters, &Chapter{Chapter: "13"})
+		if unread != 2 {
+			t.Fatalf("Expected 2 unread chapters, got %d", unread)
+		}
+	})
+	t.Run("Should count zero chapters if the last read chapter is the newest", func(t *testing.T) {
+		unread := CountUnreadChapters(chapters, &Chapter{Chapter: "15"})
+		if unread != 0 {
+			t.Fatalf("Expected 0 unread chapters, got %d", unread)
+		}
+	})
+	t.Run("Should compare chapter numbers if the last read chapter is not in the list", func(t *testing.T) {
+		unread := CountUnreadChapters(chapters, &Chapter{Chapter: "12.5"})
+		if unread != 3 {
+			t.Fatalf("Expected 3 unread chapters, got %d", unread)
+		}
+	})
+	t.Run("Should compare chapter numbers if the chapters are not sorted", func(t *testing.T) {
+		unsorted := []*Chapter{{Chapter: "13"}, {Chapter: "10"}, {Chapter: "12"}, {Chapter: "11"}}
+		unread := CountUnreadChapters(unsorted, &Chapter{Chapter: "10"})
+		if unread != 3 {
+			t.Fatalf("Expected 3 unread chapters, got %d", unread)
+		}
+	})
+	t.Run("Should count the chapters before a last read chapter without number", func(t *testing.T) {
+		withExtra := []*Chapter{{Chapter: "13"}, {Chapter: "Extra"}, {Chapter: "12"}}
+		unread := CountUnreadChapters(withExtra, &Chapter{Chapter: "Extra"})
+		if unread != 1 {
+			t.Fatalf("Expected 1 unread chapter, got %d", unread)
+		}
+	})
+	t.Run("Should count only the newest chapter if the chapters can't be compared", func(t *testing.T) {
+		unread := CountUnreadChapters(chapters, &Chapter{Chapter: "Oneshot"})
+		if unread != 1 {
+			t.Fatalf("Expected 1 unread chapter, got %d", unread)
+		}
+	})
+}
+
+func TestChaptersBetween(t *testing.T) {
+	chapters := []*Chapter{{Chapter: "14"}, {Chapter: "13"}, {Chapter: "12"}, {Chapter: "11"}, {Chapter: "10"}, {Chapter: "Extra"}}
+	t.Run("Should get the chapters after the old chapter up to the new chapter", func(t *testing.T) {
+		between := ChaptersBetween(chapters, &Chapter{Chapter: "10"}, &Chapter{Chapter: "13"})
+		if len(between) != 3 || between[0].Chapter != "13" || between[2].Chapter != "11" {
+			t.Fatalf("Expected chapters 13, 12, and 11, got %v", between)
+		}
+	})
+	t.Run("Should get all chapters up to the new chapter if there is no old chapter", func(t *testing.T) {
+		between := ChaptersBetween(chapters, nil, &Chapter{Chapter: "12"})
+		if len(between) != 4 {
+			t.Fatalf("Expected 4 chapters, got %v", between)
+		}
+	})
+}
+
+func TestMayHaveChaptersBetween(t *testing.T) {
+	testCases := []struct {
+		old, new *Chapter
+		expected bool
+	}{
+		{&Chapter{Chapter: "10"}, &Chapter{Chapter: "11"}, false},
+		{&Chapter{Chapter: "10"}, &Chapter{Chapter: "10.5"}, false},
+		{&Chapter{Chapter: "10-11"}, &Chapter{Chapter: "12"}, false},
+		{&Chapter{Chapter: "10"}, &Chapter{Chapter: "13"}, true},
+		{&Chapter{Chapter: "10"}, &Chapter{Chapter: "Extra"}, true},
+		{nil, &Chapter{Chapter: "1"}, true},
+		{&Chapter{Chapter: "10"}, nil, false},
+	}
+	for _, tc := range testCases {
+		if actual := MayHaveChaptersBetween(tc.old, tc.new); actual != tc.expected {
+			t.Fatalf("Expected MayHaveChaptersBetween(%v, %v) to be %t", tc.old, tc.new, tc.expected)
+		}
+	}
+}
+
 func getMangaCopy(source *Manga) *Manga {
 	manga := *source
 	if source.LastReleasedChapter != nil {
@@ -4,8 +4,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/diogovalentte/mantium/api/src/db"
 	"github.com/diogovalentte/mantium/api/src/errordefs"
@@ -30,11 +30,28 @@ type MultiManga struct {
 	// Else, use the multimanga's cover image fields.
 	// It's used for when the cover image is manually set by the user.
 	CoverImgFixed bool
+	// LastCheckedAt is the last time the multimanga was checked for new chapters by the update job.
+	LastCheckedAt time.Time
+	// UpdateInterval is the interval in minutes between checks for new chapters of the multimanga.
+	// If 0, the multimanga's status update interval is used.
+	UpdateInterval int
+	// ReleaseWeekday is the day of the week (0 = Sunday, 6 = Saturday) the multimanga usually has new chapters.
+	// If -1, the multimanga doesn't have a release weekday.
+	ReleaseWeekday int
+	// ReleaseDayUpdateInterval is the interval in minutes used instead of the other intervals
+	// on the release weekday. If 0, the release weekday is ignored.
+	ReleaseDayUpdateInterval int
+	// UnreadChapters is the number of chapters of the current manga released after the multimanga's last read chapter.
+	// It's calculated using the current manga's chapters history.
+	UnreadChapters int
+	// UpdateStatus is the result of the metadata updates of the multimanga's mangas from the sources.
+	// The custom mangas have their own update status.
+	UpdateStatus UpdateStatus
 }
 
 func (mm MultiManga) String() string {
-	returnStr := fmt.Sprintf("MultiManga{ID: %d, Status: %d, CoverImg: []byte, CoverImgResized: %v, CoverImgURL: %s, CoverImgFixed: %v, LastReadChapter: %s, CurrentManga: %s, Mangas: [",
-		mm.ID, mm.Status, mm.CoverImgResized, mm.CoverImgURL, mm.CoverImgFixed, mm.LastReadChapter, mm.CurrentManga)
+	returnStr := fmt.Sprintf("MultiManga{ID: %d, Status: %d, CoverImg: []byte, CoverImgResized: %v, CoverImgURL: %s, CoverImgFixed: %v, UnreadChapters: %d, LastReadChapter: %s, CurrentManga: %s, Mangas: [",
+		mm.ID, mm.Status, mm.CoverImgResized, mm.CoverImgURL, mm.CoverImgFixed, mm.UnreadChapters, mm.LastReadChapter, mm.CurrentManga)
 
 	for _, manga := range mm.Mangas {
 		returnStr += manga.String() + ", "
@@ -75,6 +92,55 @@ func (mm *MultiManga) InsertIntoDB() error {
 	return nil
 }
 
+// ImportMultiMangasIntoDB creates the multimangas and their mangas into the database
+// in a single transaction, so no multimanga is created if one of them fails.
+// If replace is true, deletes all multimangas, their mangas, and chapters before.
+func ImportMultiMangasIntoDB(multimangas []*MultiManga, replace bool) error {
+	contextError := "error importing multimangas into DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(contextError, err)
+	}
+
+	if replace {
+		_, err = tx.Exec(`
+            DELETE FROM multimangas;
+        `)
+		if err != nil {
+			tx.Rollback()
+			return util.AddErrorContext(contextError, err)
+		}
+	}
+
+	for _, mm := range multimangas {
+		err = insertMultiMangaIntoDB(mm, tx)
+		if err != nil {
+			tx.Rollback()
+			return util.AddErrorContext(fmt.Sprintf("%s: error creating multimanga '%s'", contextError, mm), err)
+		}
+
+		err = updateMultiMangaUpdateIntervalDB(mm, mm.UpdateInterval, mm.ReleaseWeekday, mm.ReleaseDayUpdateInterval, tx)
+		if err != nil {
+			tx.Rollback()
+			return util.AddErrorContext(fmt.Sprintf("%s: error updating multimanga '%s' update interval", contextError, mm), err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(contextError, err)
+	}
+
+	return nil
+}
+
 // Also creates the multimanga manga list's mangas and set current manga.
 func insertMultiMangaIntoDB(mm *MultiManga, tx *sql.Tx) error {
 	err := validateMultiManga(mm)
@@ -118,7 +184,7 @@ func insertMultiMangaIntoDB(mm *MultiManga, tx *sql.Tx) error {
 	if mm.LastReadChapter != nil {
 		err := upsertMultiMangaChapter(multiMangaID, mm.LastReadChapter, tx)
 		if err != nil {
-			if err.Error() == `pq: duplicate key value violates unique constraint "chapters_pkey"` {
+			if err.Error() == `pq: duplicate key value violates unique constraint "chapters_url_type_unique"` {
 				return fmt.Errorf("last read chapter of the multimanga you're trying to add already exists in DB")
 			}
 			return err
@@ -522,6 +588,144 @@ func (mm *MultiManga) RemoveManga(m *Manga) error {
 	return nil
 }
 
+// UpdateUpdateIntervalInDB updates the multimanga's update interval, release weekday,
+// and release day update interval in the database.
+func (mm *MultiManga) UpdateUpdateIntervalInDB(updateInterval, releaseWeekday, releaseDayUpdateInterval int) error {
+	contextError := "error updating multimanga '%s' update interval in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+
+	err = updateMultiMangaUpdateIntervalDB(mm, updateInterval, releaseWeekday, releaseDayUpdateInterval, tx)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+	mm.UpdateInterval = updateInterval
+	mm.ReleaseWeekday = releaseWeekday
+	mm.ReleaseDayUpdateInterval = releaseDayUpdateInterval
+
+	return nil
+}
+
+func updateMultiMangaUpdateIntervalDB(mm *MultiManga, updateInterval, releaseWeekday, releaseDayUpdateInterval int, tx *sql.Tx) error {
+	err := validateUpdateInterval(updateInterval, releaseWeekday, releaseDayUpdateInterval)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+        UPDATE multimangas
+        SET update_interval = $1, release_weekday = $2, release_day_update_interval = $3
+        WHERE id = $4;
+    `, updateInterval, releaseWeekday, releaseDayUpdateInterval, mm.ID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errordefs.ErrMultiMangaNotFoundDB
+	}
+
+	return nil
+}
+
+func validateUpdateInterval(updateInterval, releaseWeekday, releaseDayUpdateInterval int) error {
+	if updateInterval < 0 {
+		return fmt.Errorf("update interval should be >= 0, instead it's %d", updateInterval)
+	}
+	if releaseWeekday < -1 || releaseWeekday > 6 {
+		return fmt.Errorf("release weekday should be >= -1 && <= 6, instead it's %d", releaseWeekday)
+	}
+	if releaseDayUpdateInterval < 0 {
+		return fmt.Errorf("release day update interval should be >= 0, instead it's %d", releaseDayUpdateInterval)
+	}
+
+	return nil
+}
+
+// UpdateLastCheckedAtInDB updates the last time the multimanga was checked for new chapters in the database.
+// The time is stored in UTC, as the column doesn't have a time zone and is read as UTC.
+func (mm *MultiManga) UpdateLastCheckedAtInDB(lastCheckedAt time.Time) error {
+	contextError := "error updating multimanga '%s' last checked at in DB"
+
+	lastCheckedAt = lastCheckedAt.UTC()
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+	defer db.Close()
+
+	result, err := db.Exec(`
+        UPDATE multimangas
+        SET last_checked_at = $1
+        WHERE id = $2;
+    `, lastCheckedAt, mm.ID)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+	if rowsAffected == 0 {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), errordefs.ErrMultiMangaNotFoundDB)
+	}
+	mm.LastCheckedAt = lastCheckedAt
+
+	return nil
+}
+
+// updateIntervalTolerance is subtracted from the update intervals when checking if a
+// multimanga is due, so small delays in the update job don't make it skip a whole run.
+const updateIntervalTolerance = time.Minute
+
+// GetUpdateInterval returns the interval between checks for new chapters of the multimanga at the time now.
+// The release day update interval is used on the release weekday, the multimanga's update interval
+// is used if set, else the multimanga's status update interval is used.
+// A zero interval means the multimanga should be checked on every run of the update job.
+func (mm *MultiManga) GetUpdateInterval(now time.Time, statusUpdateIntervals map[Status]int) time.Duration {
+	minutes := statusUpdateIntervals[mm.Status]
+	if mm.UpdateInterval > 0 {
+		minutes = mm.UpdateInterval
+	}
+	if mm.ReleaseWeekday >= 0 && mm.ReleaseDayUpdateInterval > 0 && now.Weekday() == time.Weekday(mm.ReleaseWeekday) {
+		minutes = mm.ReleaseDayUpdateInterval
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+// IsDueForUpdate returns true if the multimanga should be checked for new chapters at the time now.
+func (mm *MultiManga) IsDueForUpdate(now time.Time, statusUpdateIntervals map[Status]int) bool {
+	if mm.LastCheckedAt.IsZero() {
+		return true
+	}
+	interval := mm.GetUpdateInterval(now, statusUpdateIntervals)
+	if interval <= 0 {
+		return true
+	}
+
+	return now.Sub(mm.LastCheckedAt) >= interval-updateIntervalTolerance
+}
+
 // GetMultiMangaFromDB gets a multimanga from the database by its ID
 func GetMultiMangaFromDB(multimangaID ID) (*MultiManga, error) {
 	contextError := "error getting multimanga with ID '%d' from DB"
@@ -540,6 +744,12 @@ func GetMultiMangaFromDB(multimangaID ID) (*MultiManga, error) {
 		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
 	}
 
+	unreadChapters, err := getMultiMangasUnreadChaptersFromDB(multimangaID, 0, db)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+	mm.UnreadChapters = unreadChapters[mm.ID]
+
 	return mm, nil
 }
 
@@ -565,9 +775,143 @@ func GetMultiMangasDB(getMangas bool) ([]*MultiManga, error) {
 		return nil, util.AddErrorContext(contextError, err)
 	}
 
+	unreadChapters, err := getMultiMangasUnreadChaptersFromDB(-1, 0, db)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	for _, multimanga := range multimangas {
+		multimanga.UnreadChapters = unreadChapters[multimanga.ID]
+	}
+
 	return multimangas, nil
 }
 
+// getMultiMangasUnreadChaptersFromDB returns the number of unread chapters of the multimangas
+// in a map where the key is the multimanga ID.
+// If multimangaID is -1, gets the unread chapters of all multimangas.
+// The chapters are counted using the current manga's chapters history. If the current
+// manga doesn't have a chapters history yet, its last released chapter is used instead.
+// If userID is greater than 0, the user's last read chapters are used instead of the global ones.
+func getMultiMangasUnreadChaptersFromDB(multimangaID ID, userID int, db *sql.DB) (map[ID]int, error) {
+	query := `
+        SELECT
+            mm.id,
+            cm.id,
+            last_released_chapter.url,
+            last_released_chapter.chapter,
+            last_released_chapter.name,
+            last_released_chapter.updated_at,
+            COALESCE(last_read_chapter.url, um.last_read_chapter_url),
+            COALESCE(last_read_chapter.chapter, um.last_read_chapter)
+        FROM
+            multimangas AS mm
+        JOIN
+            mangas AS cm ON cm.id = mm.current_manga
+        LEFT JOIN
+            chapters AS last_released_chapter ON last_released_chapter.id = cm.last_released_chapter
+        LEFT JOIN
+            chapters AS last_read_chapter ON $2 = 0 AND last_read_chapter.id = mm.last_read_chapter
+        LEFT JOIN
+            user_multimangas AS um ON um.user_id = $2 AND um.multimanga_id = mm.id
+        WHERE
+            ($1 = -1 OR mm.id = $1) AND ($2 = 0 OR um.user_id IS NOT NULL);
+    `
+	rows, err := db.Query(query, multimangaID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type multiMangaChapters struct {
+		lastReleasedChapter *Chapter
+		lastReadChapter     *Chapter
+		currentMangaID      ID
+	}
+	multimangasChapters := map[ID]*multiMangaChapters{}
+	for rows.Next() {
+		var mmID, currentMangaID ID
+		var (
+			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName sql.NullString
+			lastReleasedChapterUpdatedAt                                                sql.NullTime
+			lastReadChapterURL, lastReadChapterChapter                                  sql.NullString
+		)
+		err = rows.Scan(&mmID, &currentMangaID, &lastReleasedChapterURL, &lastReleasedChapterChapter, &lastReleasedChapterName, &lastReleasedChapterUpdatedAt, &lastReadChapterURL, &lastReadChapterChapter)
+		if err != nil {
+			return nil, err
+		}
+
+		chapters := &multiMangaChapters{currentMangaID: currentMangaID}
+		if lastReleasedChapterURL.Valid {
+			chapters.lastReleasedChapter = &Chapter{
+				URL:       lastReleasedChapterURL.String,
+				Chapter:   lastReleasedChapterChapter.String,
+				Name:      lastReleasedChapterName.String,
+				UpdatedAt: lastReleasedChapterUpdatedAt.Time,
+				Type:      1,
+			}
+		}
+		if lastReadChapterURL.Valid {
+			chapters.lastReadChapter = &Chapter{
+				URL:     lastReadChapterURL.String,
+				Chapter: lastReadChapterChapter.String,
+				Type:    2,
+			}
+		}
+		multimangasChapters[mmID] = chapters
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+        SELECT
+            ch.manga_id, ch.url, ch.chapter
+        FROM
+            chapters_history AS ch
+        JOIN
+            multimangas AS mm ON mm.current_manga = ch.manga_id
+        WHERE
+            $1 = -1 OR mm.id = $1
+        ORDER BY
+            COALESCE(ch.updated_at, ch.first_seen_at) DESC, ch.first_seen_at DESC, ch.id DESC;
+    `
+	rows, err = db.Query(query, multimangaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mangasChaptersHistory := map[ID][]*Chapter{}
+	for rows.Next() {
+		var mangaID ID
+		chapter := &Chapter{Type: 1}
+		err = rows.Scan(&mangaID, &chapter.URL, &chapter.Chapter)
+		if err != nil {
+			return nil, err
+		}
+		mangasChaptersHistory[mangaID] = append(mangasChaptersHistory[mangaID], chapter)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	unreadChapters := make(map[ID]int, len(multimangasChapters))
+	for mmID, chapters := range multimangasChapters {
+		chaptersHistory, ok := mangasChaptersHistory[chapters.currentMangaID]
+		if !ok || len(chaptersHistory) == 0 {
+			if chapters.lastReleasedChapter == nil {
+				continue
+			}
+			chaptersHistory = []*Chapter{chapters.lastReleasedChapter}
+		}
+		unreadChapters[mmID] = CountUnreadChapters(chaptersHistory, chapters.lastReadChapter)
+	}
+
+	return unreadChapters, nil
+}
+
 func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
 	query := `
         SELECT 
@@ -577,6 +921,15 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
             mm.cover_img_url AS multimanga_cover_img_url,
             mm.cover_img_resized AS multimanga_cover_img_resized,
             mm.cover_img_fixed AS multimanga_cover_img_fixed,
+            mm.update_interval AS multimanga_update_interval,
+            mm.release_weekday AS multimanga_release_weekday,
+            mm.release_day_update_interval AS multimanga_release_day_update_interval,
+            mm.last_checked_at AS multimanga_last_checked_at,
+            mm.update_last_attempt_at AS multimanga_update_last_attempt_at,
+            mm.update_last_success_at AS multimanga_update_last_success_at,
+            mm.update_failing_since AS multimanga_update_failing_since,
+            mm.update_last_error AS multimanga_update_last_error,
+            mm.update_consecutive_failures AS multimanga_update_consecutive_failures,
 
             -- current manga
             cm.id AS manga_id,
@@ -585,9 +938,15 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
             cm.name,
             cm.internal_id,
             cm.preferred_group,
+            cm.preferred_language,
             cm.cover_img_url AS manga_cover_img_url,
             cm.cover_img AS manga_cover_img,
             cm.cover_img_resized AS manga_cover_img_resized,
+            cm.update_last_attempt_at AS manga_update_last_attempt_at,
+            cm.update_last_success_at AS manga_update_last_success_at,
+            cm.update_failing_since AS manga_update_failing_since,
+            cm.update_last_error AS manga_update_last_error,
+            cm.update_consecutive_failures AS manga_update_consecutive_failures,
 
             -- other mangas
             COALESCE(json_agg(DISTINCT om.name) FILTER (WHERE om.id IS NOT NULL)::TEXT, '[]') AS other_mangas,
@@ -597,6 +956,7 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
             last_released_chapter.chapter AS last_released_chapter,
             last_released_chapter.name AS last_released_chapter_name,
             last_released_chapter.internal_id AS last_released_chapter_internal_id,
+            last_released_chapter.volume AS last_released_chapter_volume,
             last_released_chapter.updated_at AS last_released_chapter_updated_at,
             last_released_chapter.type AS last_released_chapter_type,
 
@@ -605,6 +965,7 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
             last_read_chapter.chapter AS last_read_chapter,
             last_read_chapter.name AS last_read_chapter_name,
             last_read_chapter.internal_id AS last_read_chapter_internal_id,
+            last_read_chapter.volume AS last_read_chapter_volume,
             last_read_chapter.updated_at AS last_read_chapter_updated_at,
             last_read_chapter.type AS last_read_chapter_type,
 			last_read_chapter.from_source_site AS last_read_chapter_from_source_site
@@ -620,7 +981,10 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
             chapters AS last_read_chapter ON last_read_chapter.id = mm.last_read_chapter
         GROUP BY
             mm.id, cm.id, mm.status, mm.cover_img, mm.cover_img_url, mm.cover_img_resized, mm.cover_img_fixed,
-            cm.source, cm.url, cm.name, cm.internal_id, cm.preferred_group, cm.cover_img_url, cm.cover_img, cm.cover_img_resized,
+            mm.update_interval, mm.release_weekday, mm.release_day_update_interval, mm.last_checked_at,
+            mm.update_last_attempt_at, mm.update_last_success_at, mm.update_failing_since, mm.update_last_error, mm.update_consecutive_failures,
+            cm.source, cm.url, cm.name, cm.internal_id, cm.preferred_group, cm.preferred_language, cm.cover_img_url, cm.cover_img, cm.cover_img_resized,
+            cm.update_last_attempt_at, cm.update_last_success_at, cm.update_failing_since, cm.update_last_error, cm.update_consecutive_failures,
             last_released_chapter.url, last_released_chapter.chapter, last_released_chapter.name, last_released_chapter.internal_id,
             last_released_chapter.updated_at, last_released_chapter.type,
             last_read_chapter.url, last_read_chapter.chapter, last_read_chapter.name, last_read_chapter.internal_id,
@@ -641,14 +1005,17 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
 		var lastReleasedChapter, multiLastReadChapter Chapter
 
 		var (
-			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID sql.NullString
-			lastReleasedChapterUpdatedAt                                                                               sql.NullTime
-			lastReleasedChapterType                                                                                    sql.NullInt32
-
-			multiLastReadChapterURL, multiLastReadChapterChapter, multiLastReadChapterName, multiLastReadChapterInternalID sql.NullString
-			multiLastReadChapterUpdatedAt                                                                                  sql.NullTime
-			multiLastReadChapterType                                                                                       sql.NullInt32
-			multiLastReadChapterFromSourceSite                                                                             sql.NullBool
+			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID, lastReleasedChapterVolume sql.NullString
+			lastReleasedChapterUpdatedAt                                                                                                          sql.NullTime
+			lastReleasedChapterType                                                                                                               sql.NullInt32
+
+			multiLastReadChapterURL, multiLastReadChapterChapter, multiLastReadChapterName, multiLastReadChapterInternalID, multiLastReadChapterVolume sql.NullString
+			multiLastReadChapterUpdatedAt                                                                                                              sql.NullTime
+			multiLastReadChapterType                                                                                                                   sql.NullInt32
+			multiLastReadChapterFromSourceSite                                                                                                         sql.NullBool
+
+			lastCheckedAt                                    sql.NullTime
+			multimangaUpdateStatus, currentMangaUpdateStatus nullUpdateStatus
 		)
 
 		altNames := []byte{}
@@ -659,26 +1026,43 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
 			&multimanga.CoverImgURL,
 			&multimanga.CoverImgResized,
 			&multimanga.CoverImgFixed,
+			&multimanga.UpdateInterval,
+			&multimanga.ReleaseWeekday,
+			&multimanga.ReleaseDayUpdateInterval,
+			&lastCheckedAt,
+			&multimangaUpdateStatus.lastAttemptAt,
+			&multimangaUpdateStatus.lastSuccessAt,
+			&multimangaUpdateStatus.failingSince,
+			&multimangaUpdateStatus.lastError,
+			&multimangaUpdateStatus.consecutiveFailures,
 			&currentManga.ID,
 			&currentManga.Source,
 			&currentManga.URL,
 			&currentManga.Name,
 			&currentManga.InternalID,
 			&currentManga.PreferredGroup,
+			&currentManga.PreferredLanguage,
 			&currentManga.CoverImgURL,
 			&currentManga.CoverImg,
 			&currentManga.CoverImgResized,
+			&currentMangaUpdateStatus.lastAttemptAt,
+			&currentMangaUpdateStatus.lastSuccessAt,
+			&currentMangaUpdateStatus.failingSince,
+			&currentMangaUpdateStatus.lastError,
+			&currentMangaUpdateStatus.consecutiveFailures,
 			&altNames,
 			&lastReleasedChapterURL,
 			&lastReleasedChapterChapter,
 			&lastReleasedChapterName,
 			&lastReleasedChapterInternalID,
+			&lastReleasedChapterVolume,
 			&lastReleasedChapterUpdatedAt,
 			&lastReleasedChapterType,
 			&multiLastReadChapterURL,
 			&multiLastReadChapterChapter,
 			&multiLastReadChapterName,
 			&multiLastReadChapterInternalID,
+			&multiLastReadChapterVolume,
 			&multiLastReadChapterUpdatedAt,
 			&multiLastReadChapterType,
 			&multiLastReadChapterFromSourceSite,
@@ -693,12 +1077,16 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
 				return nil, err
 			}
 		}
+		multimanga.LastCheckedAt = lastCheckedAt.Time
+		multimanga.UpdateStatus = multimangaUpdateStatus.toUpdateStatus()
+		currentManga.UpdateStatus = currentMangaUpdateStatus.toUpdateStatus()
 
 		if lastReleasedChapterURL.Valid {
 			lastReleasedChapter.URL = lastReleasedChapterURL.String
 			lastReleasedChapter.Chapter = lastReleasedChapterChapter.String
 			lastReleasedChapter.Name = lastReleasedChapterName.String
 			lastReleasedChapter.InternalID = lastReleasedChapterInternalID.String
+			lastReleasedChapter.Volume = lastReleasedChapterVolume.String
 			lastReleasedChapter.UpdatedAt = lastReleasedChapterUpdatedAt.Time
 			lastReleasedChapter.Type = Type(lastReleasedChapterType.Int32)
 			currentManga.LastReleasedChapter = &lastReleasedChapter
@@ -709,6 +1097,7 @@ func getMultiMangasWithoutMangasDB(db *sql.DB) ([]*MultiManga, error) {
 			multiLastReadChapter.Chapter = multiLastReadChapterChapter.String
 			multiLastReadChapter.Name = multiLastReadChapterName.String
 			multiLastReadChapter.InternalID = multiLastReadChapterInternalID.String
+			multiLastReadChapter.Volume = multiLastReadChapterVolume.String
 			multiLastReadChapter.UpdatedAt = multiLastReadChapterUpdatedAt.Time
 			multiLastReadChapter.Type = Type(multiLastReadChapterType.Int32)
 			multiLastReadChapter.FromSourceSite = multiLastReadChapterFromSourceSite.Bool
@@ -741,12 +1130,22 @@ func getMultiMangasWithMangasDB(db *sql.DB) ([]*MultiManga, error) {
             multimangas.cover_img_resized AS multimanga_cover_img_resized,
             multimangas.cover_img_fixed AS multimanga_cover_img_fixed,
             multimangas.current_manga AS multimanga_current_manga,
+            multimangas.update_interval AS multimanga_update_interval,
+            multimangas.release_weekday AS multimanga_release_weekday,
+            multimangas.release_day_update_interval AS multimanga_release_day_update_interval,
+            multimangas.last_checked_at AS multimanga_last_checked_at,
+            multimangas.update_last_attempt_at AS multimanga_update_last_attempt_at,
+            multimangas.update_last_success_at AS multimanga_update_last_success_at,
+            multimangas.update_failing_since AS multimanga_update_failing_since,
+            multimangas.update_last_error AS multimanga_update_last_error,
+            multimangas.update_consecutive_failures AS multimanga_update_consecutive_failures,
 
             -- last read chapter
             last_read_chapter.url AS last_read_chapter_url,
             last_read_chapter.chapter AS last_read_chapter,
             last_read_chapter.name AS last_read_chapter_name,
             last_read_chapter.internal_id AS last_read_chapter_internal_id,
+            last_read_chapter.volume AS last_read_chapter_volume,
             last_read_chapter.updated_at AS last_read_chapter_updated_at,
             last_read_chapter.type AS last_read_chapter_type,
 			last_read_chapter.from_source_site AS last_read_chapter_from_source_site
@@ -772,10 +1171,13 @@ func getMultiMangasWithMangasDB(db *sql.DB) ([]*MultiManga, error) {
 		var currentMangaID int
 
 		var (
-			multiLastReadChapterURL, multiLastReadChapterChapter, multiLastReadChapterName, multiLastReadChapterInternalID sql.NullString
-			multiLastReadChapterUpdatedAt                                                                                  sql.NullTime
-			multiLastReadChapterType                                                                                       sql.NullInt32
-			multiLastReadChapterFromSourceSite                                                                             sql.NullBool
+			multiLastReadChapterURL, multiLastReadChapterChapter, multiLastReadChapterName, multiLastReadChapterInternalID, multiLastReadChapterVolume sql.NullString
+			multiLastReadChapterUpdatedAt                                                                                                              sql.NullTime
+			multiLastReadChapterType                                                                                                                   sql.NullInt32
+			multiLastReadChapterFromSourceSite                                                                                                         sql.NullBool
+
+			lastCheckedAt          sql.NullTime
+			multimangaUpdateStatus nullUpdateStatus
 		)
 
 		err = rows.Scan(
@@ -786,10 +1188,20 @@ func getMultiMangasWithMangasDB(db *sql.DB) ([]*MultiManga, error) {
 			&multimanga.CoverImgResized,
 			&multimanga.CoverImgFixed,
 			&currentMangaID,
+			&multimanga.UpdateInterval,
+			&multimanga.ReleaseWeekday,
+			&multimanga.ReleaseDayUpdateInterval,
+			&lastCheckedAt,
+			&multimangaUpdateStatus.lastAttemptAt,
+			&multimangaUpdateStatus.lastSuccessAt,
+			&multimangaUpdateStatus.failingSince,
+			&multimangaUpdateStatus.lastError,
+			&multimangaUpdateStatus.consecutiveFailures,
 			&multiLastReadChapterURL,
 			&multiLastReadChapterChapter,
 			&multiLastReadChapterName,
 			&multiLastReadChapterInternalID,
+			&multiLastReadChapterVolume,
 			&multiLastReadChapterUpdatedAt,
 			&multiLastReadChapterType,
 			&multiLastReadChapterFromSourceSite,
@@ -797,12 +1209,15 @@ func getMultiMangasWithMangasDB(db *sql.DB) ([]*MultiManga, error) {
 		if err != nil {
 			return nil, err
 		}
+		multimanga.LastCheckedAt = lastCheckedAt.Time
+		multimanga.UpdateStatus = multimangaUpdateStatus.toUpdateStatus()
 
 		if multiLastReadChapterURL.Valid {
 			multiLastReadChapter.URL = multiLastReadChapterURL.String
 			multiLastReadChapter.Chapter = multiLastReadChapterChapter.String
 			multiLastReadChapter.Name = multiLastReadChapterName.String
 			multiLastReadChapter.InternalID = multiLastReadChapterInternalID.String
+			multiLastReadChapter.Volume = multiLastReadChapterVolume.String
 			multiLastReadChapter.UpdatedAt = multiLastReadChapterUpdatedAt.Time
 			multiLastReadChapter.Type = Type(multiLastReadChapterType.Int32)
 			multiLastReadChapter.FromSourceSite = multiLastReadChapterFromSourceSite.Bool
@@ -927,24 +1342,32 @@ func deleteMultiMangaChapter(mmID ID, chapter *Chapter, tx *sql.Tx) error {
 func getMultiMangaFromDB(multimangaID ID, db *sql.DB) (*MultiManga, error) {
 	var currentMangaID sql.NullInt64
 	var lastReadChapterID sql.NullInt64
+	var lastCheckedAt sql.NullTime
+	var updateStatus nullUpdateStatus
 
 	mm := &MultiManga{}
 
 	query := `
         SELECT
-            id, status, cover_img, cover_img_resized, cover_img_url, cover_img_fixed, current_manga, last_read_chapter
+            id, status, cover_img, cover_img_resized, cover_img_url, cover_img_fixed, current_manga, last_read_chapter,
+            update_interval, release_weekday, release_day_update_interval, last_checked_at,
+            update_last_attempt_at, update_last_success_at, update_failing_since, update_last_error, update_consecutive_failures
         FROM
             multimangas
         WHERE
             id = $1;
     `
-	err := db.QueryRow(query, multimangaID).Scan(&mm.ID, &mm.Status, &mm.CoverImg, &mm.CoverImgResized, &mm.CoverImgURL, &mm.CoverImgFixed, &currentMangaID, &lastReadChapterID)
+	err := db.QueryRow(query, multimangaID).Scan(&mm.ID, &mm.Status, &mm.CoverImg, &mm.CoverImgResized, &mm.CoverImgURL, &mm.CoverImgFixed, &currentMangaID, &lastReadChapterID,
+		&mm.UpdateInterval, &mm.ReleaseWeekday, &mm.ReleaseDayUpdateInterval, &lastCheckedAt,
+		&updateStatus.lastAttemptAt, &updateStatus.lastSuccessAt, &updateStatus.failingSince, &updateStatus.lastError, &updateStatus.consecutiveFailures)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errordefs.ErrMultiMangaNotFoundDB
 		}
 		return nil, err
 	}
+	mm.LastCheckedAt = lastCheckedAt.Time
+	mm.UpdateStatus = updateStatus.toUpdateStatus()
 
 	mangas, err := getMultiMangaMangasFromDB(mm.ID, db)
 	if err != nil {
@@ -987,6 +1410,7 @@ func getMultiMangaMangasFromDB(multiMangaID ID, db *sql.DB) ([]*Manga, error) {
             mangas.name,
             mangas.internal_id,
             mangas.preferred_group,
+            mangas.preferred_language,
             mangas.multimanga_id AS manga_multimanga_id,
             mangas.cover_img_url AS manga_cover_img_url,
             mangas.cover_img AS manga_cover_img,
@@ -1000,11 +1424,17 @@ func getMultiMangaMangasFromDB(multiMangaID ID, db *sql.DB) ([]*Manga, error) {
 			mangas.last_released_chapter_url_attribute,
 			mangas.last_released_chapter_url_get_first,
 			mangas.last_released_chapter_selector_use_browser,
+            mangas.update_last_attempt_at,
+            mangas.update_last_success_at,
+            mangas.update_failing_since,
+            mangas.update_last_error,
+            mangas.update_consecutive_failures,
             
             last_released_chapter.url AS last_released_chapter_url,
             last_released_chapter.chapter AS last_released_chapter,
             last_released_chapter.name AS last_released_chapter_name,
             last_released_chapter.internal_id AS last_released_chapter_internal_id,
+            last_released_chapter.volume AS last_released_chapter_volume,
             last_released_chapter.updated_at AS last_released_chapter_updated_at,
             last_released_chapter.type AS last_released_chapter_type
         FROM 
@@ -1033,28 +1463,32 @@ func getMultiMangaMangasFromDB(multiMangaID ID, db *sql.DB) ([]*Manga, error) {
 			lastReleasedChapterURLSelector, lastReleasedChapterURLAttribute                                 sql.NullString
 			lastReleasedChapterNameGetFirst, lastReleasedChapterURLGetFirst                                 sql.NullBool
 
-			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID sql.NullString
-			lastReleasedChapterUpdatedAt                                                                               sql.NullTime
-			lastReleasedChapterType                                                                                    sql.NullInt32
+			lastReleasedChapterURL, lastReleasedChapterChapter, lastReleasedChapterName, lastReleasedChapterInternalID, lastReleasedChapterVolume sql.NullString
+			lastReleasedChapterUpdatedAt                                                                                                          sql.NullTime
+			lastReleasedChapterType                                                                                                               sql.NullInt32
+
+			updateStatus nullUpdateStatus
 		)
 
 		err := rows.Scan(
 			&currentManga.Status, &currentManga.ID, &currentManga.Source, &currentManga.URL, &currentManga.Name,
-			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.MultiMangaID, &currentManga.CoverImgURL,
+			&currentManga.InternalID, &currentManga.PreferredGroup, &currentManga.PreferredLanguage, &currentManga.MultiMangaID, &currentManga.CoverImgURL,
 			&currentManga.CoverImg, &currentManga.CoverImgResized, &currentManga.LastReadChapter,
 
 			&lastReleasedChapterNameSelector, &lastReleasedChapterNameAttribute, &lastReleasedChapterNameRegex, &lastReleasedChapterNameGetFirst,
 			&lastReleasedChapterURLSelector, &lastReleasedChapterURLAttribute, &lastReleasedChapterURLGetFirst,
 			&currentManga.LastReleasedChapterSelectorUseBrowser,
+			&updateStatus.lastAttemptAt, &updateStatus.lastSuccessAt, &updateStatus.failingSince, &updateStatus.lastError, &updateStatus.consecutiveFailures,
 
 			&lastReleasedChapterURL, &lastReleasedChapterChapter, &lastReleasedChapterName,
-			&lastReleasedChapterInternalID, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
+			&lastReleasedChapterInternalID, &lastReleasedChapterVolume, &lastReleasedChapterUpdatedAt, &lastReleasedChapterType,
 		)
 		if err != nil {
 			return nil, err
 		}
 
 		currentManga.SearchNames = []string{currentManga.Name}
+		currentManga.UpdateStatus = updateStatus.toUpdateStatus()
 
 		if lastReleasedChapterNameSelector.Valid && lastReleasedChapterNameSelector.String != "" {
 			currentManga.LastReleasedChapterNameSelector = &HTMLSelector{
@@ -1077,6 +1511,7 @@ func getMultiMangaMangasFromDB(multiMangaID ID, db *sql.DB) ([]*Manga, error) {
 			lastReleasedChapter.Chapter = lastReleasedChapterChapter.String
 			lastReleasedChapter.Name = lastReleasedChapterName.String
 			lastReleasedChapter.InternalID = lastReleasedChapterInternalID.String
+			lastReleasedChapter.Volume = lastReleasedChapterVolume.String
 			lastReleasedChapter.UpdatedAt = lastReleasedChapterUpdatedAt.Time
 			lastReleasedChapter.Type = Type(lastReleasedChapterType.Int32)
 			currentManga.LastReleasedChapter = &lastReleasedChapter
@@ -1204,24 +1639,18 @@ func GetLatestManga(mangas []*Manga) (*Manga, error) {
 			continue
 		}
 
-		currentChapterInt, err := strconv.ParseFloat(currentChapter.Chapter, 64)
-		if err != nil {
-			if currentChapter.UpdatedAt.Before(newChapter.UpdatedAt) {
-				currentManga = manga
-			}
-			continue
-		}
-		newChapterInt, err := strconv.ParseFloat(newChapter.Chapter, 64)
-		if err != nil {
+		currentChapterNumber := ParseChapterNumber(currentChapter.Chapter)
+		newChapterNumber := ParseChapterNumber(newChapter.Chapter)
+		if !currentChapterNumber.Valid || !newChapterNumber.Valid {
 			if currentChapter.UpdatedAt.Before(newChapter.UpdatedAt) {
 				currentManga = manga
 			}
 			continue
 		}
-		if currentChapterInt < newChapterInt {
+		if c := currentChapterNumber.Compare(newChapterNumber); c < 0 {
 			currentManga = manga
 			continue
-		} else if currentChapterInt > newChapterInt {
+		} else if c > 0 {
 			continue
 		} else if currentChapter.UpdatedAt.Before(newChapter.UpdatedAt) {
 			currentManga = manga
@@ -233,6 +233,93 @@ func TestMultiMangaDBLifeCycle(t *testing.T) {
 	})
 }
 
+func TestMultiMangaLastCheckedAtDBLifeCycle(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC-5", -5*60*60)
+	defer func() { time.Local = local }()
+
+	multiManga := getMultiMangaCopy(multiMangaTest)
+	multiManga.Status = 1
+	statusUpdateIntervals := map[Status]int{1: 60}
+
+	t.Run("Should insert a multimanga into DB", func(t *testing.T) {
+		err := multiManga.InsertIntoDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Should not be due for update after being checked in a non-UTC time zone", func(t *testing.T) {
+		err := multiManga.UpdateLastCheckedAtInDB(time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		multiMangaDB, err := GetMultiMangaFromDB(multiManga.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(multiMangaDB.LastCheckedAt); elapsed < 0 || elapsed > time.Minute {
+			t.Fatalf("Expected the last checked at to be now, got '%s' (%s ago)", multiMangaDB.LastCheckedAt, elapsed)
+		}
+		if multiMangaDB.IsDueForUpdate(time.Now(), statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to not be due for update")
+		}
+	})
+	t.Run("Should delete the multimanga from DB", func(t *testing.T) {
+		err := multiManga.DeleteFromDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestMultiMangaIsDueForUpdate(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC) // Friday
+	statusUpdateIntervals := map[Status]int{1: 60, 4: 7 * 24 * 60}
+
+	t.Run("Should be due if it was never checked", func(t *testing.T) {
+		mm := &MultiManga{Status: 4, ReleaseWeekday: -1}
+		if !mm.IsDueForUpdate(now, statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to be due for update")
+		}
+	})
+	t.Run("Should use the status update interval", func(t *testing.T) {
+		mm := &MultiManga{Status: 4, ReleaseWeekday: -1, LastCheckedAt: now.Add(-24 * time.Hour)}
+		if mm.IsDueForUpdate(now, statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to not be due for update")
+		}
+		mm.LastCheckedAt = now.Add(-8 * 24 * time.Hour)
+		if !mm.IsDueForUpdate(now, statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to be due for update")
+		}
+	})
+	t.Run("Should be due on every run if there is no update interval", func(t *testing.T) {
+		mm := &MultiManga{Status: 2, ReleaseWeekday: -1, LastCheckedAt: now.Add(-time.Second)}
+		if !mm.IsDueForUpdate(now, statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to be due for update")
+		}
+	})
+	t.Run("Should prefer the multimanga update interval over the status update interval", func(t *testing.T) {
+		mm := &MultiManga{Status: 1, ReleaseWeekday: -1, UpdateInterval: 24 * 60, LastCheckedAt: now.Add(-2 * time.Hour)}
+		if mm.IsDueForUpdate(now, statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to not be due for update")
+		}
+	})
+	t.Run("Should use the release day update interval on the release weekday", func(t *testing.T) {
+		mm := &MultiManga{Status: 1, UpdateInterval: 24 * 60, ReleaseWeekday: int(time.Friday), ReleaseDayUpdateInterval: 60, LastCheckedAt: now.Add(-time.Hour)}
+		if !mm.IsDueForUpdate(now, statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to be due for update on the release weekday")
+		}
+		mm.LastCheckedAt = now.Add(23 * time.Hour)
+		if mm.IsDueForUpdate(now.Add(24*time.Hour), statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to not be due for update outside the release weekday")
+		}
+		mm.LastCheckedAt = now.Add(-30 * time.Minute)
+		if mm.IsDueForUpdate(now, statusUpdateIntervals) {
+			t.Fatal("Expected multimanga to not be due for update before the release day update interval")
+		}
+	})
+}
+
 func getMultiMangaCopy(source *MultiManga) *MultiManga {
 	multiManga := *source
 	if source.LastReadChapter != nil {
@@ -0,0 +1,253 @@
+package manga
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// NotificationRules are the rules used to decide if a notification should
+// be sent when a multimanga has a new chapter.
+type NotificationRules struct {
+	// Notifier is the name of the notifier used to send the notifications, like "ntfy".
+	// If empty, all enabled notifiers are used.
+	Notifier string
+	// Topic overrides the ntfy topic the notifications are sent to. If empty, the configured topic is used.
+	Topic string
+	// LastNotifiedChapter is the chapter of the last notification sent.
+	// It's used by OnlyNumericIncrease and MinChapterGap.
+	LastNotifiedChapter string
+	MultiMangaID        ID
+	// MinChapterGap is the minimum difference between the new chapter number and the last
+	// notified chapter number to send a notification. If 0, it's ignored.
+	MinChapterGap float64
+	// QuietHoursStart is the hour (0-23) the quiet hours start. The notifications in the quiet hours
+	// are queued and sent when the quiet hours end. If -1, there are no quiet hours.
+	QuietHoursStart int
+	// QuietHoursEnd is the hour (0-23) the quiet hours end (exclusive).
+	// If it's lower than QuietHoursStart, the quiet hours wrap around midnight.
+	QuietHoursEnd int
+	// Muted is true if no notifications should be sent.
+	Muted bool
+	// OnlyNumericIncrease is true if notifications should be sent only if the new
+	// chapter number is greater than the last notified chapter number.
+	OnlyNumericIncrease bool
+}
+
+// NewNotificationRules returns the default notification rules of a multimanga,
+// which notify about every new chapter.
+func NewNotificationRules(multimangaID ID) *NotificationRules {
+	return &NotificationRules{
+		MultiMangaID:    multimangaID,
+		QuietHoursStart: -1,
+		QuietHoursEnd:   -1,
+	}
+}
+
+// ShouldNotify returns true if a notification about the chapter should be sent.
+// If false, it also returns the reason why the notification should not be sent.
+// The quiet hours are not checked, as the notifications in the quiet hours are
+// delayed instead of not sent, see IsQuietHour.
+func (r *NotificationRules) ShouldNotify(chapter *Chapter) (bool, string) {
+	if r.Muted {
+		return false, "notifications are muted"
+	}
+	if chapter == nil {
+		return true, ""
+	}
+
+	if r.OnlyNumericIncrease || r.MinChapterGap > 0 {
+		chapterNumber := ParseChapterNumber(chapter.Chapter)
+		if !chapterNumber.Valid {
+			if r.OnlyNumericIncrease {
+				return false, fmt.Sprintf("chapter '%s' is not a number", chapter.Chapter)
+			}
+			return true, ""
+		}
+		lastNotifiedChapterNumber := ParseChapterNumber(r.LastNotifiedChapter)
+		if !lastNotifiedChapterNumber.Valid {
+			return true, ""
+		}
+
+		if r.OnlyNumericIncrease && chapterNumber.Compare(lastNotifiedChapterNumber) <= 0 {
+			return false, fmt.Sprintf("chapter '%s' is not greater than the last notified chapter '%s'", chapter.Chapter, r.LastNotifiedChapter)
+		}
+		if r.MinChapterGap > 0 && chapterNumber.End-lastNotifiedChapterNumber.End < r.MinChapterGap {
+			return false, fmt.Sprintf("chapter '%s' is less than %v chapters after the last notified chapter '%s'", chapter.Chapter, r.MinChapterGap, r.LastNotifiedChapter)
+		}
+	}
+
+	return true, ""
+}
+
+// IsQuietHour returns true if the time now is in the quiet hours.
+func (r *NotificationRules) IsQuietHour(now time.Time) bool {
+	if r.QuietHoursStart < 0 || r.QuietHoursEnd < 0 || r.QuietHoursStart == r.QuietHoursEnd {
+		return false
+	}
+
+	hour := now.Hour()
+	if r.QuietHoursStart < r.QuietHoursEnd {
+		return hour >= r.QuietHoursStart && hour < r.QuietHoursEnd
+	}
+
+	return hour >= r.QuietHoursStart || hour < r.QuietHoursEnd
+}
+
+// QuietHoursEndAt returns when the quiet hours that the time now is in end.
+// It should only be called if now is in the quiet hours.
+func (r *NotificationRules) QuietHoursEndAt(now time.Time) time.Time {
+	end := time.Date(now.Year(), now.Month(), now.Day(), r.QuietHoursEnd, 0, 0, 0, now.Location())
+	if !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return end
+}
+
+// GetNotificationRulesFromDB gets the notification rules of a multimanga from the database.
+// If the multimanga doesn't have notification rules, the default rules are returned.
+func GetNotificationRulesFromDB(multimangaID ID) (*NotificationRules, error) {
+	contextError := "error getting notification rules of multimanga with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+	defer db.Close()
+
+	rules, err := getNotificationRulesFromDB(multimangaID, db)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID), err)
+	}
+
+	return rules, nil
+}
+
+func getNotificationRulesFromDB(multimangaID ID, db *sql.DB) (*NotificationRules, error) {
+	rules := NewNotificationRules(multimangaID)
+	err := db.QueryRow(`
+        SELECT
+            muted, only_numeric_increase, min_chapter_gap, notifier, topic,
+            quiet_hours_start, quiet_hours_end, last_notified_chapter
+        FROM
+            notification_rules
+        WHERE
+            multimanga_id = $1;
+    `, multimangaID).Scan(&rules.Muted, &rules.OnlyNumericIncrease, &rules.MinChapterGap, &rules.Notifier, &rules.Topic,
+		&rules.QuietHoursStart, &rules.QuietHoursEnd, &rules.LastNotifiedChapter)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return rules, nil
+		}
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// UpsertIntoDB saves the notification rules of the multimanga in the database.
+// The last notified chapter is not updated, use UpdateLastNotifiedChapterInDB instead.
+func (r *NotificationRules) UpsertIntoDB() error {
+	contextError := "error saving notification rules of multimanga with ID '%d' in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, r.MultiMangaID), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, r.MultiMangaID), err)
+	}
+
+	err = upsertNotificationRulesDB(r, tx)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, r.MultiMangaID), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, r.MultiMangaID), err)
+	}
+
+	return nil
+}
+
+func upsertNotificationRulesDB(r *NotificationRules, tx *sql.Tx) error {
+	err := ValidateNotificationRules(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+        INSERT INTO notification_rules
+            (multimanga_id, muted, only_numeric_increase, min_chapter_gap, notifier, topic, quiet_hours_start, quiet_hours_end)
+        VALUES
+            ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (multimanga_id) DO UPDATE
+        SET
+            muted = EXCLUDED.muted, only_numeric_increase = EXCLUDED.only_numeric_increase,
+            min_chapter_gap = EXCLUDED.min_chapter_gap, notifier = EXCLUDED.notifier, topic = EXCLUDED.topic,
+            quiet_hours_start = EXCLUDED.quiet_hours_start, quiet_hours_end = EXCLUDED.quiet_hours_end;
+    `, r.MultiMangaID, r.Muted, r.OnlyNumericIncrease, r.MinChapterGap, r.Notifier, r.Topic, r.QuietHoursStart, r.QuietHoursEnd)
+	if err != nil {
+		if err.Error() == `pq: insert or update on table "notification_rules" violates foreign key constraint "notification_rules_multimanga_id_fkey"` {
+			return errordefs.ErrMultiMangaNotFoundDB
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdateLastNotifiedChapterInDB updates the last notified chapter of the multimanga in the database.
+func (r *NotificationRules) UpdateLastNotifiedChapterInDB(chapter string) error {
+	contextError := "error updating last notified chapter of multimanga with ID '%d' in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, r.MultiMangaID), err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+        INSERT INTO notification_rules
+            (multimanga_id, last_notified_chapter)
+        VALUES
+            ($1, $2)
+        ON CONFLICT (multimanga_id) DO UPDATE
+        SET
+            last_notified_chapter = EXCLUDED.last_notified_chapter;
+    `, r.MultiMangaID, chapter)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, r.MultiMangaID), err)
+	}
+	r.LastNotifiedChapter = chapter
+
+	return nil
+}
+
+// ValidateNotificationRules returns an error if the notification rules are invalid.
+func ValidateNotificationRules(r *NotificationRules) error {
+	if r.MinChapterGap < 0 {
+		return fmt.Errorf("minimum chapter gap should be >= 0, instead it's %v", r.MinChapterGap)
+	}
+	if r.QuietHoursStart < -1 || r.QuietHoursStart > 23 {
+		return fmt.Errorf("quiet hours start should be >= -1 && <= 23, instead it's %d", r.QuietHoursStart)
+	}
+	if r.QuietHoursEnd < -1 || r.QuietHoursEnd > 23 {
+		return fmt.Errorf("quiet hours end should be >= -1 && <= 23, instead it's %d", r.QuietHoursEnd)
+	}
+	if (r.QuietHoursStart == -1) != (r.QuietHoursEnd == -1) {
+		return fmt.Errorf("quiet hours start and end should be both -1 or both set, instead they're %d and %d", r.QuietHoursStart, r.QuietHoursEnd)
+	}
+
+	return nil
+}
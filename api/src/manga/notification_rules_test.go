@@ -0,0 +1,102 @@
+package manga
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationRulesShouldNotify(t *testing.T) {
+	chapter := &Chapter{Chapter: "105"}
+
+	t.Run("Should notify with the default rules", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		if shouldNotify, reason := rules.ShouldNotify(chapter); !shouldNotify {
+			t.Fatalf("Expected to notify, but got reason: %s", reason)
+		}
+	})
+	t.Run("Should not notify if muted", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		rules.Muted = true
+		if shouldNotify, _ := rules.ShouldNotify(chapter); shouldNotify {
+			t.Fatal("Expected to not notify")
+		}
+	})
+	t.Run("Should notify only on numeric increase", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		rules.OnlyNumericIncrease = true
+		rules.LastNotifiedChapter = "105"
+		if shouldNotify, _ := rules.ShouldNotify(chapter); shouldNotify {
+			t.Fatal("Expected to not notify about the same chapter")
+		}
+		if shouldNotify, _ := rules.ShouldNotify(&Chapter{Chapter: "104.5"}); shouldNotify {
+			t.Fatal("Expected to not notify about a lower chapter")
+		}
+		if shouldNotify, _ := rules.ShouldNotify(&Chapter{Chapter: "Oneshot"}); shouldNotify {
+			t.Fatal("Expected to not notify about a non-numeric chapter")
+		}
+		if shouldNotify, reason := rules.ShouldNotify(&Chapter{Chapter: "105.5"}); !shouldNotify {
+			t.Fatalf("Expected to notify, but got reason: %s", reason)
+		}
+		rules.LastNotifiedChapter = ""
+		if shouldNotify, reason := rules.ShouldNotify(chapter); !shouldNotify {
+			t.Fatalf("Expected to notify if there is no last notified chapter, but got reason: %s", reason)
+		}
+	})
+	t.Run("Should respect the minimum chapter gap", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		rules.MinChapterGap = 5
+		rules.LastNotifiedChapter = "101"
+		if shouldNotify, _ := rules.ShouldNotify(chapter); shouldNotify {
+			t.Fatal("Expected to not notify")
+		}
+		rules.LastNotifiedChapter = "100"
+		if shouldNotify, reason := rules.ShouldNotify(chapter); !shouldNotify {
+			t.Fatalf("Expected to notify, but got reason: %s", reason)
+		}
+	})
+	t.Run("Should notify in the quiet hours", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		rules.QuietHoursStart, rules.QuietHoursEnd = 11, 13
+		if shouldNotify, reason := rules.ShouldNotify(chapter); !shouldNotify {
+			t.Fatalf("Expected to notify, as the notification is delayed, but got reason: %s", reason)
+		}
+	})
+}
+
+func TestNotificationRulesQuietHoursEndAt(t *testing.T) {
+	t.Run("Should end on the same day", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		rules.QuietHoursStart, rules.QuietHoursEnd = 1, 7
+		now := time.Date(2024, time.March, 15, 3, 30, 0, 0, time.UTC)
+		if end := rules.QuietHoursEndAt(now); !end.Equal(time.Date(2024, time.March, 15, 7, 0, 0, 0, time.UTC)) {
+			t.Fatalf("Unexpected quiet hours end: %s", end)
+		}
+	})
+	t.Run("Should end on the next day if wrapping around midnight", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		rules.QuietHoursStart, rules.QuietHoursEnd = 22, 7
+		now := time.Date(2024, time.March, 15, 23, 30, 0, 0, time.UTC)
+		if end := rules.QuietHoursEndAt(now); !end.Equal(time.Date(2024, time.March, 16, 7, 0, 0, 0, time.UTC)) {
+			t.Fatalf("Unexpected quiet hours end: %s", end)
+		}
+	})
+}
+
+func TestNotificationRulesIsQuietHour(t *testing.T) {
+	t.Run("Should handle quiet hours wrapping around midnight", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		rules.QuietHoursStart, rules.QuietHoursEnd = 22, 7
+		for hour, expected := range map[int]bool{21: false, 22: true, 23: true, 0: true, 6: true, 7: false, 12: false} {
+			now := time.Date(2024, time.March, 15, hour, 30, 0, 0, time.UTC)
+			if rules.IsQuietHour(now) != expected {
+				t.Fatalf("Expected IsQuietHour to be %v at %d:30", expected, hour)
+			}
+		}
+	})
+	t.Run("Should not have quiet hours if disabled", func(t *testing.T) {
+		rules := NewNotificationRules(1)
+		if rules.IsQuietHour(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Fatal("Expected no quiet hours")
+		}
+	})
+}
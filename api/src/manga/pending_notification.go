@@ -0,0 +1,178 @@
+package manga
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// PendingNotification is a notification about a manga's new chapter
+// waiting to be sent in the next notification digest, or waiting for the
+// quiet hours of the manga's multimanga to end.
+type PendingNotification struct {
+	CreatedAt time.Time
+	// SendAfter is when the notification can be sent, like the end of the quiet hours.
+	// If zero, it's sent in the next notification digest.
+	SendAfter time.Time
+	MangaName string
+	Chapter   string
+	URL       string
+	// Notifier and Topic are the notifier and topic set in the multimanga's notification rules.
+	Notifier string
+	Topic    string
+	ID       int
+	MangaID  ID
+}
+
+// InsertIntoDB inserts the pending notification into the database.
+func (p *PendingNotification) InsertIntoDB() error {
+	contextError := "error inserting pending notification of manga with ID '%d' into DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, p.MangaID), err)
+	}
+	defer db.Close()
+
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	}
+
+	var sendAfter sql.NullTime
+	if !p.SendAfter.IsZero() {
+		sendAfter = sql.NullTime{Time: p.SendAfter.UTC().Truncate(time.Second), Valid: true}
+	}
+
+	err = db.QueryRow(`
+        INSERT INTO pending_notifications
+            (manga_id, manga_name, chapter, url, notifier, topic, created_at, send_after)
+        VALUES
+            ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING
+            id;
+    `, p.MangaID, p.MangaName, p.Chapter, p.URL, p.Notifier, p.Topic, p.CreatedAt, sendAfter).Scan(&p.ID)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, p.MangaID), err)
+	}
+
+	return nil
+}
+
+// GetPendingNotificationsFromDB gets all pending notifications from the database, oldest first.
+func GetPendingNotificationsFromDB() ([]*PendingNotification, error) {
+	contextError := "error getting pending notifications from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	pendingNotifications, err := getPendingNotificationsFromDB(db)
+	if err != nil {
+		return nil, util.AddErrorContext(contextError, err)
+	}
+
+	return pendingNotifications, nil
+}
+
+func getPendingNotificationsFromDB(db *sql.DB) ([]*PendingNotification, error) {
+	rows, err := db.Query(`
+        SELECT
+            id, manga_id, manga_name, chapter, url, notifier, topic, created_at, send_after
+        FROM
+            pending_notifications
+        ORDER BY
+            created_at ASC, id ASC;
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pendingNotifications := []*PendingNotification{}
+	for rows.Next() {
+		var p PendingNotification
+		var sendAfter sql.NullTime
+		err = rows.Scan(&p.ID, &p.MangaID, &p.MangaName, &p.Chapter, &p.URL, &p.Notifier, &p.Topic, &p.CreatedAt, &sendAfter)
+		if err != nil {
+			return nil, err
+		}
+		p.SendAfter = sendAfter.Time
+		pendingNotifications = append(pendingNotifications, &p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pendingNotifications, nil
+}
+
+// CanBeSent returns true if the notification can be sent at the time now.
+func (p *PendingNotification) CanBeSent(now time.Time) bool {
+	return p.SendAfter.IsZero() || !now.Before(p.SendAfter)
+}
+
+// GetPendingNotificationsToSend returns the pending notifications that can be sent at the time now.
+// The quiet hours notifications are returned when their quiet hours end. The digest notifications
+// of a notifier and topic are returned only if the oldest of them is older than the digest window.
+func GetPendingNotificationsToSend(pendingNotifications []*PendingNotification, now time.Time, digestWindow time.Duration) []*PendingNotification {
+	type target struct {
+		notifier string
+		topic    string
+	}
+	oldestDigests := map[target]time.Time{}
+	for _, p := range pendingNotifications {
+		if !p.SendAfter.IsZero() {
+			continue
+		}
+		t := target{notifier: p.Notifier, topic: p.Topic}
+		if oldest, ok := oldestDigests[t]; !ok || p.CreatedAt.Before(oldest) {
+			oldestDigests[t] = p.CreatedAt
+		}
+	}
+
+	toSend := []*PendingNotification{}
+	for _, p := range pendingNotifications {
+		if p.SendAfter.IsZero() {
+			if now.Sub(oldestDigests[target{notifier: p.Notifier, topic: p.Topic}]) < digestWindow {
+				continue
+			}
+		} else if !p.CanBeSent(now) {
+			continue
+		}
+		toSend = append(toSend, p)
+	}
+
+	return toSend
+}
+
+// DeletePendingNotificationsFromDB deletes the pending notifications with the IDs from the database.
+func DeletePendingNotificationsFromDB(ids []int) error {
+	contextError := "error deleting pending notifications from DB"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(contextError, err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+        DELETE FROM pending_notifications
+        WHERE id = ANY($1);
+    `, pq.Array(ids))
+	if err != nil {
+		return util.AddErrorContext(contextError, err)
+	}
+
+	return nil
+}
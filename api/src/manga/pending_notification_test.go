@@ -0,0 +1,82 @@
+package manga
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetPendingNotificationsToSend(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	window := 30 * time.Minute
+
+	getIDs := func(pendingNotifications []*PendingNotification) []int {
+		ids := []int{}
+		for _, p := range pendingNotifications {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+	equalIDs := func(a, b []int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	t.Run("Should send the digest notifications older than the window", func(t *testing.T) {
+		pendingNotifications := []*PendingNotification{
+			{ID: 1, CreatedAt: now.Add(-time.Hour)},
+			{ID: 2, CreatedAt: now.Add(-time.Minute)},
+		}
+		ids := getIDs(GetPendingNotificationsToSend(pendingNotifications, now, window))
+		if !equalIDs(ids, []int{1, 2}) {
+			t.Fatalf("Expected IDs [1 2], got %v", ids)
+		}
+	})
+	t.Run("Should not send the digest notifications newer than the window", func(t *testing.T) {
+		pendingNotifications := []*PendingNotification{
+			{ID: 1, CreatedAt: now.Add(-10 * time.Minute)},
+		}
+		ids := getIDs(GetPendingNotificationsToSend(pendingNotifications, now, window))
+		if len(ids) != 0 {
+			t.Fatalf("Expected no IDs, got %v", ids)
+		}
+	})
+	t.Run("Should apply the digest window to each notifier and topic", func(t *testing.T) {
+		pendingNotifications := []*PendingNotification{
+			{ID: 1, CreatedAt: now.Add(-time.Hour), Notifier: "ntfy", Topic: "a"},
+			{ID: 2, CreatedAt: now.Add(-10 * time.Minute), Notifier: "ntfy", Topic: "b"},
+			{ID: 3, CreatedAt: now.Add(-5 * time.Minute), Notifier: "ntfy", Topic: "a"},
+		}
+		ids := getIDs(GetPendingNotificationsToSend(pendingNotifications, now, window))
+		if !equalIDs(ids, []int{1, 3}) {
+			t.Fatalf("Expected IDs [1 3], got %v", ids)
+		}
+	})
+	t.Run("Should send the due quiet hours notifications regardless of the digest window", func(t *testing.T) {
+		pendingNotifications := []*PendingNotification{
+			{ID: 1, CreatedAt: now.Add(-2 * time.Minute), SendAfter: now.Add(-time.Minute)},
+			{ID: 2, CreatedAt: now.Add(-time.Minute)},
+			{ID: 3, CreatedAt: now.Add(-time.Minute), SendAfter: now.Add(time.Hour)},
+		}
+		ids := getIDs(GetPendingNotificationsToSend(pendingNotifications, now, window))
+		if !equalIDs(ids, []int{1}) {
+			t.Fatalf("Expected IDs [1], got %v", ids)
+		}
+	})
+	t.Run("Should send all due notifications without a digest window", func(t *testing.T) {
+		pendingNotifications := []*PendingNotification{
+			{ID: 1, CreatedAt: now},
+			{ID: 2, CreatedAt: now, SendAfter: now},
+		}
+		ids := getIDs(GetPendingNotificationsToSend(pendingNotifications, now, 0))
+		if !equalIDs(ids, []int{1, 2}) {
+			t.Fatalf("Expected IDs [1 2], got %v", ids)
+		}
+	})
+}
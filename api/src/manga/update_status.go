@@ -0,0 +1,158 @@
+package manga
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// UpdateStatus is the result of the metadata updates of a multimanga or custom manga.
+// It's used to find the mangas that fail to update, like mangas with dead URLs
+// or custom mangas with broken selectors.
+type UpdateStatus struct {
+	// LastAttemptAt is the last time the metadata was updated, successfully or not.
+	LastAttemptAt time.Time
+	// LastSuccessAt is the last time the metadata was updated successfully.
+	LastSuccessAt time.Time
+	// FailingSince is the time of the first failed update after the last successful update.
+	// It's zero if the last update was successful.
+	FailingSince time.Time
+	// LastError is the error of the last failed update. It's kept after a successful update.
+	LastError string
+	// ConsecutiveFailures is the number of failed updates since the last successful update.
+	ConsecutiveFailures int
+}
+
+// IsFailingFor returns true if the updates are failing for at least the duration at the time now.
+// A duration of 0 returns true if the last update failed.
+func (s *UpdateStatus) IsFailingFor(d time.Duration, now time.Time) bool {
+	if s.ConsecutiveFailures == 0 || s.FailingSince.IsZero() {
+		return false
+	}
+
+	return now.Sub(s.FailingSince) >= d
+}
+
+// IsFailingFor returns true if the updates of the multimanga, or of one of its custom
+// mangas, are failing for at least the duration at the time now.
+func (mm *MultiManga) IsFailingFor(d time.Duration, now time.Time) bool {
+	if mm.UpdateStatus.IsFailingFor(d, now) {
+		return true
+	}
+	for _, m := range mm.Mangas {
+		if m.Source == CustomMangaSource && m.UpdateStatus.IsFailingFor(d, now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateStatusColumns are the columns of the update status in the multimangas and mangas tables,
+// in the same order as the UpdateStatus scan destinations (see nullUpdateStatus.dest).
+const updateStatusColumns = "update_last_attempt_at, update_last_success_at, update_failing_since, update_last_error, update_consecutive_failures"
+
+// nullUpdateStatus is the scan destination of the update status columns, which can be null
+// when they're selected with a left join.
+type nullUpdateStatus struct {
+	lastAttemptAt, lastSuccessAt, failingSince sql.NullTime
+	lastError                                  sql.NullString
+	consecutiveFailures                        sql.NullInt32
+}
+
+// dest returns the scan destinations of the update status columns.
+func (s *nullUpdateStatus) dest() []any {
+	return []any{&s.lastAttemptAt, &s.lastSuccessAt, &s.failingSince, &s.lastError, &s.consecutiveFailures}
+}
+
+func (s *nullUpdateStatus) toUpdateStatus() UpdateStatus {
+	return UpdateStatus{
+		LastAttemptAt:       s.lastAttemptAt.Time,
+		LastSuccessAt:       s.lastSuccessAt.Time,
+		FailingSince:        s.failingSince.Time,
+		LastError:           s.lastError.String,
+		ConsecutiveFailures: int(s.consecutiveFailures.Int32),
+	}
+}
+
+// RecordUpdateResultInDB records the result of a multimanga metadata update in the database.
+// The update failed if errors is not empty.
+func (mm *MultiManga) RecordUpdateResultInDB(attemptAt time.Time, errors []string) error {
+	contextError := "error recording multimanga '%s' update result in DB"
+
+	status, err := recordUpdateResultDB("multimangas", mm.ID, attemptAt, errors)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return util.AddErrorContext(fmt.Sprintf(contextError, mm), errordefs.ErrMultiMangaNotFoundDB)
+		}
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm), err)
+	}
+	mm.UpdateStatus = *status
+
+	return nil
+}
+
+// RecordUpdateResultInDB records the result of a custom manga metadata update in the database.
+// The update failed if errors is not empty.
+func (m *Manga) RecordUpdateResultInDB(attemptAt time.Time, errors []string) error {
+	contextError := "error recording manga '%s' update result in DB"
+
+	status, err := recordUpdateResultDB("mangas", m.ID, attemptAt, errors)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return util.AddErrorContext(fmt.Sprintf(contextError, m), errordefs.ErrMangaNotFoundDB)
+		}
+		return util.AddErrorContext(fmt.Sprintf(contextError, m), err)
+	}
+	m.UpdateStatus = *status
+
+	return nil
+}
+
+// recordUpdateResultDB updates the update status columns of the table's row with the ID
+// and returns the new update status. The failures are counted in the query, so concurrent
+// updates don't overwrite each other's results. The times are stored in UTC, as the
+// columns don't have a time zone and are read as UTC.
+func recordUpdateResultDB(table string, id ID, attemptAt time.Time, errors []string) (*UpdateStatus, error) {
+	attemptAt = attemptAt.UTC()
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var query string
+	args := []any{attemptAt, id}
+	if len(errors) == 0 {
+		query = fmt.Sprintf(`
+            UPDATE %s
+            SET update_last_attempt_at = $1, update_last_success_at = $1, update_failing_since = NULL, update_consecutive_failures = 0
+            WHERE id = $2
+            RETURNING %s;
+        `, table, updateStatusColumns)
+	} else {
+		query = fmt.Sprintf(`
+            UPDATE %s
+            SET update_last_attempt_at = $1, update_failing_since = COALESCE(update_failing_since, $1),
+                update_last_error = $3, update_consecutive_failures = update_consecutive_failures + 1
+            WHERE id = $2
+            RETURNING %s;
+        `, table, updateStatusColumns)
+		args = append(args, strings.Join(errors, "\n"))
+	}
+
+	var status nullUpdateStatus
+	err = db.QueryRow(query, args...).Scan(status.dest()...)
+	if err != nil {
+		return nil, err
+	}
+	updateStatus := status.toUpdateStatus()
+
+	return &updateStatus, nil
+}
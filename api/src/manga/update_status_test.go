@@ -0,0 +1,90 @@
+package manga
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUpdateStatusIsFailingFor(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should not be failing if the last update was successful", func(t *testing.T) {
+		status := &UpdateStatus{LastAttemptAt: now, LastSuccessAt: now, LastError: "old error"}
+		if status.IsFailingFor(0, now) {
+			t.Fatal("Expected update status to not be failing")
+		}
+	})
+	t.Run("Should be failing for the time since the first failure", func(t *testing.T) {
+		status := &UpdateStatus{LastAttemptAt: now, FailingSince: now.Add(-4 * 24 * time.Hour), ConsecutiveFailures: 8}
+		if !status.IsFailingFor(0, now) {
+			t.Fatal("Expected update status to be failing")
+		}
+		if !status.IsFailingFor(3*24*time.Hour, now) {
+			t.Fatal("Expected update status to be failing for more than 3 days")
+		}
+		if status.IsFailingFor(5*24*time.Hour, now) {
+			t.Fatal("Expected update status to not be failing for more than 5 days")
+		}
+	})
+}
+
+func TestRecordUpdateResultDBLifeCycle(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC+9", 9*60*60)
+	defer func() { time.Local = local }()
+
+	multiManga := getMultiMangaCopy(multiMangaTest)
+
+	t.Run("Should insert a multimanga into DB", func(t *testing.T) {
+		err := multiManga.InsertIntoDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Should record a failed update in a non-UTC time zone", func(t *testing.T) {
+		err := multiManga.RecordUpdateResultInDB(time.Now(), []string{"error"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		multiMangaDB, err := GetMultiMangaFromDB(multiManga.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(multiMangaDB.UpdateStatus.FailingSince); elapsed < 0 || elapsed > time.Minute {
+			t.Fatalf("Expected the updates to be failing since now, got '%s' (%s ago)", multiMangaDB.UpdateStatus.FailingSince, elapsed)
+		}
+		if multiMangaDB.IsFailingFor(time.Hour, time.Now()) {
+			t.Fatal("Expected multimanga to not be failing for an hour")
+		}
+	})
+	t.Run("Should delete the multimanga from DB", func(t *testing.T) {
+		err := multiManga.DeleteFromDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestMultiMangaIsFailingFor(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	failing := UpdateStatus{LastAttemptAt: now, FailingSince: now.Add(-4 * 24 * time.Hour), ConsecutiveFailures: 8}
+
+	t.Run("Should be failing if the multimanga updates are failing", func(t *testing.T) {
+		mm := &MultiManga{UpdateStatus: failing, Mangas: []*Manga{{Source: "mangadex.org"}}}
+		if !mm.IsFailingFor(3*24*time.Hour, now) {
+			t.Fatal("Expected multimanga to be failing")
+		}
+	})
+	t.Run("Should be failing if a custom manga updates are failing", func(t *testing.T) {
+		mm := &MultiManga{Mangas: []*Manga{{Source: "mangadex.org"}, {Source: CustomMangaSource, UpdateStatus: failing}}}
+		if !mm.IsFailingFor(3*24*time.Hour, now) {
+			t.Fatal("Expected multimanga to be failing")
+		}
+	})
+	t.Run("Should ignore the update status of the mangas from sources", func(t *testing.T) {
+		mm := &MultiManga{Mangas: []*Manga{{Source: "mangadex.org", UpdateStatus: failing}}}
+		if mm.IsFailingFor(0, now) {
+			t.Fatal("Expected multimanga to not be failing")
+		}
+	})
+}
@@ -0,0 +1,472 @@
+package manga
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/diogovalentte/mantium/api/src/db"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// userMultiManga is the state of a multimanga in a user's library.
+// The multimangas and their mangas are shared by all users, but each
+// user has its own status and last read chapter for the multimanga.
+type userMultiManga struct {
+	lastReadChapter *Chapter
+	multimangaID    ID
+	status          Status
+}
+
+// applyTo sets the user's status and last read chapter in the multimanga.
+func (um *userMultiManga) applyTo(mm *MultiManga) {
+	mm.Status = um.status
+	mm.LastReadChapter = um.lastReadChapter
+	for _, m := range mm.Mangas {
+		m.Status = um.status
+	}
+}
+
+// GetUserMultiMangasDB gets the multimangas in the user's library from the database
+// with the user's status, last read chapter, and unread chapters.
+// The getMangas argument works like in GetMultiMangasDB.
+func GetUserMultiMangasDB(userID int, getMangas bool) ([]*MultiManga, error) {
+	contextError := "error getting multimangas of user with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+	defer db.Close()
+
+	var multimangas []*MultiManga
+	if !getMangas {
+		multimangas, err = getMultiMangasWithoutMangasDB(db)
+	} else {
+		multimangas, err = getMultiMangasWithMangasDB(db)
+	}
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	userMultiMangas, err := getUserMultiMangasFromDB(userID, -1, db)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	unreadChapters, err := getMultiMangasUnreadChaptersFromDB(-1, userID, db)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	libraryMultiMangas := []*MultiManga{}
+	for _, multimanga := range multimangas {
+		userMultiManga, ok := userMultiMangas[multimanga.ID]
+		if !ok {
+			continue
+		}
+		userMultiManga.applyTo(multimanga)
+		multimanga.UnreadChapters = unreadChapters[multimanga.ID]
+		libraryMultiMangas = append(libraryMultiMangas, multimanga)
+	}
+
+	return libraryMultiMangas, nil
+}
+
+// GetUserMultiMangaFromDB gets a multimanga in the user's library from the database
+// with the user's status, last read chapter, and unread chapters.
+func GetUserMultiMangaFromDB(userID int, multimangaID ID) (*MultiManga, error) {
+	contextError := "error getting multimanga with ID '%d' of user with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID, userID), err)
+	}
+	defer db.Close()
+
+	mm, err := getMultiMangaFromDB(multimangaID, db)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID, userID), errordefs.ErrMultiMangaNotFoundDB)
+		}
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID, userID), err)
+	}
+
+	userMultiMangas, err := getUserMultiMangasFromDB(userID, multimangaID, db)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID, userID), err)
+	}
+	userMultiManga, ok := userMultiMangas[multimangaID]
+	if !ok {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID, userID), errordefs.ErrMultiMangaNotInUserLibrary)
+	}
+	userMultiManga.applyTo(mm)
+
+	unreadChapters, err := getMultiMangasUnreadChaptersFromDB(multimangaID, userID, db)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, multimangaID, userID), err)
+	}
+	mm.UnreadChapters = unreadChapters[mm.ID]
+
+	return mm, nil
+}
+
+// getUserMultiMangasFromDB returns the user's multimangas states in a map where the key is the multimanga ID.
+// If multimangaID is -1, gets the states of all multimangas in the user's library.
+func getUserMultiMangasFromDB(userID int, multimangaID ID, db *sql.DB) (map[ID]*userMultiManga, error) {
+	rows, err := db.Query(`
+        SELECT
+            multimanga_id, status,
+            last_read_chapter_url, last_read_chapter, last_read_chapter_volume, last_read_chapter_name,
+            last_read_chapter_internal_id, last_read_chapter_updated_at, last_read_chapter_from_source_site
+        FROM
+            user_multimangas
+        WHERE
+            user_id = $1 AND ($2 = -1 OR multimanga_id = $2);
+    `, userID, multimangaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userMultiMangas := map[ID]*userMultiManga{}
+	for rows.Next() {
+		var um userMultiManga
+		var (
+			lastReadChapterURL, lastReadChapterChapter, lastReadChapterVolume, lastReadChapterName, lastReadChapterInternalID sql.NullString
+			lastReadChapterUpdatedAt                                                                                          sql.NullTime
+			lastReadChapterFromSourceSite                                                                                     sql.NullBool
+		)
+		err = rows.Scan(
+			&um.multimangaID, &um.status,
+			&lastReadChapterURL, &lastReadChapterChapter, &lastReadChapterVolume, &lastReadChapterName,
+			&lastReadChapterInternalID, &lastReadChapterUpdatedAt, &lastReadChapterFromSourceSite,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastReadChapterURL.Valid {
+			um.lastReadChapter = &Chapter{
+				URL:            lastReadChapterURL.String,
+				Chapter:        lastReadChapterChapter.String,
+				Volume:         lastReadChapterVolume.String,
+				Name:           lastReadChapterName.String,
+				InternalID:     lastReadChapterInternalID.String,
+				UpdatedAt:      lastReadChapterUpdatedAt.Time,
+				FromSourceSite: lastReadChapterFromSourceSite.Bool,
+				Type:           2,
+			}
+		}
+		userMultiMangas[um.multimangaID] = &um
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return userMultiMangas, nil
+}
+
+// AddToUserLibraryInDB adds the multimanga to the user's library in the database
+// using the multimanga's status and last read chapter.
+func (mm *MultiManga) AddToUserLibraryInDB(userID int) error {
+	contextError := "error adding multimanga '%s' to the library of user with ID '%d' in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	err = insertUserMultiMangaDB(mm, userID, tx)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	return nil
+}
+
+func insertUserMultiMangaDB(mm *MultiManga, userID int, tx *sql.Tx) error {
+	err := ValidateStatus(mm.Status)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+        INSERT INTO user_multimangas
+            (user_id, multimanga_id, status)
+        VALUES
+            ($1, $2, $3);
+    `, userID, mm.ID, mm.Status)
+	if err != nil {
+		if err.Error() == `pq: duplicate key value violates unique constraint "user_multimangas_pkey"` {
+			return errordefs.ErrMultiMangaAlreadyInUserLibrary
+		}
+		return err
+	}
+
+	if mm.LastReadChapter != nil {
+		err = updateUserMultiMangaLastReadChapterDB(mm.ID, userID, mm.LastReadChapter, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveFromUserLibraryInDB removes the multimanga from the user's library in the database.
+// The multimanga is not deleted, as it's shared by all users.
+func (mm *MultiManga) RemoveFromUserLibraryInDB(userID int) error {
+	contextError := "error removing multimanga '%s' from the library of user with ID '%d' in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	result, err := tx.Exec(`
+        DELETE FROM user_multimangas
+        WHERE user_id = $1 AND multimanga_id = $2;
+    `, userID, mm.ID)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	err = checkUserMultiMangaRowsAffected(result)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	return nil
+}
+
+// UpdateUserStatusInDB updates the multimanga status in the user's library in the database.
+func (mm *MultiManga) UpdateUserStatusInDB(userID int, status Status) error {
+	contextError := "error updating multimanga '%s' status in the library of user with ID '%d' in DB"
+
+	err := ValidateStatus(status)
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	result, err := tx.Exec(`
+        UPDATE user_multimangas
+        SET status = $1
+        WHERE user_id = $2 AND multimanga_id = $3;
+    `, status, userID, mm.ID)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	err = checkUserMultiMangaRowsAffected(result)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	mm.Status = status
+
+	return nil
+}
+
+// UpsertUserLastReadChapterIntoDB updates the multimanga last read chapter in the user's library in the database.
+// The chapter.Type field must be set to 2 (last read).
+func (mm *MultiManga) UpsertUserLastReadChapterIntoDB(userID int, chapter *Chapter) error {
+	contextError := "error upserting last read chapter '%s' to multimanga '%s' in the library of user with ID '%d' in DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, chapter, mm, userID), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, chapter, mm, userID), err)
+	}
+
+	err = updateUserMultiMangaLastReadChapterDB(mm.ID, userID, chapter, tx)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, chapter, mm, userID), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, chapter, mm, userID), err)
+	}
+	mm.LastReadChapter = chapter
+
+	return nil
+}
+
+// DeleteUserLastReadChapterFromDB deletes the multimanga last read chapter in the user's library in the database.
+func (mm *MultiManga) DeleteUserLastReadChapterFromDB(userID int) error {
+	contextError := "error deleting last read chapter of multimanga '%s' in the library of user with ID '%d' from DB"
+
+	db, err := db.OpenConn()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	err = updateUserMultiMangaLastReadChapterDB(mm.ID, userID, nil, tx)
+	if err != nil {
+		tx.Rollback()
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return util.AddErrorContext(fmt.Sprintf(contextError, mm, userID), err)
+	}
+	mm.LastReadChapter = nil
+
+	return nil
+}
+
+// updateUserMultiMangaLastReadChapterDB sets the user's multimanga last read chapter.
+// If chapter is nil, deletes the last read chapter.
+func updateUserMultiMangaLastReadChapterDB(mmID ID, userID int, chapter *Chapter, tx *sql.Tx) error {
+	var (
+		url, chapterChapter, volume, name, internalID sql.NullString
+		updatedAt                                     sql.NullTime
+		fromSourceSite                                sql.NullBool
+	)
+	if chapter != nil {
+		err := validateChapter(chapter)
+		if err != nil {
+			return err
+		}
+		if chapter.Type != 2 {
+			return fmt.Errorf("chapter type should be 2 (last read), instead it's %d", chapter.Type)
+		}
+		url = sql.NullString{String: chapter.URL, Valid: true}
+		chapterChapter = sql.NullString{String: chapter.Chapter, Valid: true}
+		volume = sql.NullString{String: chapter.Volume, Valid: true}
+		name = sql.NullString{String: chapter.Name, Valid: true}
+		internalID = sql.NullString{String: chapter.InternalID, Valid: true}
+		updatedAt = sql.NullTime{Time: chapter.UpdatedAt, Valid: !chapter.UpdatedAt.IsZero()}
+		fromSourceSite = sql.NullBool{Bool: chapter.FromSourceSite, Valid: true}
+	}
+
+	result, err := tx.Exec(`
+        UPDATE user_multimangas
+        SET
+            last_read_chapter_url = $1, last_read_chapter = $2, last_read_chapter_volume = $3, last_read_chapter_name = $4,
+            last_read_chapter_internal_id = $5, last_read_chapter_updated_at = $6, last_read_chapter_from_source_site = $7
+        WHERE user_id = $8 AND multimanga_id = $9;
+    `, url, chapterChapter, volume, name, internalID, updatedAt, fromSourceSite, userID, mmID)
+	if err != nil {
+		return err
+	}
+
+	return checkUserMultiMangaRowsAffected(result)
+}
+
+func checkUserMultiMangaRowsAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errordefs.ErrMultiMangaNotInUserLibrary
+	}
+
+	return nil
+}
+
+// GetUserLibraryStats returns the library stats of the user's library.
+// It has the same properties as the GetLibraryStats stats.
+func GetUserLibraryStats(userID int) (map[string]int, error) {
+	contextError := "error getting library stats of user with ID '%d'"
+
+	multimangas, err := GetUserMultiMangasDB(userID, false)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	stats, err := getMultiMangasLibraryStats(multimangas)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(contextError, userID), err)
+	}
+
+	return stats, nil
+}
+
+// getMultiMangasLibraryStats returns the library stats of the multimangas.
+// A multimanga is unread if it doesn't have a last read chapter or if its
+// last read chapter is different from the current manga's last released chapter.
+func getMultiMangasLibraryStats(multimangas []*MultiManga) (map[string]int, error) {
+	stats := map[string]int{
+		"Unread":         0,
+		"Total":          len(multimangas),
+		"Read":           0,
+		"UnreadChapters": 0,
+	}
+
+	for _, multimanga := range multimangas {
+		status, err := getStatusStr(int(multimanga.Status))
+		if err != nil {
+			return nil, err
+		}
+		stats[status]++
+
+		var lastReleasedChapter *Chapter
+		if multimanga.CurrentManga != nil {
+			lastReleasedChapter = multimanga.CurrentManga.LastReleasedChapter
+		}
+		if multimanga.LastReadChapter == nil || (lastReleasedChapter != nil && !IsSameChapter(lastReleasedChapter, multimanga.LastReadChapter)) {
+			stats["Unread"]++
+		}
+		stats["UnreadChapters"] += multimanga.UnreadChapters
+	}
+	stats["Read"] = stats["Total"] - stats["Unread"]
+
+	return stats, nil
+}
@@ -0,0 +1,55 @@
+package manga
+
+import (
+	"testing"
+)
+
+func TestGetMultiMangasLibraryStats(t *testing.T) {
+	newMultiManga := func(status Status, lastReleased, lastRead string, unreadChapters int) *MultiManga {
+		mm := &MultiManga{Status: status, CurrentManga: &Manga{}, UnreadChapters: unreadChapters}
+		if lastReleased != "" {
+			mm.CurrentManga.LastReleasedChapter = &Chapter{Chapter: lastReleased, Type: 1}
+		}
+		if lastRead != "" {
+			mm.LastReadChapter = &Chapter{Chapter: lastRead, Type: 2}
+		}
+		return mm
+	}
+
+	t.Run("Should count the user multimangas", func(t *testing.T) {
+		multimangas := []*MultiManga{
+			newMultiManga(1, "10", "10", 0),
+			newMultiManga(1, "12", "10", 2),
+			newMultiManga(2, "5", "", 5),
+			newMultiManga(5, "", "1", 0),
+		}
+		stats, err := getMultiMangasLibraryStats(multimangas)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := map[string]int{
+			"Reading":        2,
+			"Completed":      1,
+			"Plan to Read":   1,
+			"Unread":         2,
+			"Read":           2,
+			"Total":          4,
+			"UnreadChapters": 7,
+		}
+		if len(stats) != len(expected) {
+			t.Fatalf("Expected stats %v, got %v", expected, stats)
+		}
+		for key, value := range expected {
+			if stats[key] != value {
+				t.Fatalf("Expected %s to be %d, got %d", key, value, stats[key])
+			}
+		}
+	})
+	t.Run("Should return an error for an invalid status", func(t *testing.T) {
+		_, err := getMultiMangasLibraryStats([]*MultiManga{newMultiManga(0, "1", "1", 0)})
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
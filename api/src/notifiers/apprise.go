@@ -0,0 +1,58 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// Apprise sends notifications to an Apprise API server.
+// If a configuration key is set, the notification is sent to the
+// URLs stored in the key, else it's sent to the configured URLs.
+type Apprise struct {
+	Configs *config.AppriseConfigs
+	c       *http.Client
+}
+
+// GetName returns the notifier name.
+func (a *Apprise) GetName() string {
+	return "apprise"
+}
+
+// GetRetries returns the notifier retries.
+func (a *Apprise) GetRetries() int {
+	return a.Configs.Retries
+}
+
+// Notify sends the notification to the Apprise API server.
+func (a *Apprise) Notify(ctx context.Context, notification *Notification) error {
+	body := notification.Message
+	if notification.URL != "" {
+		body = fmt.Sprintf("%s\n%s", notification.Message, notification.URL)
+	}
+	payload := map[string]string{
+		"title":  notification.Title,
+		"body":   body,
+		"type":   "info",
+		"format": "text",
+	}
+
+	address := strings.TrimSuffix(a.Configs.Address, "/")
+	url := fmt.Sprintf("%s/notify", address)
+	if a.Configs.Key != "" {
+		url = fmt.Sprintf("%s/notify/%s", address, a.Configs.Key)
+	} else {
+		payload["urls"] = a.Configs.URLs
+	}
+
+	err := postJSON(ctx, a.c, url, payload, nil)
+	if err != nil {
+		return util.AddErrorContext("(apprise) error while sending notification", err)
+	}
+
+	return nil
+}
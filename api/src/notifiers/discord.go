@@ -0,0 +1,54 @@
+package notifiers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// discordMaxDescriptionLength is the maximum length of a Discord embed description.
+const discordMaxDescriptionLength = 4096
+
+// Discord sends notifications to a Discord webhook.
+type Discord struct {
+	Configs *config.DiscordConfigs
+	c       *http.Client
+}
+
+// GetName returns the notifier name.
+func (d *Discord) GetName() string {
+	return "discord"
+}
+
+// GetRetries returns the notifier retries.
+func (d *Discord) GetRetries() int {
+	return d.Configs.Retries
+}
+
+// Notify sends the notification to the Discord webhook as an embed.
+func (d *Discord) Notify(ctx context.Context, notification *Notification) error {
+	description := notification.Message
+	if runes := []rune(description); len(runes) > discordMaxDescriptionLength {
+		description = string(runes[:discordMaxDescriptionLength-3]) + "..."
+	}
+	embed := map[string]string{
+		"title":       notification.Title,
+		"description": description,
+	}
+	if notification.URL != "" {
+		embed["url"] = notification.URL
+	}
+	payload := map[string]any{
+		"username": "Mantium",
+		"embeds":   []map[string]string{embed},
+	}
+
+	err := postJSON(ctx, d.c, d.Configs.WebhookURL, payload, nil)
+	if err != nil {
+		return util.AddErrorContext("(discord) error while sending notification", err)
+	}
+
+	return nil
+}
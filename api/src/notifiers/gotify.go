@@ -0,0 +1,63 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// gotifyPriorities maps the notification priorities (1-5) to Gotify priorities (0-10).
+var gotifyPriorities = map[int]int{1: 0, 2: 2, 3: 5, 4: 7, 5: 10}
+
+// Gotify sends notifications to a Gotify server.
+type Gotify struct {
+	Configs *config.GotifyConfigs
+	c       *http.Client
+}
+
+// GetName returns the notifier name.
+func (g *Gotify) GetName() string {
+	return "gotify"
+}
+
+// GetRetries returns the notifier retries.
+func (g *Gotify) GetRetries() int {
+	return g.Configs.Retries
+}
+
+// Notify sends the notification to the Gotify server.
+func (g *Gotify) Notify(ctx context.Context, notification *Notification) error {
+	priority := g.Configs.Priority
+	if p, ok := gotifyPriorities[notification.Priority]; ok {
+		priority = p
+	}
+	payload := map[string]any{
+		"title":    notification.Title,
+		"message":  notification.Message,
+		"priority": priority,
+	}
+	if notification.URL != "" {
+		payload["extras"] = map[string]any{
+			"client::notification": map[string]any{
+				"click": map[string]string{
+					"url": notification.URL,
+				},
+			},
+		}
+	}
+	headers := map[string]string{
+		"X-Gotify-Key": g.Configs.Token,
+	}
+
+	url := fmt.Sprintf("%s/message", strings.TrimSuffix(g.Configs.Address, "/"))
+	err := postJSON(ctx, g.c, url, payload, headers)
+	if err != nil {
+		return util.AddErrorContext("(gotify) error while sending notification", err)
+	}
+
+	return nil
+}
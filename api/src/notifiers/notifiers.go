@@ -0,0 +1,202 @@
+// Package notifiers implements the notifiers used to notify about new chapters,
+// like ntfy, webhooks, Gotify, Discord, Slack, Apprise, and SMTP email.
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// Notification is a notification about a manga's new chapter.
+type Notification struct {
+	Title     string
+	Message   string
+	MangaName string
+	Chapter   string
+	// URL is the chapter URL. It's empty in digests with more than one chapter.
+	URL string
+	// Notifier is the name of the notifier used to send the notification.
+	// If empty, all enabled notifiers are used.
+	Notifier string
+	// Topic overrides the topic the notification is sent to, if the notifier supports topics.
+	Topic string
+	// Chapters are the chapters of a digest notification.
+	Chapters []*NotificationChapter
+	// Tags are used by the notifiers that support tags, like ntfy.
+	Tags []string
+	// Priority is the notification priority from 1 (min) to 5 (max). If 0, the notifier's default priority is used.
+	Priority int
+}
+
+// NotificationChapter is a new chapter in a digest notification.
+type NotificationChapter struct {
+	MangaName string `json:"manga_name"`
+	Chapter   string `json:"chapter"`
+	URL       string `json:"url"`
+}
+
+// NewDigestNotification returns a notification that groups the chapters into a single summary.
+func NewDigestNotification(chapters []*NotificationChapter) *Notification {
+	var message strings.Builder
+	for i, chapter := range chapters {
+		if i > 0 {
+			message.WriteString("\n")
+		}
+		fmt.Fprintf(&message, "%s: chapter %s\n%s", chapter.MangaName, chapter.Chapter, chapter.URL)
+	}
+
+	notification := &Notification{
+		Title:    fmt.Sprintf("(Mantium) %d new chapters", len(chapters)),
+		Message:  message.String(),
+		Chapters: chapters,
+	}
+	if len(chapters) == 1 {
+		notification.Title = fmt.Sprintf("(Mantium) New chapter of manga: %s", chapters[0].MangaName)
+		notification.MangaName = chapters[0].MangaName
+		notification.Chapter = chapters[0].Chapter
+		notification.URL = chapters[0].URL
+	}
+
+	return notification
+}
+
+// Notifier is a backend used to send notifications.
+type Notifier interface {
+	// GetName returns the notifier name, like "ntfy".
+	GetName() string
+	// GetRetries returns the number of times a notification is tried to be sent before giving up.
+	GetRetries() int
+	// Notify sends the notification.
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+// GetNotifiers returns the enabled notifiers based on the configs.
+func GetNotifiers() []Notifier {
+	configs := config.GlobalConfigs
+	notifiers := []Notifier{}
+
+	if configs.Ntfy.Enabled {
+		notifiers = append(notifiers, &Ntfy{Configs: configs.Ntfy})
+	}
+	if configs.Webhook.Enabled {
+		notifiers = append(notifiers, &Webhook{Configs: configs.Webhook, c: &http.Client{}})
+	}
+	if configs.Gotify.Enabled {
+		notifiers = append(notifiers, &Gotify{Configs: configs.Gotify, c: &http.Client{}})
+	}
+	if configs.Discord.Enabled {
+		notifiers = append(notifiers, &Discord{Configs: configs.Discord, c: &http.Client{}})
+	}
+	if configs.Slack.Enabled {
+		notifiers = append(notifiers, &Slack{Configs: configs.Slack, c: &http.Client{}})
+	}
+	if configs.Apprise.Enabled {
+		notifiers = append(notifiers, &Apprise{Configs: configs.Apprise, c: &http.Client{}})
+	}
+	if configs.SMTP.Enabled {
+		notifiers = append(notifiers, &SMTP{Configs: configs.SMTP})
+	}
+
+	return notifiers
+}
+
+// GetNotifiersNames returns the names of the enabled notifiers.
+func GetNotifiersNames() []string {
+	notifiers := GetNotifiers()
+	names := make([]string, 0, len(notifiers))
+	for _, notifier := range notifiers {
+		names = append(names, notifier.GetName())
+	}
+
+	return names
+}
+
+// Notify sends the notification using the notifier, retrying
+// up to the notifier's retries with retryInterval between the tries.
+func Notify(ctx context.Context, notifier Notifier, notification *Notification, retryInterval time.Duration) error {
+	retries := max(notifier.GetRetries(), 1)
+
+	var err error
+	for i := range retries {
+		err = notifier.Notify(ctx, notification)
+		if err == nil {
+			return nil
+		}
+		if i == retries-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return util.AddErrorContext(fmt.Sprintf("(%s) error while sending notification", notifier.GetName()), err)
+		case <-time.After(retryInterval):
+		}
+	}
+
+	return err
+}
+
+// NotifyAll sends the notification using all enabled notifiers, or only
+// the notifier set in the notification if it's not empty.
+// It returns a map with the notifier name as key and the error as value
+// for each notifier that couldn't send the notification, including the
+// notification's notifier if it's not enabled.
+func NotifyAll(ctx context.Context, notification *Notification, retryInterval time.Duration) map[string]error {
+	errors := map[string]error{}
+	var notified bool
+	for _, notifier := range GetNotifiers() {
+		if notification.Notifier != "" && notification.Notifier != notifier.GetName() {
+			continue
+		}
+		notified = true
+		err := Notify(ctx, notifier, notification, retryInterval)
+		if err != nil {
+			errors[notifier.GetName()] = err
+		}
+	}
+	if notification.Notifier != "" && !notified {
+		errors[notification.Notifier] = fmt.Errorf("notifier '%s' is not enabled", notification.Notifier)
+	}
+
+	return errors
+}
+
+// postJSON sends a POST request with the body encoded as JSON to the URL
+// and returns an error if the response status code is not 2xx.
+func postJSON(ctx context.Context, c *http.Client, url string, body any, headers map[string]string) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return util.AddErrorContext("error while encoding request body", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return util.AddErrorContext("error while creating request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return util.AddErrorContext("error while doing request", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("non-2xx status code: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
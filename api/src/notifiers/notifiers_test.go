@@ -0,0 +1,268 @@
+package notifiers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+)
+
+var notification = &Notification{
+	Title:     "(Mantium) New chapter of manga: One Piece",
+	Message:   "New chapter: 1001",
+	MangaName: "One Piece",
+	Chapter:   "1001",
+	URL:       "https://mangahub.io/chapter/one-piece_142/chapter-1001",
+}
+
+type receivedRequest struct {
+	Body    map[string]any
+	Headers http.Header
+	Path    string
+}
+
+func newTestServer(t *testing.T, statusCode int) (*httptest.Server, *[]receivedRequest) {
+	requests := &[]receivedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request body: %s", err)
+		}
+		*requests = append(*requests, receivedRequest{Body: body, Headers: r.Header, Path: r.URL.Path})
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func TestHTTPNotifiers(t *testing.T) {
+	notifierConfigs := config.NotifierConfigs{Enabled: true, Retries: 1}
+
+	t.Run("Notify with webhook", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier := &Webhook{Configs: &config.WebhookConfigs{URL: server.URL, NotifierConfigs: notifierConfigs}, c: server.Client()}
+
+		if err := notifier.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+		if len(*requests) != 1 {
+			t.Fatalf("expected 1 request, got %d", len(*requests))
+		}
+		body := (*requests)[0].Body
+		if body["manga_name"] != notification.MangaName || body["chapter"] != notification.Chapter || body["url"] != notification.URL {
+			t.Fatalf("unexpected body: %v", body)
+		}
+	})
+	t.Run("Notify with Gotify", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier := &Gotify{Configs: &config.GotifyConfigs{Address: server.URL + "/", Token: "token", Priority: 8, NotifierConfigs: notifierConfigs}, c: server.Client()}
+
+		if err := notifier.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+		request := (*requests)[0]
+		if request.Path != "/message" {
+			t.Fatalf("expected path /message, got %s", request.Path)
+		}
+		if request.Headers.Get("X-Gotify-Key") != "token" {
+			t.Fatalf("expected X-Gotify-Key header to be 'token', got '%s'", request.Headers.Get("X-Gotify-Key"))
+		}
+		if request.Body["title"] != notification.Title || request.Body["priority"] != float64(8) {
+			t.Fatalf("unexpected body: %v", request.Body)
+		}
+	})
+	t.Run("Notify with Discord", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusNoContent)
+		notifier := &Discord{Configs: &config.DiscordConfigs{WebhookURL: server.URL, NotifierConfigs: notifierConfigs}, c: server.Client()}
+
+		if err := notifier.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+		embeds, ok := (*requests)[0].Body["embeds"].([]any)
+		if !ok || len(embeds) != 1 {
+			t.Fatalf("expected 1 embed, got %v", (*requests)[0].Body["embeds"])
+		}
+		if embed := embeds[0].(map[string]any); embed["url"] != notification.URL {
+			t.Fatalf("unexpected embed: %v", embed)
+		}
+	})
+	t.Run("Notify with Slack", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier := &Slack{Configs: &config.SlackConfigs{WebhookURL: server.URL, NotifierConfigs: notifierConfigs}, c: server.Client()}
+
+		if err := notifier.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+		text, _ := (*requests)[0].Body["text"].(string)
+		if !strings.Contains(text, fmt.Sprintf("<%s|Open Chapter>", notification.URL)) {
+			t.Fatalf("unexpected text: %s", text)
+		}
+	})
+	t.Run("Notify with Apprise using a configuration key", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier := &Apprise{Configs: &config.AppriseConfigs{Address: server.URL, Key: "mantium", NotifierConfigs: notifierConfigs}, c: server.Client()}
+
+		if err := notifier.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+		request := (*requests)[0]
+		if request.Path != "/notify/mantium" {
+			t.Fatalf("expected path /notify/mantium, got %s", request.Path)
+		}
+		if _, ok := request.Body["urls"]; ok {
+			t.Fatalf("expected no urls in body, got %v", request.Body)
+		}
+	})
+	t.Run("Notify with Apprise using URLs", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier := &Apprise{Configs: &config.AppriseConfigs{Address: server.URL, URLs: "json://localhost", NotifierConfigs: notifierConfigs}, c: server.Client()}
+
+		if err := notifier.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+		request := (*requests)[0]
+		if request.Path != "/notify" || request.Body["urls"] != "json://localhost" {
+			t.Fatalf("unexpected request: %s %v", request.Path, request.Body)
+		}
+	})
+	t.Run("Return error on non-2xx status code", func(t *testing.T) {
+		server, _ := newTestServer(t, http.StatusInternalServerError)
+		notifier := &Webhook{Configs: &config.WebhookConfigs{URL: server.URL, NotifierConfigs: notifierConfigs}, c: server.Client()}
+
+		if err := notifier.Notify(context.Background(), notification); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestNotify(t *testing.T) {
+	t.Run("Retry until the notification is sent", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		failures := 2
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, receivedRequest{Path: r.URL.Path})
+			if len(*requests) <= failures {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		notifier := &Webhook{Configs: &config.WebhookConfigs{URL: server.URL, NotifierConfigs: config.NotifierConfigs{Enabled: true, Retries: 3}}, c: server.Client()}
+
+		if err := Notify(context.Background(), notifier, notification, time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		if len(*requests) != failures+1 {
+			t.Fatalf("expected %d requests, got %d", failures+1, len(*requests))
+		}
+	})
+	t.Run("Return error after all retries", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusBadGateway)
+		notifier := &Webhook{Configs: &config.WebhookConfigs{URL: server.URL, NotifierConfigs: config.NotifierConfigs{Enabled: true, Retries: 2}}, c: server.Client()}
+
+		if err := Notify(context.Background(), notifier, notification, time.Millisecond); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(*requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(*requests))
+		}
+	})
+}
+
+func TestNotifyAll(t *testing.T) {
+	t.Run("Return error if the notification's notifier is not enabled", func(t *testing.T) {
+		config.GlobalConfigs.Slack = &config.SlackConfigs{}
+		notification := *notification
+		notification.Notifier = "slack"
+
+		errors := NotifyAll(context.Background(), &notification, time.Millisecond)
+		if errors["slack"] == nil {
+			t.Fatalf("expected not enabled error, got %v", errors)
+		}
+	})
+}
+
+func TestSMTPGetMessage(t *testing.T) {
+	t.Run("Build email message", func(t *testing.T) {
+		notifier := &SMTP{Configs: &config.SMTPConfigs{From: "mantium@example.com", To: []string{"a@example.com", "b@example.com"}}}
+
+		msg := string(notifier.getMessage(notification))
+		for _, expected := range []string{
+			"From: mantium@example.com\r\n",
+			"To: a@example.com, b@example.com\r\n",
+			"Subject: " + notification.Title + "\r\n",
+			notification.URL,
+		} {
+			if !strings.Contains(msg, expected) {
+				t.Fatalf("expected message to contain '%s', got:\n%s", expected, msg)
+			}
+		}
+	})
+	t.Run("Remove line breaks and encode the subject", func(t *testing.T) {
+		notifier := &SMTP{Configs: &config.SMTPConfigs{From: "mantium@example.com", To: []string{"a@example.com"}}}
+		n := &Notification{Title: "(Mantium) New chapter\r\nBcc: c@example.com", Message: "message"}
+
+		msg := string(notifier.getMessage(n))
+		if strings.Contains(msg, "\r\nBcc:") {
+			t.Fatalf("expected subject line breaks to be removed, got:\n%s", msg)
+		}
+		if !strings.Contains(msg, "Subject: (Mantium) New chapter Bcc: c@example.com\r\n") {
+			t.Fatalf("unexpected subject, got:\n%s", msg)
+		}
+
+		n.Title = "(Mantium) 第12話"
+		msg = string(notifier.getMessage(n))
+		expected := "Subject: =?utf-8?q?(Mantium)_=E7=AC=AC12=E8=A9=B1?=\r\n"
+		if !strings.Contains(msg, expected) {
+			t.Fatalf("expected message to contain '%s', got:\n%s", expected, msg)
+		}
+	})
+}
+
+func TestNewDigestNotification(t *testing.T) {
+	t.Run("Group chapters into a single notification", func(t *testing.T) {
+		chapters := []*NotificationChapter{
+			{MangaName: "One Piece", Chapter: "1001", URL: "https://mangahub.io/chapter/one-piece_142/chapter-1001"},
+			{MangaName: "Yotsuba&!", Chapter: "110", URL: "https://mangahub.io/chapter/yotsubato/chapter-110"},
+		}
+
+		digest := NewDigestNotification(chapters)
+		if digest.Title != "(Mantium) 2 new chapters" {
+			t.Fatalf("unexpected title: %s", digest.Title)
+		}
+		if digest.URL != "" {
+			t.Fatalf("expected empty URL, got %s", digest.URL)
+		}
+		for _, chapter := range chapters {
+			if !strings.Contains(digest.Message, chapter.URL) || !strings.Contains(digest.Message, chapter.MangaName) {
+				t.Fatalf("expected message to contain chapter %v, got:\n%s", chapter, digest.Message)
+			}
+		}
+	})
+	t.Run("Use the chapter URL if there is only one chapter", func(t *testing.T) {
+		digest := NewDigestNotification([]*NotificationChapter{{MangaName: "One Piece", Chapter: "1001", URL: notification.URL}})
+		if digest.URL != notification.URL || digest.Title != notification.Title {
+			t.Fatalf("unexpected notification: %v", digest)
+		}
+	})
+	t.Run("Send the chapters in the webhook payload", func(t *testing.T) {
+		server, requests := newTestServer(t, http.StatusOK)
+		notifier := &Webhook{Configs: &config.WebhookConfigs{URL: server.URL, NotifierConfigs: config.NotifierConfigs{Enabled: true, Retries: 1}}, c: server.Client()}
+
+		digest := NewDigestNotification([]*NotificationChapter{{MangaName: "A", Chapter: "1"}, {MangaName: "B", Chapter: "2"}})
+		if err := notifier.Notify(context.Background(), digest); err != nil {
+			t.Fatal(err)
+		}
+		chapters, ok := (*requests)[0].Body["chapters"].([]any)
+		if !ok || len(chapters) != 2 {
+			t.Fatalf("expected 2 chapters, got %v", (*requests)[0].Body["chapters"])
+		}
+	})
+}
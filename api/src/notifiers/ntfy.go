@@ -0,0 +1,71 @@
+package notifiers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/AnthonyHewins/gotfy"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/integrations/ntfy"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// Ntfy sends notifications to a ntfy topic.
+type Ntfy struct {
+	Configs *config.NtfyConfigs
+}
+
+// GetName returns the notifier name.
+func (n *Ntfy) GetName() string {
+	return "ntfy"
+}
+
+// GetRetries returns the notifier retries.
+func (n *Ntfy) GetRetries() int {
+	return n.Configs.Retries
+}
+
+// Notify sends the notification to the ntfy topic.
+func (n *Ntfy) Notify(ctx context.Context, notification *Notification) error {
+	errorContext := "(ntfy) error while sending notification"
+
+	publisher, err := ntfy.GetNtfyPublisher()
+	if err != nil {
+		return util.AddErrorContext(errorContext, err)
+	}
+
+	topic := publisher.Topic
+	if notification.Topic != "" {
+		topic = notification.Topic
+	}
+
+	msg := &gotfy.Message{
+		Topic:    topic,
+		Title:    notification.Title,
+		Message:  notification.Message,
+		Tags:     notification.Tags,
+		Priority: gotfy.Priority(notification.Priority),
+	}
+	if notification.URL != "" {
+		chapterLink, err := url.Parse(notification.URL)
+		if err != nil {
+			return util.AddErrorContext(errorContext, err)
+		}
+		msg.Actions = []gotfy.ActionButton{
+			&gotfy.ViewAction{
+				Label: "Open Chapter",
+				Link:  chapterLink,
+				Clear: false,
+			},
+		}
+		msg.ClickURL = chapterLink
+	}
+
+	err = publisher.SendMessage(ctx, msg)
+	if err != nil {
+		return util.AddErrorContext(errorContext, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// Slack sends notifications to a Slack-compatible incoming webhook, like Slack or Mattermost.
+type Slack struct {
+	Configs *config.SlackConfigs
+	c       *http.Client
+}
+
+// GetName returns the notifier name.
+func (s *Slack) GetName() string {
+	return "slack"
+}
+
+// GetRetries returns the notifier retries.
+func (s *Slack) GetRetries() int {
+	return s.Configs.Retries
+}
+
+// Notify sends the notification to the Slack webhook.
+func (s *Slack) Notify(ctx context.Context, notification *Notification) error {
+	text := fmt.Sprintf("*%s*\n%s", notification.Title, notification.Message)
+	if notification.URL != "" {
+		text += fmt.Sprintf("\n<%s|Open Chapter>", notification.URL)
+	}
+	payload := map[string]string{
+		"text": text,
+	}
+
+	err := postJSON(ctx, s.c, s.Configs.WebhookURL, payload, nil)
+	if err != nil {
+		return util.AddErrorContext("(slack) error while sending notification", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// SMTP sends notifications as emails using an SMTP server.
+type SMTP struct {
+	Configs *config.SMTPConfigs
+}
+
+// GetName returns the notifier name.
+func (s *SMTP) GetName() string {
+	return "smtp"
+}
+
+// GetRetries returns the notifier retries.
+func (s *SMTP) GetRetries() int {
+	return s.Configs.Retries
+}
+
+// Notify sends the notification email to the configured recipients.
+func (s *SMTP) Notify(ctx context.Context, notification *Notification) error {
+	errorContext := "(smtp) error while sending notification"
+
+	var auth smtp.Auth
+	if s.Configs.Username != "" {
+		auth = smtp.PlainAuth("", s.Configs.Username, s.Configs.Password, s.Configs.Host)
+	}
+	addr := net.JoinHostPort(s.Configs.Host, strconv.Itoa(s.Configs.Port))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, s.Configs.From, s.Configs.To, s.getMessage(notification))
+	}()
+
+	select {
+	case <-ctx.Done():
+		return util.AddErrorContext(errorContext, ctx.Err())
+	case err := <-done:
+		if err != nil {
+			return util.AddErrorContext(errorContext, err)
+		}
+	}
+
+	return nil
+}
+
+// getMessage returns the email message in the RFC 5322 format.
+func (s *SMTP) getMessage(notification *Notification) []byte {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.Configs.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.Configs.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", encodeSubject(notification.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	fmt.Fprintf(&msg, "%s\r\n", strings.ReplaceAll(notification.Message, "\n", "\r\n"))
+	if notification.URL != "" {
+		fmt.Fprintf(&msg, "\r\nOpen chapter: %s\r\n", notification.URL)
+	}
+
+	return []byte(msg.String())
+}
+
+// encodeSubject removes the line breaks of the subject, so it can't add headers
+// to the message, and encodes it if it has non-ASCII characters, like manga names in Japanese.
+func encodeSubject(subject string) string {
+	subject = strings.NewReplacer("\r", "", "\n", " ").Replace(subject)
+	return mime.QEncoding.Encode("utf-8", subject)
+}
@@ -0,0 +1,249 @@
+package notifiers
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// Default notification templates, used when a template is empty.
+const (
+	DefaultTitleTemplate    = "(Mantium) New chapter of manga: {{ .Manga.Name }}"
+	DefaultMessageTemplate  = "New chapter: {{ .Chapter.Chapter }}"
+	DefaultTagsTemplate     = ""
+	DefaultPriorityTemplate = ""
+	DefaultClickURLTemplate = "{{ .Chapter.URL }}"
+)
+
+// priorityNames are the accepted priority names and their priority from 1 (min) to 5 (max).
+var priorityNames = map[string]int{
+	"min":     1,
+	"low":     2,
+	"default": 3,
+	"high":    4,
+	"max":     5,
+	"urgent":  5,
+}
+
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+}
+
+// TemplateData is the data available to the notification templates.
+// MultiManga is nil for custom mangas, so templates should use {{ with .MultiManga }} to access it.
+// The data is copied to plain structs, so the templates can't call the manga package methods,
+// like the ones that change the database.
+type TemplateData struct {
+	Manga      TemplateManga
+	Chapter    TemplateChapter
+	MultiManga *TemplateMultiManga
+	// Source is the manga source, like "mangadex".
+	Source string
+}
+
+// TemplateManga is the manga data available to the notification templates.
+type TemplateManga struct {
+	Name   string
+	URL    string
+	Source string
+	ID     int
+	Status int
+}
+
+// TemplateChapter is the chapter data available to the notification templates.
+type TemplateChapter struct {
+	// Chapter is the chapter number, like "1001".
+	Chapter   string
+	Name      string
+	URL       string
+	Volume    string
+	UpdatedAt time.Time
+}
+
+// TemplateMultiManga is the multimanga data available to the notification templates.
+type TemplateMultiManga struct {
+	ID     int
+	Status int
+}
+
+// NewTemplateData returns the templates data of a manga's last released chapter.
+func NewTemplateData(m *manga.Manga, mm *manga.MultiManga) *TemplateData {
+	data := &TemplateData{
+		Manga: TemplateManga{
+			Name:   m.Name,
+			URL:    m.URL,
+			Source: m.Source,
+			ID:     int(m.ID),
+			Status: int(m.Status),
+		},
+		Source: m.Source,
+	}
+	if chapter := m.LastReleasedChapter; chapter != nil {
+		data.Chapter = TemplateChapter{
+			Chapter:   chapter.Chapter,
+			Name:      chapter.Name,
+			URL:       chapter.URL,
+			Volume:    chapter.Volume,
+			UpdatedAt: chapter.UpdatedAt,
+		}
+	}
+	if mm != nil {
+		data.MultiManga = &TemplateMultiManga{
+			ID:     int(mm.ID),
+			Status: int(mm.Status),
+		}
+	}
+
+	return data
+}
+
+// NewChapterNotification returns the notification about a manga's new chapter built
+// with the configured notification templates. If the templates can't be rendered,
+// the notification is built with the default templates and the error is returned too.
+func NewChapterNotification(data *TemplateData) (*Notification, error) {
+	notification, err := RenderNotificationTemplates(config.GlobalConfigs.DashboardConfigs.Notifications, data)
+	if err != nil {
+		defaultNotification, defaultErr := RenderNotificationTemplates(config.NotificationTemplatesConfigs{}, data)
+		if defaultErr != nil {
+			return nil, defaultErr
+		}
+		return defaultNotification, err
+	}
+
+	return notification, nil
+}
+
+// RenderNotificationTemplates renders the templates using the data and returns the notification.
+func RenderNotificationTemplates(templates config.NotificationTemplatesConfigs, data *TemplateData) (*Notification, error) {
+	errorContext := "error while rendering notification %s template"
+
+	title, err := renderTemplate("title", templates.Title, DefaultTitleTemplate, data)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, "title"), err)
+	}
+	message, err := renderTemplate("message", templates.Message, DefaultMessageTemplate, data)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, "message"), err)
+	}
+	tagsStr, err := renderTemplate("tags", templates.Tags, DefaultTagsTemplate, data)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, "tags"), err)
+	}
+	priorityStr, err := renderTemplate("priority", templates.Priority, DefaultPriorityTemplate, data)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, "priority"), err)
+	}
+	priority, err := parsePriority(priorityStr)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, "priority"), err)
+	}
+	clickURL, err := renderTemplate("click URL", templates.ClickURL, DefaultClickURLTemplate, data)
+	if err != nil {
+		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, "click URL"), err)
+	}
+	clickURL = strings.TrimSpace(clickURL)
+	if clickURL != "" {
+		if _, err := url.ParseRequestURI(clickURL); err != nil {
+			return nil, util.AddErrorContext(fmt.Sprintf(errorContext, "click URL"), err)
+		}
+	}
+
+	tags := []string{}
+	for _, tag := range strings.Split(tagsStr, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return &Notification{
+		Title:     title,
+		Message:   message,
+		MangaName: data.Manga.Name,
+		Chapter:   data.Chapter.Chapter,
+		URL:       clickURL,
+		Tags:      tags,
+		Priority:  priority,
+	}, nil
+}
+
+// ValidateNotificationTemplates returns an error if any of the templates can't be
+// parsed or can't be rendered with the data of an example manga.
+func ValidateNotificationTemplates(templates config.NotificationTemplatesConfigs) error {
+	chapter := &manga.Chapter{
+		Chapter:   "1001",
+		Name:      "Chapter 1001",
+		URL:       "https://mangadex.org/chapter/example",
+		UpdatedAt: time.Now(),
+		Type:      1,
+	}
+	m := &manga.Manga{
+		ID:                  1,
+		Source:              "mangadex",
+		URL:                 "https://mangadex.org/title/example",
+		Name:                "Example Manga",
+		Status:              1,
+		MultiMangaID:        1,
+		LastReleasedChapter: chapter,
+	}
+	mm := &manga.MultiManga{
+		ID:           1,
+		Status:       1,
+		CurrentManga: m,
+		Mangas:       []*manga.Manga{m},
+	}
+
+	_, err := RenderNotificationTemplates(templates, NewTemplateData(m, mm))
+	if err != nil {
+		return util.AddErrorContext("invalid notification templates", err)
+	}
+
+	return nil
+}
+
+func renderTemplate(name, text, defaultText string, data *TemplateData) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		text = defaultText
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// parsePriority returns the priority from 1 (min) to 5 (max) of a priority number or name.
+// An empty priority returns 0, which means the notifier's default priority.
+func parsePriority(priority string) (int, error) {
+	priority = strings.ToLower(strings.TrimSpace(priority))
+	if priority == "" {
+		return 0, nil
+	}
+	if p, ok := priorityNames[priority]; ok {
+		return p, nil
+	}
+
+	p, err := strconv.Atoi(priority)
+	if err != nil || p < 1 || p > 5 {
+		return 0, fmt.Errorf("priority should be a number from 1 to 5 or one of min, low, default, high, max, urgent, instead it's '%s'", priority)
+	}
+
+	return p, nil
+}
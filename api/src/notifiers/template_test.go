@@ -0,0 +1,97 @@
+package notifiers
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/manga"
+)
+
+var templateManga = &manga.Manga{
+	Source: "mangadex",
+	Name:   "One Piece",
+	LastReleasedChapter: &manga.Chapter{
+		Chapter: "1001",
+		URL:     "https://mangadex.org/chapter/one-piece-1001",
+	},
+}
+
+func TestRenderNotificationTemplates(t *testing.T) {
+	t.Run("Render the default templates", func(t *testing.T) {
+		notification, err := RenderNotificationTemplates(config.NotificationTemplatesConfigs{}, NewTemplateData(templateManga, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if notification.Title != "(Mantium) New chapter of manga: One Piece" {
+			t.Fatalf("unexpected title: %s", notification.Title)
+		}
+		if notification.Message != "New chapter: 1001" {
+			t.Fatalf("unexpected message: %s", notification.Message)
+		}
+		if notification.URL != templateManga.LastReleasedChapter.URL {
+			t.Fatalf("unexpected URL: %s", notification.URL)
+		}
+		if len(notification.Tags) != 0 || notification.Priority != 0 {
+			t.Fatalf("expected no tags and priority, got %v and %d", notification.Tags, notification.Priority)
+		}
+	})
+	t.Run("Render custom templates", func(t *testing.T) {
+		templates := config.NotificationTemplatesConfigs{
+			Title:    "Novo capítulo: {{ upper .Manga.Name }}",
+			Message:  "Capítulo {{ .Chapter.Chapter }} ({{ .Source }}){{ with .MultiManga }} #{{ .ID }}{{ end }}",
+			Tags:     "books, {{ .Source }}",
+			Priority: "{{ if eq .Source \"mangadex\" }}high{{ else }}3{{ end }}",
+			ClickURL: "{{ .Manga.URL }}",
+		}
+		mm := &manga.MultiManga{ID: 7}
+		m := *templateManga
+		m.URL = "https://mangadex.org/title/one-piece"
+
+		notification, err := RenderNotificationTemplates(templates, NewTemplateData(&m, mm))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if notification.Title != "Novo capítulo: ONE PIECE" {
+			t.Fatalf("unexpected title: %s", notification.Title)
+		}
+		if notification.Message != "Capítulo 1001 (mangadex) #7" {
+			t.Fatalf("unexpected message: %s", notification.Message)
+		}
+		if !slices.Equal(notification.Tags, []string{"books", "mangadex"}) {
+			t.Fatalf("unexpected tags: %v", notification.Tags)
+		}
+		if notification.Priority != 4 {
+			t.Fatalf("expected priority 4, got %d", notification.Priority)
+		}
+		if notification.URL != m.URL {
+			t.Fatalf("unexpected URL: %s", notification.URL)
+		}
+	})
+}
+
+func TestValidateNotificationTemplates(t *testing.T) {
+	invalidTemplates := map[string]config.NotificationTemplatesConfigs{
+		"syntax error":  {Title: "{{ .Manga.Name "},
+		"unknown field": {Message: "{{ .Manga.Unknown }}"},
+		"DB method":     {Message: "{{ .MultiManga.DeleteFromDB }}"},
+		"priority":      {Priority: "9"},
+		"click URL":     {ClickURL: "not a url"},
+	}
+	for name, templates := range invalidTemplates {
+		t.Run("Invalid "+name, func(t *testing.T) {
+			if err := ValidateNotificationTemplates(templates); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+	t.Run("Valid templates", func(t *testing.T) {
+		templates := config.NotificationTemplatesConfigs{
+			Title:    "{{ .Manga.Name }} - {{ .MultiManga.ID }}",
+			Priority: "urgent",
+		}
+		if err := ValidateNotificationTemplates(templates); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
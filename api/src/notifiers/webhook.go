@@ -0,0 +1,55 @@
+package notifiers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// Webhook sends notifications as a JSON POST request to a generic webhook URL.
+type Webhook struct {
+	Configs *config.WebhookConfigs
+	c       *http.Client
+}
+
+// WebhookPayload is the JSON body sent by the Webhook notifier.
+type WebhookPayload struct {
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	MangaName string `json:"manga_name"`
+	Chapter   string `json:"chapter"`
+	URL       string `json:"url"`
+	// Chapters are the chapters of a digest notification.
+	Chapters []*NotificationChapter `json:"chapters,omitempty"`
+}
+
+// GetName returns the notifier name.
+func (w *Webhook) GetName() string {
+	return "webhook"
+}
+
+// GetRetries returns the notifier retries.
+func (w *Webhook) GetRetries() int {
+	return w.Configs.Retries
+}
+
+// Notify sends the notification to the webhook URL.
+func (w *Webhook) Notify(ctx context.Context, notification *Notification) error {
+	payload := WebhookPayload{
+		Title:     notification.Title,
+		Message:   notification.Message,
+		MangaName: notification.MangaName,
+		Chapter:   notification.Chapter,
+		URL:       notification.URL,
+		Chapters:  notification.Chapters,
+	}
+
+	err := postJSON(ctx, w.c, w.Configs.URL, payload, nil)
+	if err != nil {
+		return util.AddErrorContext("(webhook) error while sending notification", err)
+	}
+
+	return nil
+}
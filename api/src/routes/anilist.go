@@ -0,0 +1,246 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/diogovalentte/mantium/api/src/auth"
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/dashboard"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/integrations/anilist"
+	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// AniListRoutes sets the routes for the AniList tracker integration.
+func AniListRoutes(group *gin.RouterGroup) {
+	{
+		group.GET("/multimanga/anilist", GetMultiMangaAniListLink)
+		group.PUT("/multimanga/anilist", LinkMultiMangaToAniList)
+		group.DELETE("/multimanga/anilist", UnlinkMultiMangaFromAniList)
+		group.POST("/anilist/pull", PullAniListProgress)
+	}
+}
+
+// @Summary Get multimanga AniList link
+// @Description Returns the AniList media linked to the multimanga.
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Success 200 {object} manga.AniListLink "{"link": linkObj}"
+// @Router /multimanga/anilist [get]
+func GetMultiMangaAniListLink(c *gin.Context) {
+	multimangaID, ok := getAniListRequestMultiMangaID(c)
+	if !ok {
+		return
+	}
+
+	link, err := manga.GetAniListLinkFromDB(multimangaID)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrAniListLinkNotFoundDB.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"link": link})
+}
+
+// @Summary Link multimanga to AniList
+// @Description Links the multimanga to an AniList media and pushes the multimanga's status and last read chapter to the AniList list entry. After that, the status and last read chapter are pushed every time they're updated. The AniList progress is never decreased: if AniList has a higher chapter, it's kept.
+// @Accept json
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Param link body LinkMultiMangaToAniListRequest true "AniList media"
+// @Success 200 {object} responseMessage
+// @Router /multimanga/anilist [put]
+func LinkMultiMangaToAniList(c *gin.Context) {
+	multimangaID, ok := getAniListRequestMultiMangaID(c)
+	if !ok {
+		return
+	}
+
+	var requestData LinkMultiMangaToAniListRequest
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
+		return
+	}
+	if requestData.MediaID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "mediaID must be > 0"})
+		return
+	}
+
+	multimanga, err := manga.GetMultiMangaFromDB(multimangaID)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	link := &manga.AniListLink{MultiMangaID: multimangaID, MediaID: requestData.MediaID}
+	err = link.UpsertIntoDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	a := anilist.AniList{}
+	a.Init()
+	err = a.PushMultiManga(multimanga, link.MediaID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": util.AddErrorContext("multimanga linked to AniList, but error while pushing it to AniList", err).Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Multimanga linked to AniList successfully"})
+}
+
+// LinkMultiMangaToAniListRequest is the request body for the LinkMultiMangaToAniList route.
+type LinkMultiMangaToAniListRequest struct {
+	MediaID int `json:"mediaID" binding:"required"`
+}
+
+// @Summary Unlink multimanga from AniList
+// @Description Unlinks the multimanga from its AniList media. The AniList list entry is not changed.
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Success 200 {object} responseMessage
+// @Router /multimanga/anilist [delete]
+func UnlinkMultiMangaFromAniList(c *gin.Context) {
+	multimangaID, ok := getAniListRequestMultiMangaID(c)
+	if !ok {
+		return
+	}
+
+	err := manga.DeleteAniListLinkFromDB(multimangaID)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrAniListLinkNotFoundDB.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Multimanga unlinked from AniList successfully"})
+}
+
+// @Summary Pull AniList progress
+// @Description Pulls the progress of the AniList list entries of all linked multimangas. A multimanga's last read chapter is updated only if the AniList progress is higher. The multimangas' statuses are not changed.
+// @Produce json
+// @Success 200 {object} responseMessage
+// @Router /anilist/pull [post]
+func PullAniListProgress(c *gin.Context) {
+	if !isAniListRequestAllowed(c) {
+		return
+	}
+
+	updated, err := RunPullAniListProgress()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("AniList progress pulled successfully, %d multimangas updated", updated)})
+}
+
+// RunPullAniListProgress pulls the progress of the AniList list entries of all linked
+// multimangas and updates the dashboard if a multimanga was updated.
+// Returns the number of multimangas updated.
+func RunPullAniListProgress() (int, error) {
+	a := anilist.AniList{}
+	a.Init()
+	updated, errs := a.PullLinkedMultiMangas()
+	if updated > 0 {
+		dashboard.UpdateDashboard()
+	}
+	if len(errs) > 0 {
+		errMessages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			errMessages = append(errMessages, err.Error())
+		}
+		return updated, fmt.Errorf("%d multimangas updated, but some errors occurred while pulling the progress from AniList: %s", updated, strings.Join(errMessages, "; "))
+	}
+
+	return updated, nil
+}
+
+// pushMultiMangaToAniList pushes the multimanga's status and last read chapter to its
+// linked AniList media. It does nothing if the AniList integration is disabled,
+// the request is made by a user, as the integration only syncs the global library,
+// or the multimanga isn't linked.
+// The push is best-effort, as the multimanga is already updated in the DB, so errors
+// are logged and set as the last background error instead of failing the request.
+func pushMultiMangaToAniList(c *gin.Context, mm *manga.MultiManga) {
+	if !config.GlobalConfigs.AniList.Valid || auth.GetUserID(c) > 0 {
+		return
+	}
+
+	link, err := manga.GetAniListLinkFromDB(mm.ID)
+	if err != nil {
+		if !strings.Contains(err.Error(), errordefs.ErrAniListLinkNotFoundDB.Error()) {
+			reportPushError(c, "AniList", mm, err)
+		}
+		return
+	}
+
+	a := anilist.AniList{}
+	a.Init()
+	err = a.PushMultiManga(mm, link.MediaID)
+	if err != nil {
+		reportPushError(c, "AniList", mm, err)
+	}
+}
+
+// reportPushError logs the error of pushing a multimanga to a tracker
+// and sets it as the last background error.
+func reportPushError(c *gin.Context, tracker string, mm *manga.MultiManga, err error) {
+	zerolog.Ctx(c.Request.Context()).Error().Err(err).Int("multimanga_id", int(mm.ID)).Msgf("error while pushing multimanga to %s", tracker)
+	dashboard.SetLastBackgroundError(util.AddErrorContext(fmt.Sprintf("error while pushing multimanga with ID '%d' to %s", mm.ID, tracker), err).Error())
+}
+
+// isAniListRequestAllowed returns true if the AniList integration is enabled and the request
+// is not made by a user. Otherwise, it sets the error response and returns false.
+func isAniListRequestAllowed(c *gin.Context) bool {
+	if !config.GlobalConfigs.AniList.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "the AniList integration is not enabled"})
+		return false
+	}
+	if auth.GetUserID(c) > 0 {
+		c.JSON(http.StatusForbidden, gin.H{"message": "the AniList integration only syncs the global library"})
+		return false
+	}
+
+	return true
+}
+
+// getAniListRequestMultiMangaID returns the multimanga ID of the AniList request.
+// If the request is not allowed or the ID is invalid, it sets the error response and returns false.
+func getAniListRequestMultiMangaID(c *gin.Context) (manga.ID, bool) {
+	if !isAniListRequestAllowed(c) {
+		return 0, false
+	}
+
+	multimangaIDStr := c.Query("id")
+	if multimangaIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return 0, false
+	}
+	multimangaID, err := strconv.Atoi(multimangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return 0, false
+	}
+
+	return manga.ID(multimangaID), true
+}
@@ -0,0 +1,206 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/diogovalentte/mantium/api/src/auth"
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+)
+
+// AuthRoutes sets the routes for the API authentication.
+// All routes require the admin scope when the authentication is enabled.
+func AuthRoutes(group *gin.RouterGroup) {
+	{
+		group.GET("/auth/status", GetAuthStatus)
+		group.GET("/auth/tokens", GetAPITokens)
+		group.POST("/auth/tokens", CreateAPIToken)
+		group.DELETE("/auth/tokens", RevokeAPIToken)
+		group.GET("/auth/users", GetUsers)
+		group.POST("/auth/users", CreateUser)
+		group.DELETE("/auth/users", DeleteUser)
+	}
+}
+
+// @Summary Get authentication status
+// @Description Returns if the API authentication is enabled. The authentication is enabled when the admin password is set.
+// @Success 200 {object} map[string]bool "{"enabled": true}"
+// @Produce json
+// @Router /auth/status [get]
+func GetAuthStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"enabled": config.GlobalConfigs.Auth.Enabled})
+}
+
+// @Summary Get API tokens
+// @Description Returns all API tokens, including the revoked ones. The token values are not returned.
+// @Success 200 {array} auth.Token "{"tokens": [tokenObj]}"
+// @Produce json
+// @Router /auth/tokens [get]
+func GetAPITokens(c *gin.Context) {
+	tokens, err := auth.GetTokensFromDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// @Summary Create API token
+// @Description Creates an API token. The token value is returned only in this response. Scopes: "iframe" (only the mangas iFrame), "read" (read-only routes), "write" (all routes except the admin ones), and "admin" (all routes). If userID is provided, the token is owned by the user, accesses the user's library, and its scope can't be higher than the user's scope.
+// @Accept json
+// @Produce json
+// @Param token body CreateAPITokenRequest true "API token"
+// @Success 200 {object} map[string]any "{"message": "API token created successfully", "token": "mantium_...", "tokenInfo": tokenObj}"
+// @Router /auth/tokens [post]
+func CreateAPIToken(c *gin.Context) {
+	var requestData CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
+		return
+	}
+	if !auth.ValidateScope(requestData.Scope) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("scope must be one of %v", auth.Scopes)})
+		return
+	}
+
+	value, token, err := auth.CreateTokenInDB(requestData.Name, requestData.Scope, requestData.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrUserNotFoundDB.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API token created successfully", "token": value, "tokenInfo": token})
+}
+
+// CreateAPITokenRequest is the request body for the CreateAPIToken route.
+type CreateAPITokenRequest struct {
+	Name   string     `json:"name" binding:"required,max=255"`
+	Scope  auth.Scope `json:"scope" binding:"required"`
+	UserID int        `json:"userID" binding:"omitempty,gte=0"`
+}
+
+// @Summary Revoke API token
+// @Description Revokes an API token. Revoked tokens can't be used anymore.
+// @Success 200 {object} responseMessage
+// @Produce json
+// @Param id query int true "API token ID" Example(1)
+// @Router /auth/tokens [delete]
+func RevokeAPIToken(c *gin.Context) {
+	idStr := c.Query("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return
+	}
+
+	err = auth.RevokeTokenInDB(id)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrAPITokenNotFoundDB.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API token revoked successfully"})
+}
+
+// @Summary Get users
+// @Description Returns all users. The users' passwords are not returned.
+// @Success 200 {array} auth.User "{"users": [userObj]}"
+// @Produce json
+// @Router /auth/users [get]
+func GetUsers(c *gin.Context) {
+	users, err := auth.GetUsersFromDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// @Summary Create user
+// @Description Creates a user. Each user has its own library, with its own multimangas status and last read chapter, and its own dashboard configs. Users authenticate using basic auth with their username and password, or with API tokens created for them. Scopes: "iframe", "read", and "write".
+// @Accept json
+// @Produce json
+// @Param user body CreateUserRequest true "User"
+// @Success 200 {object} map[string]any "{"message": "User created successfully", "user": userObj}"
+// @Router /auth/users [post]
+func CreateUser(c *gin.Context) {
+	var requestData CreateUserRequest
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
+		return
+	}
+	if !slices.Contains(auth.UserScopes, requestData.Scope) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("scope must be one of %v", auth.UserScopes)})
+		return
+	}
+
+	user, err := auth.CreateUserInDB(requestData.Username, requestData.Password, requestData.Scope)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrUserAlreadyInDB.Error()) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User created successfully", "user": user})
+}
+
+// CreateUserRequest is the request body for the CreateUser route.
+type CreateUserRequest struct {
+	Username string     `json:"username" binding:"required,max=50"`
+	Password string     `json:"password" binding:"required"`
+	Scope    auth.Scope `json:"scope" binding:"required"`
+}
+
+// @Summary Delete user
+// @Description Deletes a user with its library, dashboard configs, and API tokens. The multimangas are not deleted, as they're shared by all users.
+// @Success 200 {object} responseMessage
+// @Produce json
+// @Param id query int true "User ID" Example(1)
+// @Router /auth/users [delete]
+func DeleteUser(c *gin.Context) {
+	idStr := c.Query("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return
+	}
+
+	err = auth.DeleteUserFromDB(id)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrUserNotFoundDB.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
+}
@@ -3,12 +3,13 @@ package routes
 import (
 	"fmt"
 	"net/http"
-	"slices"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/diogovalentte/mantium/api/src/auth"
 	"github.com/diogovalentte/mantium/api/src/config"
 	"github.com/diogovalentte/mantium/api/src/dashboard"
+	"github.com/diogovalentte/mantium/api/src/notifiers"
 )
 
 // DashboardRoutes sets the routes for the dashboard.
@@ -24,16 +25,29 @@ func DashboardRoutes(group *gin.RouterGroup) {
 }
 
 // @Summary Get the dashboard configs
-// @Description Returns the dashboard configs
+// @Description Returns the dashboard configs. If the request is made by a user, the display configs are the user's display configs. The proxies are returned only if the request has the admin scope.
 // @Success 200 {object} config.DashboardConfigs
 // @Produce json
 // @Router /dashboard/configs [get]
 func GetDashboardConfigs(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"configs": config.GlobalConfigs.DashboardConfigs})
+	configs := *config.GlobalConfigs.DashboardConfigs
+	// The proxies can have credentials and only the admin can set them
+	if !auth.GetScope(c).Allows(auth.ScopeAdmin) {
+		configs.Proxies = config.ProxiesConfigs{}
+	}
+	if userID := auth.GetUserID(c); userID > 0 {
+		err := config.LoadUserConfigsFromDB(userID, &configs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"configs": configs})
 }
 
 // @Summary Update dashboard configs
-// @Description Update the dashboard configs in the DB. Cannot update version.
+// @Description Update the dashboard configs in the DB. Cannot update version. If the request is made by a user, only the user's display configs are updated. Else, the request requires the admin scope when the authentication is enabled.
 // @Success 200 {object} responseMessage
 // @Accept json
 // @Produce json
@@ -47,18 +61,38 @@ func UpdateDashboardConfigs(c *gin.Context) {
 		return
 	}
 
-	if newConfigs.Display.Columns < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "columns must be greater than 0"})
+	err = config.ValidateDisplayConfigs(&newConfigs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	if newConfigs.Display.SearchResultsLimit < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "searchResultsLimit must be greater than 0"})
+	userID := auth.GetUserID(c)
+	if userID > 0 {
+		err = config.SaveUserConfigsToDB(userID, &newConfigs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": fmt.Sprintf("error while saving configs: %s", err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Configs updated successfully"})
 		return
 	}
 
-	if !slices.Contains(config.ValidDisplayModeValues, newConfigs.Display.DisplayMode) {
-		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("displayMode must be one of the following values: %v", config.ValidDisplayModeValues)})
+	if !auth.GetScope(c).Allows(auth.ScopeAdmin) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "only the admin can update the global configs"})
+		return
+	}
+
+	err = notifiers.ValidateNotificationTemplates(newConfigs.Notifications)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	err = config.ValidateProxiesConfigs(&newConfigs.Proxies)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/jobs"
+)
+
+// JobsRoutes sets the routes for the asynchronous jobs.
+func JobsRoutes(group *gin.RouterGroup) {
+	{
+		group.GET("/jobs", GetJobs)
+		group.GET("/jobs/:id", GetJob)
+		group.DELETE("/jobs/:id", CancelJob)
+	}
+}
+
+// @Summary Get jobs
+// @Description Returns the running jobs and the last finished jobs, sorted by start time, desc. Jobs are kept only in memory.
+// @Success 200 {array} jobs.Job "{"jobs": [jobObj]}"
+// @Produce json
+// @Router /jobs [get]
+func GetJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs.GetJobs()})
+}
+
+// @Summary Get job
+// @Description Returns a job with its status, progress (processed items), and errors.
+// @Success 200 {object} jobs.Job "{"job": jobObj}"
+// @Produce json
+// @Param id path string true "Job ID"
+// @Router /jobs/{id} [get]
+func GetJob(c *gin.Context) {
+	job, err := jobs.GetJob(c.Param("id"))
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrJobNotFound.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+// @Summary Cancel job
+// @Description Cancels a running job. The job stops after finishing the items it's currently processing.
+// @Success 200 {object} responseMessage
+// @Produce json
+// @Param id path string true "Job ID"
+// @Router /jobs/{id} [delete]
+func CancelJob(c *gin.Context) {
+	err := jobs.CancelJob(c.Param("id"))
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrJobNotFound.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), errordefs.ErrJobNotRunning.Error()) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job canceled successfully"})
+}
@@ -0,0 +1,252 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/diogovalentte/mantium/api/src/auth"
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/dashboard"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/jobs"
+	"github.com/diogovalentte/mantium/api/src/library"
+)
+
+// LibraryRoutes sets the routes for the library export and import.
+func LibraryRoutes(group *gin.RouterGroup) {
+	{
+		group.GET("/library/export", ExportLibrary)
+		group.POST("/library/import", ImportLibrary)
+		group.POST("/library/import/list", ImportTrackerList)
+	}
+}
+
+// @Summary Export library
+// @Description Exports the library as a versioned JSON document with the multimangas, their mangas, custom manga selectors, last read chapters, statuses, fixed cover images, and the dashboard configs. The document can be imported using the import library route. If the request is made by a user, exports the user's library and configs. The proxies are exported only if the request has the admin scope.
+// @Produce json
+// @Param include_cover_imgs query bool false "Include the cover images of all mangas. By default, only the cover images of custom mangas and fixed multimanga cover images are included, as the other mangas get their cover images from the sources." Example(false)
+// @Success 200 {object} library.Document
+// @Router /library/export [get]
+func ExportLibrary(c *gin.Context) {
+	includeCoverImgs := false
+	includeCoverImgsStr := c.Query("include_cover_imgs")
+	if includeCoverImgsStr != "" {
+		var err error
+		includeCoverImgs, err = strconv.ParseBool(includeCoverImgsStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "include_cover_imgs must be a boolean"})
+			return
+		}
+	}
+
+	multimangas, err := getRequestMultiMangasDB(c, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	configs := *config.GlobalConfigs.DashboardConfigs
+	// The proxies can have credentials and only the admin can set them
+	if !auth.GetScope(c).Allows(auth.ScopeAdmin) {
+		configs.Proxies = config.ProxiesConfigs{}
+	}
+	if userID := auth.GetUserID(c); userID > 0 {
+		err = config.LoadUserConfigsFromDB(userID, &configs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	doc := library.Export(multimangas, &configs, includeCoverImgs)
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=mantium-library-%s.json", doc.ExportedAt.Format(time.DateOnly)))
+	c.JSON(http.StatusOK, doc)
+}
+
+// @Summary Import library
+// @Description Imports a library document created by the export library route into the global library. In the "merge" mode, adds only the multimangas that are not in the library (a multimanga is in the library if one of its mangas is in the library). In the "replace" mode, deletes all multimangas, including their notification rules and users' library entries, before adding the document's multimangas. The dashboard configs are also imported if they're in the document. The multimangas are imported in a single transaction.
+// @Accept json
+// @Produce json
+// @Param mode query string false "Import mode: merge (default) or replace." Example(merge)
+// @Param dry_run query bool false "If true, validates the document and returns what would be imported, without changing the library." Example(true)
+// @Param document body library.Document true "Library document"
+// @Success 200 {object} library.ImportReport "{"message": "Library imported successfully", "report": reportObj}"
+// @Router /library/import [post]
+func ImportLibrary(c *gin.Context) {
+	mode := library.ImportModeMerge
+	if modeStr := c.Query("mode"); modeStr != "" {
+		mode = library.ImportMode(modeStr)
+	}
+	if !slices.Contains(library.ImportModes, mode) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("mode must be one of %v", library.ImportModes)})
+		return
+	}
+
+	dryRun := false
+	dryRunStr := c.Query("dry_run")
+	if dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "dry_run must be a boolean"})
+			return
+		}
+	}
+
+	var doc library.Document
+	if err := c.ShouldBindJSON(&doc); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("invalid library document: %s", err.Error())})
+		return
+	}
+
+	report, err := library.Import(&doc, mode, dryRun)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrInvalidLibraryDocument.Error()) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, gin.H{"message": "Library document is valid, nothing was imported", "report": report})
+		return
+	}
+
+	dashboard.UpdateDashboard()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Library imported successfully", "report": report})
+}
+
+// @Summary Import tracker list
+// @Description Imports a manga list exported from MyAnimeList (XML export), AniList (GraphQL MediaListCollection JSON response), or Kitsu (JSON:API library entries response with the manga included). Each entry is searched in the sources, and the manga with the most similar name is added with the entry's status and last read chapter if its confidence is at least the minimum confidence. Entries without a match with the minimum confidence, or that couldn't be added, are returned in the review list with their best candidates, so they can be added manually. Entries whose match is already in the library are skipped. If the request is made by a user, imports into the user's library. The import runs in the background as a job, and the request returns the job ID right away. Use the /jobs/{id} route to follow the job's progress and to cancel it. The import report is the job's result.
+// @Accept plain
+// @Produce json
+// @Param format query string true "List format: mal, anilist, or kitsu." Example(mal)
+// @Param sources query string false "Comma-separated names of the sources where the entries are searched, in order of preference. Defaults to the allowed sources." Example(mangadex,mangaupdates)
+// @Param min_confidence query number false "Minimum confidence, from 0 to 1, of a match for the entry to be imported. Defaults to 0.8." Example(0.8)
+// @Param dry_run query bool false "If true, searches the entries and returns what would be imported, without changing the library." Example(true)
+// @Param wait query bool false "If true, waits for the job to finish and returns the import report." Example(true)
+// @Param list body string true "Exported list file content"
+// @Success 202 {object} map[string]string "{"message": "...", "job_id": "..."}"
+// @Success 200 {object} library.ListImportReport "{"message": "List imported successfully", "report": reportObj, "job_id": "..."}"
+// @Router /library/import/list [post]
+func ImportTrackerList(c *gin.Context) {
+	format := library.ListFormat(c.Query("format"))
+	if !slices.Contains(library.ListFormats, format) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("format must be one of %v", library.ListFormats)})
+		return
+	}
+
+	allowedSources := config.GlobalConfigs.DashboardConfigs.Manga.AllowedSources
+	searchSources := allowedSources
+	if sourcesStr := c.Query("sources"); sourcesStr != "" {
+		searchSources = strings.Split(sourcesStr, ",")
+		for _, source := range searchSources {
+			if !slices.Contains(allowedSources, source) {
+				c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("source %s is not allowed", source)})
+				return
+			}
+		}
+	}
+
+	minConfidence := library.DefaultMinMatchConfidence
+	if minConfidenceStr := c.Query("min_confidence"); minConfidenceStr != "" {
+		var err error
+		minConfidence, err = strconv.ParseFloat(minConfidenceStr, 64)
+		if err != nil || minConfidence < 0 || minConfidence > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "min_confidence must be a number between 0 and 1"})
+			return
+		}
+	}
+
+	dryRun := false
+	dryRunStr := c.Query("dry_run")
+	if dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "dry_run must be a boolean"})
+			return
+		}
+	}
+
+	data, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("error reading list: %s", err.Error())})
+		return
+	}
+
+	entries, err := library.ParseList(data, format)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrInvalidTrackerList.Error()) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	wait := c.Query("wait") == "true"
+
+	options := library.ListImportOptions{
+		Sources:       searchSources,
+		MinConfidence: minConfidence,
+		UserID:        auth.GetUserID(c),
+		DryRun:        dryRun,
+	}
+	job, ctx := jobs.NewJob(context.Background(), ImportTrackerListJobType)
+	if !wait {
+		go RunImportTrackerListJob(ctx, job, entries, format, options)
+		c.JSON(http.StatusAccepted, gin.H{"message": "List import started", "job_id": job.ID})
+		return
+	}
+
+	report, err := RunImportTrackerListJob(ctx, job, entries, format, options)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error(), "job_id": job.ID})
+		return
+	}
+
+	if dryRun {
+		c.JSON(http.StatusOK, gin.H{"message": "List entries searched, nothing was imported", "report": report, "job_id": job.ID})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "List imported successfully", "report": report, "job_id": job.ID})
+}
+
+// ImportTrackerListJobType is the type of the jobs that import tracker lists.
+const ImportTrackerListJobType = "import_tracker_list"
+
+// RunImportTrackerListJob imports the list entries using library.ImportList,
+// reporting the progress to the job and finishing it with the import report as its result.
+func RunImportTrackerListJob(ctx context.Context, job *jobs.Job, entries []*library.ListEntry, format library.ListFormat, options library.ListImportOptions) (*library.ListImportReport, error) {
+	report, err := library.ImportList(ctx, job, entries, format, options)
+
+	message := "List imported successfully"
+	if options.DryRun {
+		message = "List entries searched, nothing was imported"
+	}
+	if err == nil {
+		job.SetResult(report)
+		if !options.DryRun && len(report.Added) > 0 {
+			dashboard.UpdateDashboard()
+		}
+	}
+	if ctx.Err() != nil {
+		message = "List import canceled"
+	}
+	job.Finish(ctx, nil, err, message)
+
+	return report, err
+}
@@ -17,19 +17,20 @@ import (
 	"sync"
 	"time"
 
-	"github.com/AnthonyHewins/gotfy"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
+	"github.com/diogovalentte/mantium/api/src/auth"
 	"github.com/diogovalentte/mantium/api/src/config"
 	"github.com/diogovalentte/mantium/api/src/dashboard"
 	"github.com/diogovalentte/mantium/api/src/errordefs"
 	"github.com/diogovalentte/mantium/api/src/integrations/kaizoku"
-	"github.com/diogovalentte/mantium/api/src/integrations/ntfy"
 	"github.com/diogovalentte/mantium/api/src/integrations/suwayomi"
 	"github.com/diogovalentte/mantium/api/src/integrations/tranga"
+	"github.com/diogovalentte/mantium/api/src/jobs"
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/notifiers"
 	"github.com/diogovalentte/mantium/api/src/sources"
 	"github.com/diogovalentte/mantium/api/src/sources/models"
 	"github.com/diogovalentte/mantium/api/src/util"
@@ -42,12 +43,15 @@ func MangaRoutes(group *gin.RouterGroup) {
 		group.GET("/mangas", GetMangas)
 		group.GET("/manga/metadata", GetMangaMetadata)
 		group.GET("/manga/chapters", GetMangaChapters)
+		group.PATCH("/manga/preferred_group", UpdateMangaPreferredGroup)
+		group.PATCH("/manga/preferred_language", UpdateMangaPreferredLanguage)
 
 		// Methods for custom manga only
 		group.PATCH("/custom_manga/last_released_chapter_selectors", UpdateCustomMangaLastReleasedChapterSelectors)
 		group.PATCH("/custom_manga/name", UpdateCustomMangaName)
 		group.PATCH("/custom_manga/url", UpdateCustomMangaURL)
 		group.PATCH("/custom_manga/cover_img", UpdateCustomMangaCoverImg)
+		group.PATCH("/custom_manga/metadata", UpdateCustomMangaMetadata)
 
 		// Methods for multimanga only
 		group.POST("/multimanga", AddMultiManga)
@@ -56,7 +60,12 @@ func MangaRoutes(group *gin.RouterGroup) {
 		group.GET("/multimangas", GetMultiMangas)
 		group.GET("/multimanga/choose_current_manga", ChooseCurrentManga)
 		group.GET("/multimanga/chapters", GetMultiMangaChapters)
+		group.GET("/multimanga/chapters/history", GetMultiMangaChaptersHistory)
 		group.PATCH("/multimanga/status", UpdateMultiMangaStatus)
+		group.PATCH("/multimanga/update_interval", UpdateMultiMangaUpdateInterval)
+		group.GET("/multimanga/notification_rules", GetMultiMangaNotificationRules)
+		group.PATCH("/multimanga/notification_rules", UpdateMultiMangaNotificationRules)
+		group.PATCH("/multimanga/metadata", UpdateMultiMangaMetadata)
 		group.PATCH("/multimanga/last_read_chapter", UpdateMultiMangaLastReadChapter)
 		group.PATCH("/multimanga/cover_img", UpdateMultiMangaCoverImg)
 		group.POST("/multimanga/manga", AddMangaToMultiManga)
@@ -70,6 +79,8 @@ func MangaRoutes(group *gin.RouterGroup) {
 		group.POST("/mangas/add_to_tranga", AddMangasToTranga)
 		group.POST("/mangas/add_to_suwayomi", AddMangasToSuwayomi)
 		group.GET("/mangas/stats", GetLibraryStats)
+		group.GET("/mangas/update_intervals", GetStatusUpdateIntervals)
+		group.PATCH("/mangas/update_interval", UpdateStatusUpdateInterval)
 	}
 }
 
@@ -109,10 +120,11 @@ func GetMangaChapters(c *gin.Context) {
 		return
 	}
 
+	var mangaGet *manga.Manga
 	if mangaURL == "" {
-		mangaGet, err := manga.GetMangaDB(mangaID, mangaURL)
+		mangaGet, err = getRequestMangaDB(c, mangaID, mangaURL)
 		if err != nil {
-			if strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) {
+			if isMangaNotFoundError(err) {
 				c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 				return
 			}
@@ -120,6 +132,9 @@ func GetMangaChapters(c *gin.Context) {
 			return
 		}
 		mangaURL = mangaGet.URL
+	} else {
+		// The manga doesn't need to be in the DB, it's used only to store the chapters history
+		mangaGet, _ = manga.GetMangaDB(mangaID, mangaURL)
 	}
 
 	chapters, err := sources.GetMangaChapters(mangaURL, mangaInternalID)
@@ -128,10 +143,73 @@ func GetMangaChapters(c *gin.Context) {
 		return
 	}
 
+	if mangaGet != nil {
+		err = mangaGet.UpsertChaptersHistoryIntoDB(chapters)
+		if err != nil {
+			logger := util.GetLogger(zerolog.Level(config.GlobalConfigs.API.LogLevelInt))
+			logger.Error().Err(err).Str("manga_url", mangaURL).Msg("Error saving manga chapters history to DB")
+		}
+	}
+
 	resMap := map[string][]*manga.Chapter{"chapters": chapters}
 	c.JSON(http.StatusOK, resMap)
 }
 
+// @Summary Update custom manga metadata
+// @Description Gets the custom manga last released chapter from its site and updates it in the database. Notifies and triggers the download integrations like the route that updates all mangas metadata. You must provide either the manga ID or the manga URL.
+// @Produce json
+// @Param id query int false "Manga ID" Example(1)
+// @Param url query string false "Manga current URL" Example("https://mangadex.org/title/1/one-piece")
+// @Param notify query string false "Notify if a new chapter was released for the manga (only if the manga status is reading or completed)."
+// @Success 200 {object} responseMessage
+// @Router /custom_manga/metadata [patch]
+func UpdateCustomMangaMetadata(c *gin.Context) {
+	mangaIDStr := c.Query("id")
+	mangaURL := c.Query("url")
+	mangaID, mangaURL, err := getMangaIDAndURL(mangaIDStr, mangaURL)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	notify := c.Query("notify") == "true"
+
+	mangaUpdate, err := getRequestMangaDB(c, mangaID, mangaURL)
+	if err != nil {
+		if isMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	if mangaUpdate.Source != manga.CustomMangaSource {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "you can only update the metadata of custom mangas using this route"})
+		return
+	}
+
+	logger := util.GetLogger(zerolog.Level(config.GlobalConfigs.API.LogLevelInt))
+	errors := newUpdateMangasMetadataErrors()
+	retryInterval := 3 * time.Second
+
+	mangaWithNewChapters, mangaErrors := updateCustomMangaMetadata(mangaUpdate, logger)
+	errors["manga_metadata"] = append(errors["manga_metadata"], mangaErrors...)
+	var mangasWithNewChapter []*manga.Manga
+	if mangaWithNewChapters != nil {
+		mangasWithNewChapter = append(mangasWithNewChapter, mangaWithNewChapters)
+	}
+	handleMangasWithNewChapters(mangasWithNewChapter, mangaWithNewChapters != nil, notify, retryInterval, errors, logger)
+
+	for _, errSlice := range errors {
+		if len(errSlice) > 0 {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "some errors occured while updating the custom manga metadata, check the logs for more information", "errors": errors})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Custom manga metadata updated successfully"})
+}
+
 // @Summary Update custom manga name
 // @Description Updates a custom manga name in the database. You must provide either the manga ID or the manga URL.
 // @Produce json
@@ -155,9 +233,9 @@ func UpdateCustomMangaName(c *gin.Context) {
 		return
 	}
 
-	mangaUpdate, err := manga.GetMangaDB(mangaID, mangaURL)
+	mangaUpdate, err := getRequestMangaDB(c, mangaID, mangaURL)
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) {
+		if isMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -181,6 +259,135 @@ func UpdateCustomMangaName(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Custom manga name updated successfully"})
 }
 
+// @Summary Update manga preferred group
+// @Description Updates a manga preferred group in the database. Sources with chapters from multiple groups, like MangaDex and MangaUpdates, only get the chapters of the preferred groups, so a chapter from other groups is not a new released chapter. The manga last released chapter is updated to the last chapter of the new preferred groups. You must provide either the manga ID or the manga URL.
+// @Produce json
+// @Param id query int false "Manga ID" Example(1)
+// @Param url query string false "Manga URL" Example("https://mangadex.org/title/1/one-piece")
+// @Param preferred_group query string false "Names or IDs of the preferred groups, separated by commas. Empty to get the chapters of all groups." Example("Group A, Group B")
+// @Success 200 {object} responseMessage
+// @Router /manga/preferred_group [patch]
+func UpdateMangaPreferredGroup(c *gin.Context) {
+	mangaIDStr := c.Query("id")
+	mangaURL := c.Query("url")
+	preferredGroup := strings.TrimSpace(c.Query("preferred_group"))
+	mangaID, mangaURL, err := getMangaIDAndURL(mangaIDStr, mangaURL)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	mangaUpdate, err := getRequestMangaDB(c, mangaID, mangaURL)
+	if err != nil {
+		if isMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	if mangaUpdate.Source == manga.CustomMangaSource {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "custom mangas don't have groups"})
+		return
+	}
+
+	err = updateMangaChapterPreferences(mangaUpdate, preferredGroup, mangaUpdate.PreferredLanguage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	dashboard.UpdateDashboard()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Manga preferred group updated successfully"})
+}
+
+// @Summary Update manga preferred language
+// @Description Updates a manga preferred translated languages in the database. Sources with chapters in multiple languages, like MangaDex, only get the chapters in the preferred languages, or in the source default languages if the manga doesn't have preferred languages. The manga last released chapter is updated to the last chapter in the new preferred languages. You must provide either the manga ID or the manga URL.
+// @Produce json
+// @Param id query int false "Manga ID" Example(1)
+// @Param url query string false "Manga URL" Example("https://mangadex.org/title/1/one-piece")
+// @Param preferred_language query string false "Codes of the preferred languages, separated by commas. Empty to use the source default languages." Example("en, pt-br")
+// @Success 200 {object} responseMessage
+// @Router /manga/preferred_language [patch]
+func UpdateMangaPreferredLanguage(c *gin.Context) {
+	mangaIDStr := c.Query("id")
+	mangaURL := c.Query("url")
+	preferredLanguage := strings.ToLower(strings.TrimSpace(c.Query("preferred_language")))
+	mangaID, mangaURL, err := getMangaIDAndURL(mangaIDStr, mangaURL)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	err = manga.ValidateLanguages(preferredLanguage)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	mangaUpdate, err := getRequestMangaDB(c, mangaID, mangaURL)
+	if err != nil {
+		if isMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	if mangaUpdate.Source == manga.CustomMangaSource {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "custom mangas don't have languages"})
+		return
+	}
+
+	err = updateMangaChapterPreferences(mangaUpdate, mangaUpdate.PreferredGroup, preferredLanguage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	dashboard.UpdateDashboard()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Manga preferred language updated successfully"})
+}
+
+// updateMangaChapterPreferences updates the manga preferred group and language in the DB,
+// with the last released chapter of the new preferred groups and languages.
+// If the manga is in a multimanga, the multimanga current manga is also updated.
+func updateMangaChapterPreferences(m *manga.Manga, preferredGroup, preferredLanguage string) error {
+	// The last released chapter is updated now, or else the next update would consider
+	// an older chapter of the new preferred groups or languages a new released chapter.
+	filter := models.NewChapterFilter(&manga.Manga{PreferredGroup: preferredGroup, PreferredLanguage: preferredLanguage})
+	updatedManga, err := sources.GetMangaMetadataWithFilter(m.URL, m.InternalID, filter)
+	if err != nil {
+		return err
+	}
+	lastReleasedChapter := updatedManga.LastReleasedChapter
+	if lastReleasedChapter != nil && lastReleasedChapter.UpdatedAt.IsZero() {
+		lastReleasedChapter.UpdatedAt = time.Now().Truncate(time.Second)
+	}
+
+	err = m.UpdateChapterPreferencesInDB(preferredGroup, preferredLanguage, lastReleasedChapter)
+	if err != nil {
+		return err
+	}
+
+	if m.MultiMangaID > 0 {
+		multimanga, err := manga.GetMultiMangaFromDB(m.MultiMangaID)
+		if err != nil {
+			return err
+		}
+		err = multimanga.UpdateCurrentMangaInDB()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // @Summary Update custom manga URL
 // @Description Updates a custom manga URL in the database. You must provide either the manga ID or the manga current URL.
 // @Produce json
@@ -199,9 +406,9 @@ func UpdateCustomMangaURL(c *gin.Context) {
 		return
 	}
 
-	mangaUpdate, err := manga.GetMangaDB(mangaID, mangaURL)
+	mangaUpdate, err := getRequestMangaDB(c, mangaID, mangaURL)
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) {
+		if isMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -254,9 +461,9 @@ func UpdateCustomMangaLastReleasedChapterSelectors(c *gin.Context) {
 		return
 	}
 
-	mangaToUpdate, err := manga.GetMangaDB(mangaID, mangaURL)
+	mangaToUpdate, err := getRequestMangaDB(c, mangaID, mangaURL)
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) {
+		if isMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -312,7 +519,7 @@ type AddMultiMangaRequest struct {
 }
 
 // @Summary Add multimanga
-// @Description Gets a manga metadata from source and inserts it as the current manga of a new multimanga into the database.
+// @Description Gets a manga metadata from source and inserts it as the current manga of a new multimanga into the database. If the request is made by a user, the multimanga is also added to the user's library. If the manga is already in the database, the user's library gets the existing multimanga instead.
 // @Accept json
 // @Produce json
 // @Param manga body AddMultiMangaRequest true "Current manga data"
@@ -431,7 +638,7 @@ func AddMultiManga(c *gin.Context) {
 				currentManga.CoverImgResized = false
 			}
 		} else if requestData.CoverImgURL != "" {
-			currentManga.CoverImg, currentManga.CoverImgResized, err = util.GetImageFromURL(requestData.CoverImgURL, 3, 3*time.Second)
+			currentManga.CoverImg, currentManga.CoverImgResized, err = util.GetImageFromURL(manga.CustomMangaSource, requestData.CoverImgURL)
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "invalid image: " + err.Error()})
 				return
@@ -462,12 +669,44 @@ func AddMultiManga(c *gin.Context) {
 		Status:          currentManga.Status,
 	}
 
+	userID := auth.GetUserID(c)
 	err = multiManga.InsertIntoDB()
 	if err != nil {
+		if userID > 0 && strings.Contains(err.Error(), errordefs.ErrMangaAlreadyInDB.Error()) {
+			// The manga is already shared by another user or by the global library
+			existingManga, err := manga.GetMangaDB(-1, currentManga.URL)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+				return
+			}
+			multiManga.ID = existingManga.MultiMangaID
+			err = multiManga.AddToUserLibraryInDB(userID)
+			if err != nil {
+				if strings.Contains(err.Error(), errordefs.ErrMultiMangaAlreadyInUserLibrary.Error()) {
+					c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+				return
+			}
+
+			dashboard.UpdateDashboard()
+
+			c.JSON(http.StatusOK, gin.H{"message": "Multimanga added to the user library successfully"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
 	}
 
+	if userID > 0 {
+		err = multiManga.AddToUserLibraryInDB(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": util.AddErrorContext("multimanga added to DB, but error while adding it to the user library", err).Error()})
+			return
+		}
+	}
+
 	var integrationsErrors []error
 	if config.GlobalConfigs.Kaizoku.Valid && requestData.Name == "" {
 		kaizoku := kaizoku.Kaizoku{}
@@ -514,7 +753,7 @@ func AddMultiManga(c *gin.Context) {
 }
 
 // @Summary Delete multimanga
-// @Description Deletes a multimanga from the database.
+// @Description Deletes a multimanga from the database. If the request is made by a user, only removes the multimanga from the user's library, as the multimangas are shared by all users.
 // @Produce json
 // @Param id query int true "Multimanga ID" Example(1)
 // @Success 200 {object} responseMessage
@@ -531,9 +770,9 @@ func DeleteMultiManga(c *gin.Context) {
 		return
 	}
 
-	multimangaDelete, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimangaDelete, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -541,6 +780,19 @@ func DeleteMultiManga(c *gin.Context) {
 		return
 	}
 
+	if userID := auth.GetUserID(c); userID > 0 {
+		err = multimangaDelete.RemoveFromUserLibraryInDB(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+
+		dashboard.UpdateDashboard()
+
+		c.JSON(http.StatusOK, gin.H{"message": "Multimanga removed from the user library successfully"})
+		return
+	}
+
 	err = multimangaDelete.DeleteFromDB()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
@@ -553,7 +805,7 @@ func DeleteMultiManga(c *gin.Context) {
 }
 
 // @Summary Get multimanga
-// @Description Gets a multimanga from the database.
+// @Description Gets a multimanga from the database. If the request is made by a user, the multimanga must be in the user's library.
 // @Produce json
 // @Param id query int true "Multimanga ID" Example(1)
 // @Success 200 {object} manga.MultiManga "{"multimanga": multimangaObj}"
@@ -570,9 +822,9 @@ func GetMultiManga(c *gin.Context) {
 		return
 	}
 
-	multimangaGet, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimangaGet, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -607,9 +859,9 @@ func ChooseCurrentManga(c *gin.Context) {
 		return
 	}
 
-	multimanga, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -683,9 +935,9 @@ func GetMultiMangaChapters(c *gin.Context) {
 		return
 	}
 
-	multimanga, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -693,18 +945,89 @@ func GetMultiMangaChapters(c *gin.Context) {
 		return
 	}
 
-	chapters, err := sources.GetMangaChapters(multimanga.CurrentManga.URL, multimanga.CurrentManga.InternalID)
+	chapters, err := sources.GetMangaChaptersWithFilter(multimanga.CurrentManga.URL, multimanga.CurrentManga.InternalID, models.NewChapterFilter(multimanga.CurrentManga))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
 	}
 
+	err = multimanga.CurrentManga.UpsertChaptersHistoryIntoDB(chapters)
+	if err != nil {
+		logger := util.GetLogger(zerolog.Level(config.GlobalConfigs.API.LogLevelInt))
+		logger.Error().Err(err).Str("manga_url", multimanga.CurrentManga.URL).Msg("Error saving manga chapters history to DB")
+	}
+
 	resMap := map[string][]*manga.Chapter{"chapters": chapters}
 	c.JSON(http.StatusOK, resMap)
 }
 
+// @Summary Get multimanga chapters history
+// @Description Get the chapters history of a multimanga's manga from the database, without requesting the source. The history contains every chapter Mantium has seen in the source and when it was first seen: the chapters released between the metadata updates and the chapters got from the source by the chapters routes. Defaults to the current manga of the multimanga.
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Param manga_id query int false "ID of the multimanga's manga to get the chapters history from" Example(1)
+// @Success 200 {array} manga.HistoryChapter "{"chapters": [chapterObj]}"
+// @Router /multimanga/chapters/history [get]
+func GetMultiMangaChaptersHistory(c *gin.Context) {
+	multimangaIDStr := c.Query("id")
+	if multimangaIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return
+	}
+	multimangaID, err := strconv.Atoi(multimangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return
+	}
+
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
+	if err != nil {
+		if isMultiMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	mangaToGetChaptersFrom := multimanga.CurrentManga
+	mangaIDStr := c.Query("manga_id")
+	if mangaIDStr != "" {
+		mangaID, err := strconv.Atoi(mangaIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "manga_id must be a number"})
+			return
+		}
+		mangaToGetChaptersFrom = nil
+		for _, m := range multimanga.Mangas {
+			if m.ID == manga.ID(mangaID) {
+				mangaToGetChaptersFrom = m
+				break
+			}
+		}
+		if mangaToGetChaptersFrom == nil {
+			c.JSON(http.StatusNotFound, gin.H{"message": errordefs.ErrMangaNotFoundInMultiManga.Error()})
+			return
+		}
+	}
+
+	chapters, err := mangaToGetChaptersFrom.GetChaptersHistoryFromDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	for _, chapter := range chapters {
+		if strings.HasPrefix(chapter.URL, manga.CustomMangaURLPrefix) {
+			chapter.URL = ""
+		}
+	}
+
+	resMap := map[string][]*manga.HistoryChapter{"chapters": chapters}
+	c.JSON(http.StatusOK, resMap)
+}
+
 // @Summary Update multimanga status
-// @Description Updates a multimanga status in the database.
+// @Description Updates a multimanga status in the database. If the request is made by a user, updates the status in the user's library. The response has a `warning` field if the MangaUpdates integration is enabled and the multimanga was not pushed to it because it doesn't have a manga from MangaUpdates.
 // @Produce json
 // @Param id query int true "Multimanga ID" Example(1)
 // @Param status body UpdateMangaStatusRequest true "Multimanga status"
@@ -722,9 +1045,9 @@ func UpdateMultiMangaStatus(c *gin.Context) {
 		return
 	}
 
-	multimanga, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -738,7 +1061,11 @@ func UpdateMultiMangaStatus(c *gin.Context) {
 		return
 	}
 
-	err = multimanga.UpdateStatusInDB(requestData.Status)
+	if userID := auth.GetUserID(c); userID > 0 {
+		err = multimanga.UpdateUserStatusInDB(userID, requestData.Status)
+	} else {
+		err = multimanga.UpdateStatusInDB(requestData.Status)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
@@ -746,7 +1073,14 @@ func UpdateMultiMangaStatus(c *gin.Context) {
 
 	dashboard.UpdateDashboard()
 
-	c.JSON(http.StatusOK, gin.H{"message": "Multimanga status updated successfully"})
+	multimanga.Status = requestData.Status
+	pushMultiMangaToAniList(c, multimanga)
+	response := gin.H{"message": "Multimanga status updated successfully"}
+	if warning := pushMultiMangaToMangaUpdates(c, multimanga); warning != "" {
+		response["warning"] = warning
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // UpdateMangaStatusRequest is the request body for the UpdateMangaStatus route
@@ -754,26 +1088,14 @@ type UpdateMangaStatusRequest struct {
 	Status manga.Status `json:"status" binding:"required,gte=0,lte=5"`
 }
 
-// UpdateLastReadChapterRequest is the request body for updating a manga chapter
-type UpdateLastReadChapterRequest struct {
-	FromSourceSite bool   `json:"from_source_site,omitempty"`
-	DeleteChapter  bool   `json:"delete_chapter,omitempty"`
-	Chapter        string `json:"chapter,omitempty"`
-	URL            string `json:"url,omitempty" binding:"omitempty,http_url"`
-	InternalID     string `json:"internal_id,omitempty"`
-}
-
-// @Summary Update multimanga last read chapter
-// @Description Updates a multimanga last read chapter in the database. It also needs to know from which manga the chapter is from if not a custom manga. If both `chapter` and `chapter_url` are empty strings in the body, set the last read chapter to the last released chapter in the database.
+// @Summary Update multimanga metadata
+// @Description Gets the metadata of the multimanga's mangas from the sources and updates them in the database. Notifies and triggers the download integrations like the route that updates all mangas metadata.
 // @Produce json
 // @Param id query int true "Multimanga ID" Example(1)
-// @Param manga_id query int true "Manga ID" Example(1)
-// @Param chapter body UpdateLastReadChapterRequest true "Chapter"
+// @Param notify query string false "Notify if a new chapter was released for the multimanga (only if the multimanga status is reading or completed)."
 // @Success 200 {object} responseMessage
-// @Router /multimanga/last_read_chapter [patch]
-func UpdateMultiMangaLastReadChapter(c *gin.Context) {
-	currentTime := time.Now()
-
+// @Router /multimanga/metadata [patch]
+func UpdateMultiMangaMetadata(c *gin.Context) {
 	multimangaIDStr := c.Query("id")
 	if multimangaIDStr == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
@@ -784,16 +1106,11 @@ func UpdateMultiMangaLastReadChapter(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
 		return
 	}
+	notify := c.Query("notify") == "true"
 
-	var requestData UpdateLastReadChapterRequest
-	if err := c.ShouldBindJSON(&requestData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
-		return
-	}
-
-	multimanga, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -801,15 +1118,268 @@ func UpdateMultiMangaLastReadChapter(c *gin.Context) {
 		return
 	}
 
-	var chapter *manga.Chapter
-	if requestData.DeleteChapter {
-		err = multimanga.DeleteLastReadChapterFromDB()
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+	logger := util.GetLogger(zerolog.Level(config.GlobalConfigs.API.LogLevelInt))
+	errors := newUpdateMangasMetadataErrors()
+	retryInterval := 3 * time.Second
+
+	mangaWithNewChapters, newMetadata, multimangaErrors := updateMultiMangaMetadata(multimanga, false, logger)
+	errors["manga_metadata"] = append(errors["manga_metadata"], multimangaErrors...)
+	var mangasWithNewChapter []*manga.Manga
+	if mangaWithNewChapters != nil {
+		mangasWithNewChapter = append(mangasWithNewChapter, mangaWithNewChapters)
+	}
+	handleMangasWithNewChapters(mangasWithNewChapter, newMetadata, notify, retryInterval, errors, logger)
+
+	for _, errSlice := range errors {
+		if len(errSlice) > 0 {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "some errors occured while updating the multimanga metadata, check the logs for more information", "errors": errors})
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"message": "Multimanga last read chapter updated successfully"})
-		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Multimanga metadata updated successfully"})
+}
+
+// @Summary Update multimanga update interval
+// @Description Updates how often a multimanga is checked for new chapters by the periodic update job. The release day update interval is used instead of the update interval on the release weekday. An update interval of 0 uses the multimanga's status update interval.
+// @Accept json
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Param update_interval body UpdateMultiMangaUpdateIntervalRequest true "Multimanga update interval"
+// @Success 200 {object} responseMessage
+// @Router /multimanga/update_interval [patch]
+func UpdateMultiMangaUpdateInterval(c *gin.Context) {
+	multimangaIDStr := c.Query("id")
+	if multimangaIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return
+	}
+	multimangaID, err := strconv.Atoi(multimangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return
+	}
+
+	var requestData UpdateMultiMangaUpdateIntervalRequest
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
+		return
+	}
+	releaseWeekday := -1
+	if requestData.ReleaseWeekday != nil {
+		releaseWeekday = *requestData.ReleaseWeekday
+	}
+
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
+	if err != nil {
+		if isMultiMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	err = multimanga.UpdateUpdateIntervalInDB(requestData.UpdateInterval, releaseWeekday, requestData.ReleaseDayUpdateInterval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Multimanga update interval updated successfully"})
+}
+
+// UpdateMultiMangaUpdateIntervalRequest is the request body for the UpdateMultiMangaUpdateInterval route.
+// Intervals are in minutes. If the release weekday (0 = Sunday, 6 = Saturday) is not provided, the multimanga will not have a release weekday.
+type UpdateMultiMangaUpdateIntervalRequest struct {
+	ReleaseWeekday           *int `json:"releaseWeekday" binding:"omitempty,gte=-1,lte=6"`
+	UpdateInterval           int  `json:"updateInterval" binding:"gte=0"`
+	ReleaseDayUpdateInterval int  `json:"releaseDayUpdateInterval" binding:"gte=0"`
+}
+
+// @Summary Get multimanga notification rules
+// @Description Gets the rules used to decide if a notification should be sent when a multimanga has a new chapter. If the multimanga doesn't have notification rules, the default rules (notify about every new chapter) are returned.
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Success 200 {object} manga.NotificationRules "{"rules": notificationRulesObj}"
+// @Router /multimanga/notification_rules [get]
+func GetMultiMangaNotificationRules(c *gin.Context) {
+	multimangaIDStr := c.Query("id")
+	if multimangaIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return
+	}
+	multimangaID, err := strconv.Atoi(multimangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return
+	}
+
+	_, err = getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
+	if err != nil {
+		if isMultiMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	rules, err := manga.GetNotificationRulesFromDB(manga.ID(multimangaID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// @Summary Update multimanga notification rules
+// @Description Updates the rules used to decide if a notification should be sent when a multimanga has a new chapter. The notifier must be one of the enabled notifiers, or empty to use all of them. The topic overrides the ntfy topic. Quiet hours are in the server's timezone; if not provided, there are no quiet hours. Notifications in the quiet hours are delayed until the quiet hours end.
+// @Accept json
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Param notification_rules body UpdateMultiMangaNotificationRulesRequest true "Multimanga notification rules"
+// @Success 200 {object} responseMessage
+// @Router /multimanga/notification_rules [patch]
+func UpdateMultiMangaNotificationRules(c *gin.Context) {
+	multimangaIDStr := c.Query("id")
+	if multimangaIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return
+	}
+	multimangaID, err := strconv.Atoi(multimangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return
+	}
+
+	var requestData UpdateMultiMangaNotificationRulesRequest
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
+		return
+	}
+	if requestData.Notifier != "" && !slices.Contains(notifiers.GetNotifiersNames(), requestData.Notifier) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": fmt.Sprintf("notifier '%s' is not enabled", requestData.Notifier)})
+		return
+	}
+
+	_, err = getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
+	if err != nil {
+		if isMultiMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	rules, err := manga.GetNotificationRulesFromDB(manga.ID(multimangaID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	rules.Muted = requestData.Muted
+	rules.OnlyNumericIncrease = requestData.OnlyNumericIncrease
+	rules.MinChapterGap = requestData.MinChapterGap
+	rules.Notifier = requestData.Notifier
+	rules.Topic = requestData.Topic
+	rules.QuietHoursStart, rules.QuietHoursEnd = -1, -1
+	if requestData.QuietHoursStart != nil && requestData.QuietHoursEnd != nil {
+		rules.QuietHoursStart, rules.QuietHoursEnd = *requestData.QuietHoursStart, *requestData.QuietHoursEnd
+	} else if requestData.QuietHoursStart != nil || requestData.QuietHoursEnd != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "quietHoursStart and quietHoursEnd must be provided together"})
+		return
+	}
+
+	err = rules.UpsertIntoDB()
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Multimanga notification rules updated successfully"})
+}
+
+// UpdateMultiMangaNotificationRulesRequest is the request body for the UpdateMultiMangaNotificationRules route.
+// Quiet hours are hours of the day (0-23), the end hour is exclusive.
+type UpdateMultiMangaNotificationRulesRequest struct {
+	QuietHoursStart     *int    `json:"quietHoursStart" binding:"omitempty,gte=0,lte=23"`
+	QuietHoursEnd       *int    `json:"quietHoursEnd" binding:"omitempty,gte=0,lte=23"`
+	Notifier            string  `json:"notifier"`
+	Topic               string  `json:"topic" binding:"max=255"`
+	MinChapterGap       float64 `json:"minChapterGap" binding:"gte=0"`
+	Muted               bool    `json:"muted"`
+	OnlyNumericIncrease bool    `json:"onlyNumericIncrease"`
+}
+
+// UpdateLastReadChapterRequest is the request body for updating a manga chapter
+type UpdateLastReadChapterRequest struct {
+	FromSourceSite bool   `json:"from_source_site,omitempty"`
+	DeleteChapter  bool   `json:"delete_chapter,omitempty"`
+	Chapter        string `json:"chapter,omitempty"`
+	URL            string `json:"url,omitempty" binding:"omitempty,http_url"`
+	InternalID     string `json:"internal_id,omitempty"`
+	// Volume is the volume of the chapter. If set, it replaces the volume provided by the source.
+	Volume string `json:"volume,omitempty"`
+}
+
+// @Summary Update multimanga last read chapter
+// @Description Updates a multimanga last read chapter in the database, or in the user's library if the request is made by a user. It also needs to know from which manga the chapter is from if not a custom manga. If both `chapter` and `chapter_url` are empty strings in the body, set the last read chapter to the last released chapter in the database. The chapter's volume is provided by the source if it knows it, or can be set using `volume`. The response has a `warning` field if the MangaUpdates integration is enabled and the multimanga was not pushed to it because it doesn't have a manga from MangaUpdates.
+// @Produce json
+// @Param id query int true "Multimanga ID" Example(1)
+// @Param manga_id query int true "Manga ID" Example(1)
+// @Param chapter body UpdateLastReadChapterRequest true "Chapter"
+// @Success 200 {object} responseMessage
+// @Router /multimanga/last_read_chapter [patch]
+func UpdateMultiMangaLastReadChapter(c *gin.Context) {
+	currentTime := time.Now()
+
+	multimangaIDStr := c.Query("id")
+	if multimangaIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be provided"})
+		return
+	}
+	multimangaID, err := strconv.Atoi(multimangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "id must be a number"})
+		return
+	}
+
+	var requestData UpdateLastReadChapterRequest
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
+		return
+	}
+
+	userID := auth.GetUserID(c)
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
+	if err != nil {
+		if isMultiMangaNotFoundError(err) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	var chapter *manga.Chapter
+	if requestData.DeleteChapter {
+		if userID > 0 {
+			err = multimanga.DeleteUserLastReadChapterFromDB(userID)
+		} else {
+			err = multimanga.DeleteLastReadChapterFromDB()
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Multimanga last read chapter updated successfully"})
+		return
 	} else if requestData.Chapter == "" && requestData.URL == "" {
 		chapter = multimanga.CurrentManga.LastReleasedChapter
 		if multimanga.CurrentManga.Source == manga.CustomMangaSource {
@@ -865,7 +1435,11 @@ func UpdateMultiMangaLastReadChapter(c *gin.Context) {
 		}
 
 		if chapter == nil {
-			err = multimanga.DeleteLastReadChapterFromDB()
+			if userID > 0 {
+				err = multimanga.DeleteUserLastReadChapterFromDB(userID)
+			} else {
+				err = multimanga.DeleteLastReadChapterFromDB()
+			}
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 				return
@@ -878,8 +1452,15 @@ func UpdateMultiMangaLastReadChapter(c *gin.Context) {
 
 	chapter.Type = 2
 	chapter.UpdatedAt = currentTime.Truncate(time.Second)
+	if requestData.Volume != "" {
+		chapter.Volume = requestData.Volume
+	}
 
-	err = multimanga.UpsertChapterIntoDB(chapter)
+	if userID > 0 {
+		err = multimanga.UpsertUserLastReadChapterIntoDB(userID, chapter)
+	} else {
+		err = multimanga.UpsertChapterIntoDB(chapter)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
@@ -887,7 +1468,14 @@ func UpdateMultiMangaLastReadChapter(c *gin.Context) {
 
 	dashboard.UpdateDashboard()
 
-	c.JSON(http.StatusOK, gin.H{"message": "Multimanga last read chapter updated successfully"})
+	multimanga.LastReadChapter = chapter
+	pushMultiMangaToAniList(c, multimanga)
+	response := gin.H{"message": "Multimanga last read chapter updated successfully"}
+	if warning := pushMultiMangaToMangaUpdates(c, multimanga); warning != "" {
+		response["warning"] = warning
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary Update custom manga cover image
@@ -956,9 +1544,9 @@ func UpdateCustomMangaCoverImg(c *gin.Context) {
 		return
 	}
 
-	mangaToUpdate, err := manga.GetMangaDB(mangaID, "")
+	mangaToUpdate, err := getRequestMangaDB(c, mangaID, "")
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) {
+		if isMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -966,8 +1554,6 @@ func UpdateCustomMangaCoverImg(c *gin.Context) {
 		return
 	}
 
-	retries := 3
-	retryInterval := 3 * time.Second
 	if len(coverImg) != 0 {
 		if !util.IsImageValid(coverImg) {
 			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid image"})
@@ -987,7 +1573,7 @@ func UpdateCustomMangaCoverImg(c *gin.Context) {
 			return
 		}
 	} else if coverImgURL != "" {
-		coverImg, isImgRezied, err := util.GetImageFromURL(coverImgURL, retries, retryInterval)
+		coverImg, isImgRezied, err := util.GetImageFromURL(mangaToUpdate.Source, coverImgURL)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "invalid image: " + err.Error()})
 			return
@@ -1088,9 +1674,9 @@ func UpdateMultiMangaCoverImg(c *gin.Context) {
 		return
 	}
 
-	multimanga, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -1098,8 +1684,6 @@ func UpdateMultiMangaCoverImg(c *gin.Context) {
 		return
 	}
 
-	retries := 3
-	retryInterval := 3 * time.Second
 	if len(coverImg) != 0 {
 		if !util.IsImageValid(coverImg) {
 			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid image"})
@@ -1119,7 +1703,7 @@ func UpdateMultiMangaCoverImg(c *gin.Context) {
 			return
 		}
 	} else if coverImgURL != "" {
-		coverImg, isImgRezied, err := util.GetImageFromURL(coverImgURL, retries, retryInterval)
+		coverImg, isImgRezied, err := util.GetImageFromURL("", coverImgURL)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "invalid image: " + err.Error()})
 			return
@@ -1173,9 +1757,9 @@ func AddMangaToMultiManga(c *gin.Context) {
 		return
 	}
 
-	multimanga, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -1257,7 +1841,7 @@ func AddMangaToMultiManga(c *gin.Context) {
 				mangaAdd.CoverImgResized = false
 			}
 		} else if requestData.CoverImgURL != "" {
-			mangaAdd.CoverImg, mangaAdd.CoverImgResized, err = util.GetImageFromURL(requestData.CoverImgURL, 3, 3*time.Second)
+			mangaAdd.CoverImg, mangaAdd.CoverImgResized, err = util.GetImageFromURL(manga.CustomMangaSource, requestData.CoverImgURL)
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "invalid image: " + err.Error()})
 				return
@@ -1370,9 +1954,9 @@ func RemoveMangaFromMultiManga(c *gin.Context) {
 		return
 	}
 
-	multimanga, err := manga.GetMultiMangaFromDB(manga.ID(multimangaID))
+	multimanga, err := getRequestMultiMangaFromDB(c, manga.ID(multimangaID))
 	if err != nil {
-		if strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) {
+		if isMultiMangaNotFoundError(err) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
 		}
@@ -1442,14 +2026,14 @@ type SearchMangaRequest struct {
 }
 
 // @Summary Get mangas
-// @Description Gets the current manga of multimangas.
+// @Description Gets the current manga of multimangas. If the request is made by a user, gets only the multimangas in the user's library.
 // @Produce json
 // @Success 200 {array} manga.Manga "{"mangas": [mangaObj]}"
 // @Router /mangas [get]
 func GetMangas(c *gin.Context) {
 	mangas := []*manga.Manga{}
 	var err error
-	multimangas, err := manga.GetMultiMangasDB(false)
+	multimangas, err := getRequestMultiMangasDB(c, false)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
@@ -1480,29 +2064,54 @@ func GetMangas(c *gin.Context) {
 }
 
 // @Summary Get multimangas
-// @Description Gets all multimangas. The multimanga's mangas will have only the current manga. The current manga will have a possible wrong status, so use the multimanga's status.
+// @Description Gets all multimangas, or only the multimangas in the user's library if the request is made by a user. The multimanga's mangas will have only the current manga. The current manga will have a possible wrong status, so use the multimanga's status. The UnreadChapters property is the number of chapters released after the last read chapter. The UpdateStatus property is the result of the metadata updates of the multimanga, and of the current manga if it's a custom manga.
 // @Produce json
+// @Param failing_for_days query int false "Gets only the multimangas whose metadata updates are failing for at least this number of days. 0 gets the multimangas whose last update failed." Example(3)
 // @Success 200 {array} manga.MultiManga "{"multimangas": [multimangaObj]}"
 // @Router /multimangas [get]
 func GetMultiMangas(c *gin.Context) {
-	multimangas, err := manga.GetMultiMangasDB(false)
+	failingForDaysStr := c.Query("failing_for_days")
+	failingForDays := -1
+	if failingForDaysStr != "" {
+		var err error
+		failingForDays, err = strconv.Atoi(failingForDaysStr)
+		if err != nil || failingForDays < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "failing_for_days must be a number greater than or equal to 0"})
+			return
+		}
+	}
+
+	multimangas, err := getRequestMultiMangasDB(c, false)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
 	}
 
+	if failingForDays >= 0 {
+		now := time.Now()
+		failingFor := time.Duration(failingForDays) * 24 * time.Hour
+		failingMultiMangas := []*manga.MultiManga{}
+		for _, multimanga := range multimangas {
+			if multimanga.IsFailingFor(failingFor, now) {
+				failingMultiMangas = append(failingMultiMangas, multimanga)
+			}
+		}
+		multimangas = failingMultiMangas
+	}
+
 	resMap := map[string][]*manga.MultiManga{"multimangas": multimangas}
 	c.JSON(http.StatusOK, resMap)
 }
 
 // @Summary Mangas iFrame
-// @Description Returns an iFrame with mangas. Only mangas with unread chapters, and status reading or completed. Sort by last released chapter date.
+// @Description Returns an iFrame with mangas. Only mangas with unread chapters, and status reading or completed. Sort by last released chapter date. If the request is made by a user, like with a user's API token, shows only the mangas in the user's library.
 // @Success 200 {string} string "HTML content"
 // @Produce html
 // @Param api_url query string true "API URL used by your browser. Used for the button that updates the last read chater, as your browser needs to send a request to the API to update the chapter." Example(https://sub.domain.com)
 // @Param theme query string false "IFrame theme, defaults to light. If it's different from your dashboard theme, the background turns may turn white" Example(light)
 // @Param limit query int false "Limits the number of items in the iFrame." Example(5)
 // @Param showBackgroundErrorWarning query bool false "If true, shows a warning in the iFrame if an error occurred in the background. Defaults to true." Example(true)
+// @Param token query string false "API token used by the iFrame to send requests to the API when the authentication is enabled. With an 'iframe' or 'read' scope token, the iFrame is read-only and doesn't show the buttons that update the mangas." Example(mantium_0123456789abcdef)
 // @Router /mangas/iframe [get]
 func GetMangasiFrame(c *gin.Context) {
 	queryLimit := c.Query("limit")
@@ -1543,7 +2152,7 @@ func GetMangasiFrame(c *gin.Context) {
 	}
 
 	allMangas := []*manga.Manga{}
-	multimangas, err := manga.GetMultiMangasDB(false)
+	multimangas, err := getRequestMultiMangasDB(c, false)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
@@ -1572,7 +2181,10 @@ func GetMangasiFrame(c *gin.Context) {
 		mangas = mangas[:limit]
 	}
 
-	html, err := getMangasiFrame(mangas, theme, apiURL, showBackgroundErrorWarning)
+	token := c.Query("token")
+	readOnly := !auth.GetScope(c).Allows(auth.ScopeWrite)
+
+	html, err := getMangasiFrame(mangas, theme, apiURL, token, readOnly, showBackgroundErrorWarning)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
@@ -1581,7 +2193,7 @@ func GetMangasiFrame(c *gin.Context) {
 	c.Data(http.StatusOK, "text/html", []byte(html))
 }
 
-func getMangasiFrame(mangas []*manga.Manga, theme, apiURL string, showBackgroundErrorWarning bool) ([]byte, error) {
+func getMangasiFrame(mangas []*manga.Manga, theme, apiURL, token string, readOnly, showBackgroundErrorWarning bool) ([]byte, error) {
 	html := `
 <!doctype html>
 <html lang="en">
@@ -1761,10 +2373,19 @@ func getMangasiFrame(mangas []*manga.Manga, theme, apiURL string, showBackground
     </style>
 
     <script>
+      var apiToken = '{{ .Token }}';
+
+      function withToken(url) {
+        if (apiToken === '') {
+            return url;
+        }
+        return url + (url.includes('?') ? '&' : '?') + 'token=' + encodeURIComponent(apiToken);
+      }
+
       function setMultiMangaLastReadChapter(multimangaId, mangaId) {
         try {
             var xhr = new XMLHttpRequest();
-            var url = '{{ .APIURL }}/v1/multimanga/last_read_chapter?id=' + encodeURIComponent(multimangaId) + '&manga_id=' + encodeURIComponent(mangaId);
+            var url = withToken('{{ .APIURL }}/v1/multimanga/last_read_chapter?id=' + encodeURIComponent(multimangaId) + '&manga_id=' + encodeURIComponent(mangaId));
             xhr.open('PATCH', url, true);
             xhr.setRequestHeader('Content-Type', 'application/json');
 
@@ -1802,7 +2423,7 @@ func getMangasiFrame(mangas []*manga.Manga, theme, apiURL string, showBackground
       function deleteBackgroundError() {
         try {
             var xhr = new XMLHttpRequest();
-            var url = '{{ .APIURL }}/v1/dashboard/last_background_error';
+            var url = withToken('{{ .APIURL }}/v1/dashboard/last_background_error');
             xhr.open('DELETE', url, true);
             xhr.setRequestHeader('Content-Type', 'application/json');
 
@@ -1839,7 +2460,7 @@ func getMangasiFrame(mangas []*manga.Manga, theme, apiURL string, showBackground
 
         async function fetchData() {
             try {
-                var url = '{{ .APIURL }}/v1/dashboard/last_update';
+                var url = withToken('{{ .APIURL }}/v1/dashboard/last_update');
                 const response = await fetch(url);
                 const data = await response.json();
 
@@ -1875,9 +2496,11 @@ func getMangasiFrame(mangas []*manga.Manga, theme, apiURL string, showBackground
             <span style="margin-right: 7px;" class="info-label"><i class="fa-solid fa-calendar-days"></i> {{ .BackgroundErrorTime.Format "2006-01-02 15:04:05" }}</span>
         </div>
     </div>
+    {{ if not .ReadOnly }}
     <div class="delete-background-error-container">
         <button id="delete-background-error-button" onclick="deleteBackgroundError()" onmouseenter="this.style.cursor='pointer';">Delete Error</button>
     </div>
+    {{ end }}
 </div>
 {{ end }}
 {{range .Mangas }}
@@ -1908,9 +2531,11 @@ func getMangasiFrame(mangas []*manga.Manga, theme, apiURL string, showBackground
 				<a href="{{ .URL }}" class="chapter-label last-released-chapter-label" target="_blank">N/A</a>
 			{{ end }}
 
+			{{ if not $.ReadOnly }}
 			<div>
 				<button id="manga-{{ .ID }}" onclick="setMultiMangaLastReadChapter('{{ .MultiMangaID }}', '{{ .ID }}')" class="set-last-read-button" onmouseenter="this.style.cursor='pointer';">Set last read</button>
 			</div>
+			{{ end }}
         </div>
 
     </div>
@@ -1929,6 +2554,8 @@ func getMangasiFrame(mangas []*manga.Manga, theme, apiURL string, showBackground
 		Mangas:                        mangas,
 		Theme:                         theme,
 		APIURL:                        apiURL,
+		Token:                         token,
+		ReadOnly:                      readOnly,
 		ScrollbarThumbBackgroundColor: scrollbarThumbBackgroundColor,
 		ScrollbarTrackBackgroundColor: scrollbarTrackBackgroundColor,
 	}
@@ -1959,16 +2586,22 @@ type iframeTemplateData struct {
 	BackgroundErrorTime           time.Time
 	Theme                         string
 	APIURL                        string
+	Token                         string
 	ScrollbarThumbBackgroundColor string
 	ScrollbarTrackBackgroundColor string
 	Mangas                        []*manga.Manga
 	ShowBackgroundError           bool
+	// ReadOnly is true if the iFrame shouldn't show the buttons that update the mangas.
+	ReadOnly bool
 }
 
 // @Summary Update mangas metadata
-// @Description Get the mangas metadata from the sources and update them in the database.
+// @Description Get the mangas metadata from the sources and update them in the database. The update runs in the background as a job, and the request returns the job ID right away. Use the /jobs/{id} route to follow the job's progress, errors, and to cancel it.
 // @Produce json
 // @Param notify query string false "Notify if a new chapter was released for the manga (only of mangas with status reading or completed)."
+// @Param only_due query string false "Update only the multimangas due for update based on their update intervals and their status update intervals. Custom mangas are always updated."
+// @Param wait query string false "If true, waits for the job to finish before returning, like the old behavior of this route."
+// @Success 202 {object} map[string]string "{"message": "...", "job_id": "..."}"
 // @Success 200 {object} responseMessage
 // @Router /mangas/metadata [patch]
 func UpdateMangasMetadata(c *gin.Context) {
@@ -1977,44 +2610,98 @@ func UpdateMangasMetadata(c *gin.Context) {
 	if notifyStr == "true" {
 		notify = true
 	}
+	onlyDue := c.Query("only_due") == "true"
+	wait := c.Query("wait") == "true"
 
-	var mangasWithNewChapter []*manga.Manga
+	job, ctx := jobs.NewJob(context.Background(), UpdateMangasMetadataJobType)
+	if !wait {
+		go RunUpdateMangasMetadataJob(ctx, job, notify, onlyDue)
+		c.JSON(http.StatusAccepted, gin.H{"message": "Mangas metadata update started", "job_id": job.ID})
+		return
+	}
 
-	logger := util.GetLogger(zerolog.Level(config.GlobalConfigs.API.LogLevelInt))
-	errors := map[string][]string{
-		"manga_metadata": {},
-		"ntfy":           {},
-		"tranga":         {},
-		"kaizoku":        {},
-		"suwayomi":       {},
+	errors, err := RunUpdateMangasMetadataJob(ctx, job, notify, onlyDue)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error(), "job_id": job.ID})
+		return
 	}
-	var newMetadata bool
-	var trangaInt *tranga.Tranga
-	if config.GlobalConfigs.Tranga.Valid {
-		trangaInt = &tranga.Tranga{}
-		trangaInt.Init()
+
+	for _, errSlice := range errors {
+		if len(errSlice) > 0 {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "some errors occured while updating the mangas metadata, check the logs for more information", "errors": errors, "job_id": job.ID})
+			return
+		}
 	}
-	var suwayomiInt *suwayomi.Suwayomi
-	if config.GlobalConfigs.Suwayomi.Valid {
-		suwayomiInt = &suwayomi.Suwayomi{}
-		suwayomiInt.Init()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Mangas metadata updated successfully", "job_id": job.ID})
+}
+
+// UpdateMangasMetadataJobType is the type of the jobs that update the mangas metadata.
+const UpdateMangasMetadataJobType = "update_mangas_metadata"
+
+// RunUpdateMangasMetadataJob updates the mangas metadata using UpdateAllMangasMetadata,
+// reporting the progress to the job and finishing it when done.
+func RunUpdateMangasMetadataJob(ctx context.Context, job *jobs.Job, notify, onlyDue bool) (map[string][]string, error) {
+	errors, err := UpdateAllMangasMetadata(ctx, job, notify, onlyDue)
+
+	message := "Mangas metadata updated successfully"
+	for _, errSlice := range errors {
+		if len(errSlice) > 0 {
+			message = "some errors occured while updating the mangas metadata, check the logs for more information"
+			break
+		}
+	}
+	if ctx.Err() != nil {
+		message = "Mangas metadata update canceled"
 	}
-	retries := 3
+	job.Finish(ctx, errors, err, message)
+
+	return errors, err
+}
+
+// UpdateAllMangasMetadata gets the metadata of all multimangas and custom mangas from the sources
+// and updates them in the database. It also notifies about new chapters and triggers the integrations.
+// Returns the errors that occurred while updating the mangas, grouped by where they occurred
+// ("manga_metadata", "tranga", "kaizoku", "suwayomi", and the notifiers names, like "ntfy").
+// If onlyDue is true, only the multimangas due for update are updated (see manga.MultiManga.IsDueForUpdate).
+// The progress is reported to the job if it's not nil. If ctx is canceled, the mangas not updated yet are skipped.
+// The returned error is not nil only if the mangas can't be retrieved from the database.
+func UpdateAllMangasMetadata(ctx context.Context, job *jobs.Job, notify, onlyDue bool) (map[string][]string, error) {
+	var mangasWithNewChapter []*manga.Manga
+
+	logger := util.GetLogger(zerolog.Level(config.GlobalConfigs.API.LogLevelInt))
+	errors := newUpdateMangasMetadataErrors()
+	var newMetadata bool
 	retryInterval := 3 * time.Second
 
 	mangas, err := manga.GetCustomMangasDB()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
-		return
+		return nil, err
 	}
 	multimangas, err := manga.GetMultiMangasDB(true)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
-		return
+		return nil, err
 	}
-
-	type result struct {
-		mangaWithNewChapters *manga.Manga
+	if onlyDue {
+		statusUpdateIntervals, err := manga.GetStatusUpdateIntervalsDB()
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		dueMultiMangas := []*manga.MultiManga{}
+		for _, multimanga := range multimangas {
+			if multimanga.IsDueForUpdate(now, statusUpdateIntervals) {
+				dueMultiMangas = append(dueMultiMangas, multimanga)
+			}
+		}
+		logger.Info().Msgf("%d of %d multimangas are due for update", len(dueMultiMangas), len(multimangas))
+		multimangas = dueMultiMangas
+	}
+	job.SetTotal(len(mangas) + len(multimangas))
+
+	type result struct {
+		mangaWithNewChapters *manga.Manga
+		item                 *jobs.Item
 		multimangaErrors     []string
 	}
 
@@ -2033,12 +2720,16 @@ func UpdateMangasMetadata(c *gin.Context) {
 		go func(chunk []*manga.Manga) {
 			defer wg.Done()
 			for _, mangaToUpdate := range chunk {
-				mangaWithNewChapters, multimangaErrors := updateCustomMangaMetadata(mangaToUpdate, retries, retryInterval, logger)
+				if ctx.Err() != nil {
+					return
+				}
+				mangaWithNewChapters, multimangaErrors := updateCustomMangaMetadata(mangaToUpdate, logger)
 				if mangaWithNewChapters != nil {
 					newMetadata = true
 				}
 				result := result{
 					mangaWithNewChapters: mangaWithNewChapters,
+					item:                 getUpdateJobItem(int(mangaToUpdate.ID), mangaToUpdate.Name, mangaWithNewChapters, multimangaErrors),
 					multimangaErrors:     multimangaErrors,
 				}
 				results <- result
@@ -2058,12 +2749,16 @@ func UpdateMangasMetadata(c *gin.Context) {
 		go func(chunk []*manga.MultiManga) {
 			defer wg.Done()
 			for _, multimangaToUpdate := range chunk {
-				mangaWithNewChapters, multimangaNewMetadata, multimangaErrors := updateMultiMangaMetadata(multimangaToUpdate, retries, retryInterval, logger)
+				if ctx.Err() != nil {
+					return
+				}
+				mangaWithNewChapters, multimangaNewMetadata, multimangaErrors := updateMultiMangaMetadata(multimangaToUpdate, true, logger)
 				if multimangaNewMetadata {
 					newMetadata = true
 				}
 				result := result{
 					mangaWithNewChapters: mangaWithNewChapters,
+					item:                 getUpdateJobItem(int(multimangaToUpdate.ID), multimangaToUpdate.CurrentManga.Name, mangaWithNewChapters, multimangaErrors),
 					multimangaErrors:     multimangaErrors,
 				}
 				results <- result
@@ -2077,6 +2772,7 @@ func UpdateMangasMetadata(c *gin.Context) {
 	}()
 
 	for res := range results {
+		job.AddItem(res.item)
 		if res.mangaWithNewChapters != nil {
 			mangasWithNewChapter = append(mangasWithNewChapter, res.mangaWithNewChapters)
 		}
@@ -2085,77 +2781,13 @@ func UpdateMangasMetadata(c *gin.Context) {
 		}
 	}
 
-	if newMetadata {
-		dashboard.UpdateDashboard()
+	if ctx.Err() != nil {
+		logger.Warn().Msg("Mangas metadata update canceled, the mangas already updated will still be notified and sent to the integrations")
 	}
 
-	for _, m := range mangasWithNewChapter {
-		// Notify only if the manga's status is 1 (reading) or 2 (completed)
-		if notify && (m.Status == 1 || m.Status == 2) {
-			for j := range retries {
-				err = NotifyMangaLastReleasedChapterUpdate(m)
-				if err != nil {
-					if j == retries-1 {
-						logger.Error().Err(err).Str("manga_url", m.URL).Msg(fmt.Sprintf("Manga metadata updated in DB, but error while notifying: %s.\nWill continue with the next manga...", err.Error()))
-						errors["ntfy"] = append(errors["ntfy"], err.Error())
-						break
-					}
-					logger.Error().Err(err).Str("manga_url", m.URL).Msgf("Manga metadata updated in DB, but error while notifying: %s.\nRetrying in %.2f seconds...", err.Error(), retryInterval.Seconds())
-					continue
-				}
-				break
-			}
-		}
-
-		if m.Source == manga.CustomMangaSource {
-			continue
-		}
+	handleMangasWithNewChapters(mangasWithNewChapter, newMetadata, notify, retryInterval, errors, logger)
 
-		if trangaInt != nil {
-			err = trangaInt.StartJob(m)
-			if err != nil {
-				logger.Error().Err(err).Str("manga_url", m.URL).Msg("Manga metadata updated in DB, but error starting job in Tranga.\nWill continue with the next manga...")
-				errors["tranga"] = append(errors["tranga"], err.Error())
-			}
-
-		}
-
-		if suwayomiInt != nil {
-			mangaID, err := suwayomiInt.GetLibraryMangaID(m)
-			if err != nil {
-				logger.Error().Err(err).Str("manga_url", m.URL).Msg("Manga metadata updated in DB, but error getting manga ID from Suwayomi.\nWill continue with the next manga...")
-				errors["suwayomi"] = append(errors["suwayomi"], err.Error())
-			} else {
-				chapter, err := suwayomiInt.GetChapter(mangaID, m.LastReleasedChapter.URL)
-				if err != nil {
-					logger.Error().Err(err).Str("manga_url", m.URL).Str("suwayomi_manga_id", strconv.Itoa(mangaID)).Msg("Manga metadata updated in DB, but error getting chapter from Suwayomi.\nWill continue with the next manga...")
-					errors["suwayomi"] = append(errors["suwayomi"], err.Error())
-				} else {
-					err = suwayomiInt.EnqueueChapterDownloads([]int{chapter.ID})
-					if err != nil {
-						logger.Error().Err(err).Str("manga_url", m.URL).Str("suwayomi_chapter_id", strconv.Itoa(chapter.ID)).Msg("Manga metadata updated in DB, but error updating chapter in Suwayomi.\nWill continue with the next manga...")
-						errors["suwayomi"] = append(errors["suwayomi"], err.Error())
-					}
-				}
-			}
-		}
-	}
-
-	if config.GlobalConfigs.Kaizoku.Valid && newMetadata {
-		err = KaizokuTriggerChaptersDownload(logger)
-		if err != nil {
-			errors["kaizoku"] = append(errors["kaizoku"], err.Error())
-		}
-	}
-
-	for _, errSlice := range errors {
-		if len(errSlice) > 0 {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "some errors occured while updating the mangas metadata, check the logs for more information", "errors": errors})
-			return
-		}
-	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "Mangas metadata updated successfully"})
+	return errors, nil
 }
 
 // @Summary Add mangas to Kaizoku
@@ -2382,12 +3014,18 @@ func AddMangasToSuwayomi(c *gin.Context) {
 }
 
 // @Summary Get library stats
-// @Description Get the library stats from all multimangas and custom mangas.
+// @Description Get the library stats from all multimangas and custom mangas. The UnreadChapters property is the sum of the unread chapters of all multimangas. If the request is made by a user, gets the stats of the user's library.
 // @Produce json
 // @Success 200 {map} map[string]int "{"property": value}"
 // @Router /mangas/stats [get]
 func GetLibraryStats(c *gin.Context) {
-	stats, err := manga.GetLibraryStats()
+	var stats map[string]int
+	var err error
+	if userID := auth.GetUserID(c); userID > 0 {
+		stats, err = manga.GetUserLibraryStats(userID)
+	} else {
+		stats, err = manga.GetLibraryStats()
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
@@ -2396,6 +3034,101 @@ func GetLibraryStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// @Summary Get status update intervals
+// @Description Get how often the multimangas of each status are checked for new chapters by the periodic update job, in minutes. Statuses without an update interval are checked on every run.
+// @Produce json
+// @Success 200 {object} map[string]int "{"intervals": {"1": 60, "4": 10080}}"
+// @Router /mangas/update_intervals [get]
+func GetStatusUpdateIntervals(c *gin.Context) {
+	intervals, err := manga.GetStatusUpdateIntervalsDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"intervals": intervals})
+}
+
+// @Summary Update status update interval
+// @Description Updates how often the multimangas of a status are checked for new chapters by the periodic update job. Multimangas with their own update interval are not affected. An update interval of 0 checks the multimangas on every run.
+// @Accept json
+// @Produce json
+// @Param update_interval body UpdateStatusUpdateIntervalRequest true "Status update interval"
+// @Success 200 {object} responseMessage
+// @Router /mangas/update_interval [patch]
+func UpdateStatusUpdateInterval(c *gin.Context) {
+	var requestData UpdateStatusUpdateIntervalRequest
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid JSON fields, refer to the API documentation"})
+		return
+	}
+
+	err := manga.UpdateStatusUpdateIntervalDB(requestData.Status, requestData.UpdateInterval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Status update interval updated successfully"})
+}
+
+// UpdateStatusUpdateIntervalRequest is the request body for the UpdateStatusUpdateInterval route.
+// The update interval is in minutes.
+type UpdateStatusUpdateIntervalRequest struct {
+	Status         manga.Status `json:"status" binding:"required,gte=1,lte=5"`
+	UpdateInterval int          `json:"updateInterval" binding:"gte=0"`
+}
+
+// getRequestMultiMangaFromDB gets a multimanga from the DB. If the request was made by a user,
+// gets the multimanga from the user's library, with the user's status and last read chapter.
+func getRequestMultiMangaFromDB(c *gin.Context, multimangaID manga.ID) (*manga.MultiManga, error) {
+	if userID := auth.GetUserID(c); userID > 0 {
+		return manga.GetUserMultiMangaFromDB(userID, multimangaID)
+	}
+
+	return manga.GetMultiMangaFromDB(multimangaID)
+}
+
+// getRequestMultiMangasDB gets all multimangas from the DB. If the request was made by a user,
+// gets only the multimangas in the user's library, with the user's status and last read chapter.
+func getRequestMultiMangasDB(c *gin.Context, getMangas bool) ([]*manga.MultiManga, error) {
+	if userID := auth.GetUserID(c); userID > 0 {
+		return manga.GetUserMultiMangasDB(userID, getMangas)
+	}
+
+	return manga.GetMultiMangasDB(getMangas)
+}
+
+// getRequestMangaDB gets a manga from the DB. If the request was made by a user,
+// the manga's multimanga must be in the user's library.
+func getRequestMangaDB(c *gin.Context, mangaID manga.ID, mangaURL string) (*manga.Manga, error) {
+	m, err := manga.GetMangaDB(mangaID, mangaURL)
+	if err != nil {
+		return nil, err
+	}
+	if auth.GetUserID(c) > 0 {
+		_, err = getRequestMultiMangaFromDB(c, m.MultiMangaID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// isMangaNotFoundError returns true if the error is because the manga isn't in
+// the DB or its multimanga isn't in the user's library.
+func isMangaNotFoundError(err error) bool {
+	return strings.Contains(err.Error(), errordefs.ErrMangaNotFoundDB.Error()) || isMultiMangaNotFoundError(err)
+}
+
+// isMultiMangaNotFoundError returns true if the error is because the multimanga
+// isn't in the DB or isn't in the user's library.
+func isMultiMangaNotFoundError(err error) bool {
+	return strings.Contains(err.Error(), errordefs.ErrMultiMangaNotFoundDB.Error()) ||
+		strings.Contains(err.Error(), errordefs.ErrMultiMangaNotInUserLibrary.Error())
+}
+
 func getMangaIDAndURL(mangaIDStr string, mangaURL string) (manga.ID, string, error) {
 	if mangaIDStr == "" && mangaURL == "" {
 		err := fmt.Errorf("you must provide either the manga ID or the manga URL")
@@ -2421,58 +3154,42 @@ func getMangaIDAndURL(mangaIDStr string, mangaURL string) (manga.ID, string, err
 }
 
 // NotifyMangaLastReleasedChapterUpdate notifies a manga last released chapter update
-func NotifyMangaLastReleasedChapterUpdate(m *manga.Manga) error {
-	publisher, err := ntfy.GetNtfyPublisher()
-	if err != nil {
-		return err
-	}
-
-	title := fmt.Sprintf("(Mantium) New chapter of manga: %s", m.Name)
-
-	message := fmt.Sprintf("New chapter: %s", m.LastReleasedChapter.Chapter)
-
-	chapterLink, err := url.Parse(m.LastReleasedChapter.URL)
-	if err != nil {
-		return err
-	}
-
-	msg := &gotfy.Message{
-		Topic:   publisher.Topic,
-		Title:   title,
-		Message: message,
-		Actions: []gotfy.ActionButton{
-			&gotfy.ViewAction{
-				Label: "Open Chapter",
-				Link:  chapterLink,
-				Clear: false,
-			},
-		},
-		ClickURL: chapterLink,
-	}
-
-	ctx := context.Background()
-	err = publisher.SendMessage(ctx, msg)
-	if err != nil {
-		return err
-	}
-
-	return nil
+// using all enabled notifiers, or the notifier and topic set in the notification rules if not nil.
+// The notification is built with the notification templates, and mm is the manga's multimanga (nil for custom mangas).
+// It returns a map with the notifier name as key and the error as value for each notifier
+// that couldn't send the notification. If the templates can't be rendered, the notification is
+// sent using the default templates and the templates error is returned.
+func NotifyMangaLastReleasedChapterUpdate(m *manga.Manga, mm *manga.MultiManga, rules *manga.NotificationRules, retryInterval time.Duration) (map[string]error, error) {
+	notification, templatesErr := notifiers.NewChapterNotification(notifiers.NewTemplateData(m, mm))
+	if notification == nil {
+		return nil, templatesErr
+	}
+	if rules != nil {
+		notification.Notifier = rules.Notifier
+		notification.Topic = rules.Topic
+	}
+
+	return notifiers.NotifyAll(context.Background(), notification, retryInterval), templatesErr
 }
 
+// isNewChapterDifferentFromOld returns true if the new chapter is not the old chapter.
+// Chapters with the same URL or internal ID are the same chapter, even if they were renamed.
+// Otherwise, the chapter numbers are compared, so a re-uploaded chapter is the same chapter.
 func isNewChapterDifferentFromOld(oldChapter, newChapter *manga.Chapter, source string) bool {
-	if oldChapter == nil && newChapter != nil {
-		return true
+	if oldChapter == nil || newChapter == nil {
+		return oldChapter == nil && newChapter != nil
 	}
-	if oldChapter != nil && newChapter != nil && oldChapter.Chapter != newChapter.Chapter {
+	if source == manga.CustomMangaSource && oldChapter.URL != newChapter.URL {
 		return true
 	}
-	if source == manga.CustomMangaSource {
-		if oldChapter != nil && newChapter != nil && oldChapter.URL != newChapter.URL {
-			return true
-		}
+	if oldChapter.URL != "" && oldChapter.URL == newChapter.URL {
+		return false
+	}
+	if oldChapter.InternalID != "" && oldChapter.InternalID == newChapter.InternalID {
+		return false
 	}
 
-	return false
+	return !manga.IsSameChapter(oldChapter, newChapter)
 }
 
 func KaizokuTriggerChaptersDownload(logger *zerolog.Logger) error {
@@ -2591,40 +3308,57 @@ func getCheckFixOutOfSyncChaptersActiveWaitingJobs(kaizoku *kaizoku.Kaizoku) (in
 // updateMultiMangaMetadata gets the manga metadata from the sources for all the multimanga' mangas and updates it in the database.
 // Returns the updated current manga if the current manga has a new released chapter, else nil.
 // Also returns a bool indicating if any metadata was updated and a slice of errors.
-func updateMultiMangaMetadata(multimanga *manga.MultiManga, retries int, retryInterval time.Duration, logger *zerolog.Logger) (*manga.Manga, bool, []string) {
-	var err error
+// The failed requests to the sources are retried by the sources' HTTP transport, see util.SourcesHTTPConfigs.
+// If skipDownSources is true, the mangas from sources that are down are skipped, see sources.IsSourceDown.
+// The result is recorded in the multimanga's update status if at least one manga is checked.
+// The multimanga's last checked at is updated only if at least one manga is checked and there are no errors,
+// so a multimanga whose mangas were all skipped or failed is checked again in the next run.
+func updateMultiMangaMetadata(multimanga *manga.MultiManga, skipDownSources bool, logger *zerolog.Logger) (*manga.Manga, bool, []string) {
 	var errors []string
 	var newMetadata bool
 	var mangasHaveNewChapter bool
+	var checked bool
+	defer func() {
+		if !checked {
+			return
+		}
+		checkedAt := time.Now()
+		err := multimanga.RecordUpdateResultInDB(checkedAt, errors)
+		if err != nil {
+			logger.Error().Err(err).Str("multimanga_id", multimanga.ID.String()).Msg("Error saving multimanga update status to DB")
+		}
+		if len(errors) > 0 {
+			return
+		}
+		err = multimanga.UpdateLastCheckedAtInDB(checkedAt)
+		if err != nil {
+			logger.Error().Err(err).Str("multimanga_id", multimanga.ID.String()).Msg("Error saving multimanga last checked at to DB")
+		}
+	}()
 
 	for _, mangaToUpdate := range multimanga.Mangas {
 		if mangaToUpdate.Source == manga.CustomMangaSource {
 			continue
 		}
-
-		var updatedManga *manga.Manga
-		for i := 0; i < retries; i++ {
-			updatedManga, err = sources.GetMangaMetadata(mangaToUpdate.URL, mangaToUpdate.InternalID)
-			if err != nil {
-				if i != retries-1 {
-					logger.Error().Err(err).Str("manga_url", mangaToUpdate.URL).Msgf("Error getting manga metadata, retrying in %.2f seconds...", retryInterval.Seconds())
-					time.Sleep(retryInterval)
-					continue
-				}
-				break
-			}
-			if len(updatedManga.CoverImg) == 0 {
-				continue
-			}
-			break
+		if skipDownSources && sources.IsSourceDown(mangaToUpdate.Source) {
+			logger.Warn().Str("manga_url", mangaToUpdate.URL).Str("source", mangaToUpdate.Source).Msg("Source is down, skipping the manga until the source recovers...")
+			continue
 		}
-		if updatedManga == nil {
+		checked = true
+
+		updatedManga, err := sources.GetMangaMetadataWithFilter(mangaToUpdate.URL, mangaToUpdate.InternalID, models.NewChapterFilter(mangaToUpdate))
+		if err != nil {
 			logger.Error().Err(err).Str("manga_url", mangaToUpdate.URL).Msg("Error getting manga metadata, will continue with the next manga...")
 			errors = append(errors, err.Error())
 			continue
 		}
 
 		// Turn manga into valid manga to update DB
+		// If the cover image download failed, keep the current cover image
+		// instead of replacing it with the default one.
+		if len(updatedManga.CoverImg) == 0 && len(mangaToUpdate.CoverImg) != 0 {
+			updatedManga.CoverImg, updatedManga.CoverImgResized, updatedManga.CoverImgURL = mangaToUpdate.CoverImg, mangaToUpdate.CoverImgResized, mangaToUpdate.CoverImgURL
+		}
 		if len(updatedManga.CoverImg) == 0 {
 			updatedManga.CoverImg, err = util.GetDefaultCoverImg()
 			if err != nil {
@@ -2636,6 +3370,7 @@ func updateMultiMangaMetadata(multimanga *manga.MultiManga, retries int, retryIn
 		}
 		updatedManga.Status = multimanga.Status
 		updatedManga.ID = mangaToUpdate.ID
+		updatedManga.PreferredGroup = mangaToUpdate.PreferredGroup
 
 		mangaHasNewReleasedChapter := isNewChapterDifferentFromOld(mangaToUpdate.LastReleasedChapter, updatedManga.LastReleasedChapter, mangaToUpdate.Source)
 		if mangaHasNewReleasedChapter || (!mangaToUpdate.CoverImgFixed && (mangaToUpdate.CoverImgURL != updatedManga.CoverImgURL || !bytes.Equal(mangaToUpdate.CoverImg, updatedManga.CoverImg))) || mangaToUpdate.Name != updatedManga.Name {
@@ -2656,6 +3391,12 @@ func updateMultiMangaMetadata(multimanga *manga.MultiManga, retries int, retryIn
 				continue
 			}
 			newMetadata = true
+
+			if mangaHasNewReleasedChapter && manga.MayHaveChaptersBetween(mangaToUpdate.LastReleasedChapter, updatedManga.LastReleasedChapter) {
+				// More than one chapter could have been released since the last update. The chapter
+				// list is only requested in this case, as the new last released chapter is already stored.
+				updateMangaChaptersHistory(updatedManga, mangaToUpdate.LastReleasedChapter, logger)
+			}
 		}
 	}
 	if mangasHaveNewChapter {
@@ -2674,7 +3415,7 @@ func updateMultiMangaMetadata(multimanga *manga.MultiManga, retries int, retryIn
 		if updatedMultimanga.CurrentManga.LastReleasedChapter != nil {
 			if multimanga.CurrentManga.LastReleasedChapter == nil {
 				return updatedMultimanga.CurrentManga, newMetadata, errors
-			} else if updatedMultimanga.CurrentManga.LastReleasedChapter.Chapter != multimanga.CurrentManga.LastReleasedChapter.Chapter {
+			} else if !manga.IsSameChapter(updatedMultimanga.CurrentManga.LastReleasedChapter, multimanga.CurrentManga.LastReleasedChapter) {
 				return updatedMultimanga.CurrentManga, newMetadata, errors
 			}
 		}
@@ -2683,8 +3424,285 @@ func updateMultiMangaMetadata(multimanga *manga.MultiManga, retries int, retryIn
 	return nil, newMetadata, errors
 }
 
+// newUpdateMangasMetadataErrors returns the map used to group the errors that occur
+// while updating the mangas metadata by where they occurred.
+func newUpdateMangasMetadataErrors() map[string][]string {
+	errors := map[string][]string{
+		"manga_metadata": {},
+		"ntfy":           {},
+		"tranga":         {},
+		"kaizoku":        {},
+		"suwayomi":       {},
+	}
+	for _, name := range notifiers.GetNotifiersNames() {
+		errors[name] = []string{}
+	}
+
+	return errors
+}
+
+// handleMangasWithNewChapters updates the dashboard if there is new metadata, notifies about the
+// mangas with new chapters, and sends them to the download integrations.
+// The errors are added to the errors map, see newUpdateMangasMetadataErrors.
+func handleMangasWithNewChapters(mangasWithNewChapter []*manga.Manga, newMetadata, notify bool, retryInterval time.Duration, errors map[string][]string, logger *zerolog.Logger) {
+	if newMetadata {
+		dashboard.UpdateDashboard()
+	}
+
+	var trangaInt *tranga.Tranga
+	if config.GlobalConfigs.Tranga.Valid {
+		trangaInt = &tranga.Tranga{}
+		trangaInt.Init()
+	}
+	var suwayomiInt *suwayomi.Suwayomi
+	if config.GlobalConfigs.Suwayomi.Valid {
+		suwayomiInt = &suwayomi.Suwayomi{}
+		suwayomiInt.Init()
+	}
+
+	for _, m := range mangasWithNewChapter {
+		// Notify only if the manga's status is 1 (reading) or 2 (completed)
+		if notify && (m.Status == 1 || m.Status == 2) {
+			notifyMangaWithNewChapter(m, retryInterval, errors, logger)
+		}
+
+		if m.Source == manga.CustomMangaSource {
+			continue
+		}
+
+		if trangaInt != nil {
+			err := trangaInt.StartJob(m)
+			if err != nil {
+				logger.Error().Err(err).Str("manga_url", m.URL).Msg("Manga metadata updated in DB, but error starting job in Tranga.\nWill continue with the next manga...")
+				errors["tranga"] = append(errors["tranga"], err.Error())
+			}
+
+		}
+
+		if suwayomiInt != nil {
+			mangaID, err := suwayomiInt.GetLibraryMangaID(m)
+			if err != nil {
+				logger.Error().Err(err).Str("manga_url", m.URL).Msg("Manga metadata updated in DB, but error getting manga ID from Suwayomi.\nWill continue with the next manga...")
+				errors["suwayomi"] = append(errors["suwayomi"], err.Error())
+			} else {
+				chapter, err := suwayomiInt.GetChapter(mangaID, m.LastReleasedChapter.URL)
+				if err != nil {
+					logger.Error().Err(err).Str("manga_url", m.URL).Str("suwayomi_manga_id", strconv.Itoa(mangaID)).Msg("Manga metadata updated in DB, but error getting chapter from Suwayomi.\nWill continue with the next manga...")
+					errors["suwayomi"] = append(errors["suwayomi"], err.Error())
+				} else {
+					err = suwayomiInt.EnqueueChapterDownloads([]int{chapter.ID})
+					if err != nil {
+						logger.Error().Err(err).Str("manga_url", m.URL).Str("suwayomi_chapter_id", strconv.Itoa(chapter.ID)).Msg("Manga metadata updated in DB, but error updating chapter in Suwayomi.\nWill continue with the next manga...")
+						errors["suwayomi"] = append(errors["suwayomi"], err.Error())
+					}
+				}
+			}
+		}
+	}
+
+	if notify {
+		sendPendingNotifications(retryInterval, errors, logger)
+	}
+
+	if config.GlobalConfigs.Kaizoku.Valid && newMetadata {
+		err := KaizokuTriggerChaptersDownload(logger)
+		if err != nil {
+			errors["kaizoku"] = append(errors["kaizoku"], err.Error())
+		}
+	}
+}
+
+// notifyMangaWithNewChapter notifies about the manga's last released chapter if its multimanga's
+// notification rules allow it. Custom mangas use the default notification rules.
+// In digest mode or in the multimanga's quiet hours, the notification is stored as a pending notification
+// to be sent by sendPendingNotifications.
+// The errors are added to the errors map, see newUpdateMangasMetadataErrors.
+func notifyMangaWithNewChapter(m *manga.Manga, retryInterval time.Duration, errors map[string][]string, logger *zerolog.Logger) {
+	rules := manga.NewNotificationRules(m.MultiMangaID)
+	if m.MultiMangaID > 0 {
+		var err error
+		rules, err = manga.GetNotificationRulesFromDB(m.MultiMangaID)
+		if err != nil {
+			logger.Error().Err(err).Str("manga_url", m.URL).Msg("Manga metadata updated in DB, but error getting notification rules, will use the default rules...")
+			errors["manga_metadata"] = append(errors["manga_metadata"], err.Error())
+			rules = manga.NewNotificationRules(m.MultiMangaID)
+		}
+	}
+
+	if shouldNotify, reason := rules.ShouldNotify(m.LastReleasedChapter); !shouldNotify {
+		logger.Debug().Str("manga_url", m.URL).Str("reason", reason).Msg("Not notifying about the manga's new chapter")
+		return
+	}
+
+	var notified bool
+	now := time.Now()
+	isQuietHour := rules.IsQuietHour(now)
+	if (config.GlobalConfigs.NotificationDigest.Enabled || isQuietHour) && m.LastReleasedChapter != nil {
+		pendingNotification := &manga.PendingNotification{
+			MangaID:   m.ID,
+			MangaName: m.Name,
+			Chapter:   m.LastReleasedChapter.Chapter,
+			URL:       m.LastReleasedChapter.URL,
+			Notifier:  rules.Notifier,
+			Topic:     rules.Topic,
+		}
+		if isQuietHour {
+			pendingNotification.SendAfter = rules.QuietHoursEndAt(now)
+			logger.Debug().Str("manga_url", m.URL).Time("send_after", pendingNotification.SendAfter).Msg("Quiet hours, delaying the notification about the manga's new chapter")
+		}
+		err := pendingNotification.InsertIntoDB()
+		if err != nil {
+			logger.Error().Err(err).Str("manga_url", m.URL).Msg("Manga metadata updated in DB, but error adding the notification to the digest.\nWill continue with the next manga...")
+			errors["manga_metadata"] = append(errors["manga_metadata"], err.Error())
+		}
+		notified = err == nil
+	} else {
+		var multimanga *manga.MultiManga
+		if m.MultiMangaID > 0 {
+			var err error
+			multimanga, err = manga.GetMultiMangaFromDB(m.MultiMangaID)
+			if err != nil {
+				logger.Error().Err(err).Str("manga_url", m.URL).Msg("Error getting the manga's multimanga to build the notification, will notify without it...")
+				errors["manga_metadata"] = append(errors["manga_metadata"], err.Error())
+			}
+		}
+
+		notifiersErrors, err := NotifyMangaLastReleasedChapterUpdate(m, multimanga, rules, retryInterval)
+		if err != nil {
+			logger.Error().Err(err).Str("manga_url", m.URL).Msg("Error building the notification with the notification templates, the default templates were used")
+			errors["manga_metadata"] = append(errors["manga_metadata"], err.Error())
+		}
+		for notifierName, err := range notifiersErrors {
+			logger.Error().Err(err).Str("manga_url", m.URL).Str("notifier", notifierName).Msg("Manga metadata updated in DB, but error while notifying.\nWill continue with the next manga...")
+			errors[notifierName] = append(errors[notifierName], err.Error())
+		}
+		notified = len(notifiersErrors) == 0
+	}
+
+	if m.MultiMangaID > 0 && m.LastReleasedChapter != nil && notified {
+		err := rules.UpdateLastNotifiedChapterInDB(m.LastReleasedChapter.Chapter)
+		if err != nil {
+			logger.Error().Err(err).Str("manga_url", m.URL).Msg("Manga notified, but error updating the last notified chapter in DB")
+			errors["manga_metadata"] = append(errors["manga_metadata"], err.Error())
+		}
+	}
+}
+
+// sendPendingNotifications sends the pending notifications that can be sent as digest notifications.
+// In digest mode, they're sent only if the digest window has passed since the oldest of them.
+// The pending notifications delayed by the quiet hours can be sent after the quiet hours end.
+// The pending notifications are grouped by their notifier and topic, and are deleted after being
+// sent by at least one notifier.
+// The errors are added to the errors map, see newUpdateMangasMetadataErrors.
+func sendPendingNotifications(retryInterval time.Duration, errors map[string][]string, logger *zerolog.Logger) {
+	allPendingNotifications, err := manga.GetPendingNotificationsFromDB()
+	if err != nil {
+		logger.Error().Err(err).Msg("Error getting pending notifications to send the notification digest")
+		errors["manga_metadata"] = append(errors["manga_metadata"], err.Error())
+		return
+	}
+	var window time.Duration
+	if config.GlobalConfigs.NotificationDigest.Enabled {
+		window = time.Duration(config.GlobalConfigs.NotificationDigest.WindowMinutes) * time.Minute
+	}
+	pendingNotifications := manga.GetPendingNotificationsToSend(allPendingNotifications, time.Now(), window)
+	if len(pendingNotifications) == 0 {
+		return
+	}
+
+	type digestTarget struct {
+		notifier string
+		topic    string
+	}
+	targets := []digestTarget{}
+	groups := map[digestTarget][]*manga.PendingNotification{}
+	for _, pendingNotification := range pendingNotifications {
+		target := digestTarget{notifier: pendingNotification.Notifier, topic: pendingNotification.Topic}
+		if _, ok := groups[target]; !ok {
+			targets = append(targets, target)
+		}
+		groups[target] = append(groups[target], pendingNotification)
+	}
+
+	notifiersNames := notifiers.GetNotifiersNames()
+	for _, target := range targets {
+		chapters := make([]*notifiers.NotificationChapter, 0, len(groups[target]))
+		ids := make([]int, 0, len(groups[target]))
+		for _, pendingNotification := range groups[target] {
+			chapters = append(chapters, &notifiers.NotificationChapter{
+				MangaName: pendingNotification.MangaName,
+				Chapter:   pendingNotification.Chapter,
+				URL:       pendingNotification.URL,
+			})
+			ids = append(ids, pendingNotification.ID)
+		}
+
+		notification := notifiers.NewDigestNotification(chapters)
+		notification.Notifier = target.notifier
+		notification.Topic = target.topic
+		notifiersErrors := notifiers.NotifyAll(context.Background(), notification, retryInterval)
+		for notifierName, err := range notifiersErrors {
+			logger.Error().Err(err).Str("notifier", notifierName).Msg("Error while sending notification digest")
+			errors[notifierName] = append(errors[notifierName], err.Error())
+		}
+
+		targetedNotifiers := len(notifiersNames)
+		if target.notifier != "" {
+			targetedNotifiers = 1
+		}
+		if targetedNotifiers > 0 && len(notifiersErrors) >= targetedNotifiers {
+			// Keep the pending notifications to try again in the next digest
+			continue
+		}
+
+		err = manga.DeletePendingNotificationsFromDB(ids)
+		if err != nil {
+			logger.Error().Err(err).Msg("Notification digest sent, but error deleting the pending notifications from DB")
+			errors["manga_metadata"] = append(errors["manga_metadata"], err.Error())
+		}
+	}
+}
+
+// getUpdateJobItem returns the job item of a multimanga or custom manga updated by UpdateAllMangasMetadata.
+func getUpdateJobItem(id int, name string, mangaWithNewChapters *manga.Manga, errors []string) *jobs.Item {
+	item := &jobs.Item{
+		ID:     id,
+		Name:   name,
+		Status: jobs.ItemStatusChecked,
+		Errors: errors,
+	}
+	if item.Errors == nil {
+		item.Errors = []string{}
+	}
+	if len(errors) > 0 {
+		item.Status = jobs.ItemStatusFailed
+	} else if mangaWithNewChapters != nil {
+		item.Status = jobs.ItemStatusNewChapter
+	}
+
+	return item
+}
+
+// updateMangaChaptersHistory gets the manga chapters from the source and stores the chapters released
+// after the old last released chapter in the chapters history.
+// Errors are only logged, as the chapters history is not essential to update the manga metadata.
+func updateMangaChaptersHistory(m *manga.Manga, oldLastReleasedChapter *manga.Chapter, logger *zerolog.Logger) {
+	chapters, err := sources.GetMangaChaptersWithFilter(m.URL, m.InternalID, models.NewChapterFilter(m))
+	if err != nil {
+		logger.Error().Err(err).Str("manga_url", m.URL).Msg("Error getting manga chapters to update the chapters history")
+		return
+	}
+
+	err = m.UpsertChaptersHistoryIntoDB(manga.ChaptersBetween(chapters, oldLastReleasedChapter, m.LastReleasedChapter))
+	if err != nil {
+		logger.Error().Err(err).Str("manga_url", m.URL).Msg("Error saving manga chapters history to DB")
+	}
+}
+
 // updateCustomMangaMetadata gets the custom manga last released chapter metadata and updates it in the database.
-func updateCustomMangaMetadata(m *manga.Manga, retries int, retryInterval time.Duration, logger *zerolog.Logger) (*manga.Manga, []string) {
+// The result is recorded in the custom manga's update status if the manga has a URL and selectors.
+// The failed requests are retried by the sources' HTTP transport, see util.SourcesHTTPConfigs.
+func updateCustomMangaMetadata(m *manga.Manga, logger *zerolog.Logger) (*manga.Manga, []string) {
 	var err error
 	var errors []string
 	var chapter *manga.Chapter
@@ -2692,27 +3710,15 @@ func updateCustomMangaMetadata(m *manga.Manga, retries int, retryInterval time.D
 	if strings.HasPrefix(m.URL, manga.CustomMangaURLPrefix) || (m.LastReleasedChapterNameSelector == nil && m.LastReleasedChapterURLSelector == nil) {
 		return nil, errors
 	}
-
-	for i := 0; i < retries; i++ {
-		chapter, err = manga.GetCustomMangaLastReleasedChapter(m.URL, m.LastReleasedChapterNameSelector, m.LastReleasedChapterURLSelector, m.LastReleasedChapterSelectorUseBrowser)
+	defer func() {
+		err := m.RecordUpdateResultInDB(time.Now(), errors)
 		if err != nil {
-			if i != retries-1 {
-				nameSelector, URLSelector := &manga.HTMLSelector{}, &manga.HTMLSelector{}
-				if m.LastReleasedChapterNameSelector != nil {
-					nameSelector = m.LastReleasedChapterNameSelector
-				}
-				if m.LastReleasedChapterURLSelector != nil {
-					URLSelector = m.LastReleasedChapterURLSelector
-				}
-				logger.Error().Err(err).Str("manga_url", m.URL).Str("name_selector", nameSelector.String()).Str("url_selector", URLSelector.String()).Msgf("Error getting custom manga last released chapter metadata, retrying in %.2f seconds...", retryInterval.Seconds())
-				time.Sleep(retryInterval)
-				continue
-			}
-			break
+			logger.Error().Err(err).Str("manga_url", m.URL).Msg("Error saving custom manga update status to DB")
 		}
-		break
-	}
-	if chapter == nil {
+	}()
+
+	chapter, err = manga.GetCustomMangaLastReleasedChapter(m.URL, m.LastReleasedChapterNameSelector, m.LastReleasedChapterURLSelector, m.LastReleasedChapterSelectorUseBrowser)
+	if err != nil {
 		if m.LastReleasedChapterNameSelector == nil {
 			m.LastReleasedChapterNameSelector = &manga.HTMLSelector{}
 		}
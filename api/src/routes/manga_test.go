@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/diogovalentte/mantium/api/src"
 	"github.com/diogovalentte/mantium/api/src/config"
@@ -714,6 +715,20 @@ func TestCustomMangaLifeCycle(t *testing.T) {
 			t.Fatalf(`expected message "%s", got "%s"`, expected, actual)
 		}
 	})
+	t.Run("Update custom manga metadata", func(t *testing.T) {
+		test := addCustomMangaRequestWithLastReasedChapter
+		var resMap map[string]string
+		err := requestHelper(http.MethodPatch, fmt.Sprintf("/v1/custom_manga/metadata?url=%s", test.URL), nil, &resMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := resMap["message"]
+		expected := "Custom manga metadata updated successfully"
+		if actual != expected {
+			t.Fatalf(`expected message "%s", got "%s"`, expected, actual)
+		}
+	})
 	t.Run("Update custom manga name", func(t *testing.T) {
 		test := addCustomMangaRequestWithNextChapter
 		newName := "Test"
@@ -1552,6 +1567,36 @@ func TestMultiMangaLifeCycle(t *testing.T) {
 			t.Fatalf(`expected at least 1 chapter, got %d`, len(chapters))
 		}
 	})
+	t.Run("Get chapters history of a multimanga", func(t *testing.T) {
+		var resMap map[string][]manga.HistoryChapter
+		err := requestHelper(http.MethodGet, fmt.Sprintf("/v1/multimanga/chapters/history?id=%d&manga_id=%d", multimanga.ID, multimanga.CurrentManga.ID), nil, &resMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		chapters := resMap["chapters"]
+		if len(chapters) < 1 {
+			t.Fatalf(`expected at least 1 chapter in the chapters history, got %d`, len(chapters))
+		}
+		for _, chapter := range chapters {
+			if chapter.MangaID != multimanga.CurrentManga.ID || chapter.FirstSeenAt.IsZero() {
+				t.Fatalf(`expected chapter of manga %d with first seen time, got %s`, multimanga.CurrentManga.ID, chapter)
+			}
+		}
+	})
+	t.Run("Don't get chapters history of a manga not in the multimanga", func(t *testing.T) {
+		var resMap map[string]string
+		err := requestHelper(http.MethodGet, fmt.Sprintf("/v1/multimanga/chapters/history?id=%d&manga_id=%d", multimanga.ID, -1), nil, &resMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := resMap["message"]
+		expected := errordefs.ErrMangaNotFoundInMultiManga.Error()
+		if actual != expected {
+			t.Fatalf(`expected message "%s", got "%s"`, expected, actual)
+		}
+	})
 	t.Run("Update a multimanga status", func(t *testing.T) {
 		body, err := json.Marshal(routes.UpdateMangaStatusRequest{Status: 4})
 		if err != nil {
@@ -1762,7 +1807,7 @@ func TestMultiMangaLifeCycle(t *testing.T) {
 func TestUpdateMangasMetadata(t *testing.T) {
 	t.Run("Update all mangas metadata", func(t *testing.T) {
 		var resMap map[string]string
-		err := requestHelper(http.MethodPatch, "/v1/mangas/metadata", nil, &resMap)
+		err := requestHelper(http.MethodPatch, "/v1/mangas/metadata?wait=true", nil, &resMap)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1803,9 +1848,12 @@ func TestNotifyMangaLastReleasedChapterUpdate(t *testing.T) {
 			},
 		}
 
-		err := routes.NotifyMangaLastReleasedChapterUpdate(m)
+		errors, err := routes.NotifyMangaLastReleasedChapterUpdate(m, nil, nil, 3*time.Second)
 		if err != nil {
 			t.Fatal(err)
 		}
+		for notifierName, err := range errors {
+			t.Fatalf("error while notifying with %s: %s", notifierName, err)
+		}
 	})
 }
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/diogovalentte/mantium/api/src/auth"
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/dashboard"
+	"github.com/diogovalentte/mantium/api/src/integrations/mangaupdates"
+	"github.com/diogovalentte/mantium/api/src/manga"
+)
+
+// MangaUpdatesRoutes sets the routes for the MangaUpdates lists integration.
+func MangaUpdatesRoutes(group *gin.RouterGroup) {
+	{
+		group.POST("/mangaupdates/push", PushMangaUpdatesLists)
+		group.POST("/mangaupdates/import", ImportMangaUpdatesLists)
+	}
+}
+
+// @Summary Push to MangaUpdates lists
+// @Description Pushes the status and last read chapter of all multimangas with a manga from the MangaUpdates source to the MangaUpdates lists. A series is moved to the list of the multimanga's status (reading, complete, on hold, unfinished, or wish list). The MangaUpdates read progress is never decreased.
+// @Produce json
+// @Success 200 {object} responseMessage
+// @Router /mangaupdates/push [post]
+func PushMangaUpdatesLists(c *gin.Context) {
+	if !isMangaUpdatesRequestAllowed(c) {
+		return
+	}
+
+	multimangas, err := manga.GetMultiMangasDB(true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	m := mangaupdates.MangaUpdates{}
+	m.Init()
+	pushed, errs := m.PushMultiMangas(multimangas)
+	if len(errs) > 0 {
+		errMessages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			errMessages = append(errMessages, err.Error())
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": fmt.Sprintf("%d multimangas pushed, but some errors occurred while pushing to MangaUpdates: %s", pushed, strings.Join(errMessages, "; "))})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Multimangas pushed to MangaUpdates successfully, %d multimangas pushed", pushed)})
+}
+
+// @Summary Import MangaUpdates lists
+// @Description Imports the series in the MangaUpdates default lists (reading, wish, complete, unfinished, and on hold) into the library as multimangas with a manga from the MangaUpdates source. The multimanga's status is the status of the series list, and its last read chapter is the series read progress. The series already in the library are skipped.
+// @Produce json
+// @Param dry_run query bool false "If true, returns what would be imported, without changing the library." Example(true)
+// @Success 200 {object} library.ListImportReport "{"message": "MangaUpdates lists imported successfully", "report": reportObj}"
+// @Router /mangaupdates/import [post]
+func ImportMangaUpdatesLists(c *gin.Context) {
+	if !isMangaUpdatesRequestAllowed(c) {
+		return
+	}
+
+	dryRun := false
+	dryRunStr := c.Query("dry_run")
+	if dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "dry_run must be a boolean"})
+			return
+		}
+	}
+
+	m := mangaupdates.MangaUpdates{}
+	m.Init()
+	report, err := m.ImportLists(dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	if !dryRun && len(report.Added) > 0 {
+		dashboard.UpdateDashboard()
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "MangaUpdates lists imported successfully", "report": report})
+}
+
+// pushMultiMangaToMangaUpdates pushes the multimanga's status and last read chapter to
+// the MangaUpdates lists. It does nothing if the MangaUpdates integration is disabled,
+// the request is made by a user, as the integration only syncs the global library,
+// or the multimanga doesn't have a manga from MangaUpdates.
+// The push is best-effort like the AniList push. Returns a warning for the response
+// if the multimanga isn't pushed because it doesn't have a manga from MangaUpdates.
+func pushMultiMangaToMangaUpdates(c *gin.Context, mm *manga.MultiManga) string {
+	if !config.GlobalConfigs.MangaUpdates.Valid || auth.GetUserID(c) > 0 {
+		return ""
+	}
+
+	m := mangaupdates.MangaUpdates{}
+	m.Init()
+	pushed, err := m.PushMultiManga(mm)
+	if err != nil {
+		reportPushError(c, "MangaUpdates", mm, err)
+		return ""
+	}
+	if !pushed {
+		return "the multimanga doesn't have a manga from MangaUpdates, so it was not pushed to the MangaUpdates lists"
+	}
+
+	return ""
+}
+
+// isMangaUpdatesRequestAllowed returns true if the MangaUpdates integration is enabled and the request
+// is not made by a user. Otherwise, it sets the error response and returns false.
+func isMangaUpdatesRequestAllowed(c *gin.Context) bool {
+	if !config.GlobalConfigs.MangaUpdates.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "the MangaUpdates integration is not enabled"})
+		return false
+	}
+	if auth.GetUserID(c) > 0 {
+		c.JSON(http.StatusForbidden, gin.H{"message": "the MangaUpdates integration only syncs the global library"})
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,140 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/scheduler"
+)
+
+// SchedulerRoutes sets the routes for the background jobs scheduler.
+func SchedulerRoutes(group *gin.RouterGroup) {
+	{
+		group.GET("/scheduler/jobs", GetSchedulerJobs)
+		group.GET("/scheduler/job", GetSchedulerJob)
+		group.POST("/scheduler/job/trigger", TriggerSchedulerJob)
+		group.PATCH("/scheduler/job/pause", PauseSchedulerJob)
+		group.PATCH("/scheduler/job/resume", ResumeSchedulerJob)
+	}
+}
+
+// @Summary Get scheduler jobs
+// @Description Returns all background jobs registered in the scheduler, with their schedule, next run, last run, and runs history.
+// @Success 200 {array} scheduler.Job "{"jobs": [jobObj]}"
+// @Produce json
+// @Router /scheduler/jobs [get]
+func GetSchedulerJobs(c *gin.Context) {
+	jobs := scheduler.DefaultScheduler.GetJobs()
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
+
+// @Summary Get scheduler job
+// @Description Returns a background job registered in the scheduler, with its schedule, next run, last run, and runs history.
+// @Success 200 {object} scheduler.Job "{"job": jobObj}"
+// @Produce json
+// @Param name query string true "Job name" Example(update_mangas_metadata)
+// @Router /scheduler/job [get]
+func GetSchedulerJob(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "name must be provided"})
+		return
+	}
+
+	job, err := scheduler.DefaultScheduler.GetJob(name)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrJobNotFound.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+// @Summary Trigger scheduler job
+// @Description Runs a background job right away, even if it's paused. The job runs in the background, so the request returns before the job finishes. It doesn't change the job's next run.
+// @Success 200 {object} responseMessage
+// @Produce json
+// @Param name query string true "Job name" Example(update_mangas_metadata)
+// @Router /scheduler/job/trigger [post]
+func TriggerSchedulerJob(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "name must be provided"})
+		return
+	}
+
+	err := scheduler.DefaultScheduler.TriggerJob(name)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrJobNotFound.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), errordefs.ErrJobAlreadyRunning.Error()) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job triggered successfully"})
+}
+
+// @Summary Pause scheduler job
+// @Description Pauses a background job. It will not run until resumed, but can still be triggered manually. Pausing a running job doesn't stop the current run.
+// @Success 200 {object} responseMessage
+// @Produce json
+// @Param name query string true "Job name" Example(update_mangas_metadata)
+// @Router /scheduler/job/pause [patch]
+func PauseSchedulerJob(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "name must be provided"})
+		return
+	}
+
+	err := scheduler.DefaultScheduler.PauseJob(name)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrJobNotFound.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job paused successfully"})
+}
+
+// @Summary Resume scheduler job
+// @Description Resumes a paused background job. The next run is calculated from the moment the job is resumed.
+// @Success 200 {object} responseMessage
+// @Produce json
+// @Param name query string true "Job name" Example(update_mangas_metadata)
+// @Router /scheduler/job/resume [patch]
+func ResumeSchedulerJob(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "name must be provided"})
+		return
+	}
+
+	err := scheduler.DefaultScheduler.ResumeJob(name)
+	if err != nil {
+		if strings.Contains(err.Error(), errordefs.ErrJobNotFound.Error()) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job resumed successfully"})
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/diogovalentte/mantium/api/src/sources"
+)
+
+// SourcesRoutes sets the routes for the sources.
+func SourcesRoutes(group *gin.RouterGroup) {
+	{
+		group.GET("/sources/health", GetSourcesHealth)
+	}
+}
+
+// @Summary Get sources health
+// @Description Returns the health of each source: the number of successful and failed calls, the last success and error, and the average latency. A source is down after many consecutive failures, and the update job skips it until its cooldown passes. The health is kept only in memory.
+// @Success 200 {array} sources.SourceHealth "{"sources": [sourceHealthObj]}"
+// @Produce json
+// @Router /sources/health [get]
+func GetSourcesHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"sources": sources.GetSourcesHealth()})
+}
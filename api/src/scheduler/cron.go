@@ -0,0 +1,241 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule describes when a job should run.
+type Schedule interface {
+	// Next returns the next time the job should run after the time t.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a schedule spec. It accepts:
+//   - Standard 5 fields cron expressions: "minute hour day-of-month month day-of-week", like "*/30 * * * *".
+//     Each field can be a "*", a number, a range ("1-5"), a list ("1,3,5"), and a step ("*/2", "1-10/2").
+//     Months and days of week also accept three letters names, like "jan" and "mon".
+//   - The descriptors "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", and "@hourly".
+//   - "@every <duration>", where duration is parsed by time.ParseDuration, like "@every 30m".
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("empty schedule spec")
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		if strings.HasPrefix(spec, "@every ") {
+			duration, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration in schedule spec '%s': %s", spec, err)
+			}
+			if duration < time.Second {
+				return nil, fmt.Errorf("invalid duration in schedule spec '%s': should be at least one second", spec)
+			}
+			return everySchedule{interval: duration}, nil
+		}
+
+		switch spec {
+		case "@yearly", "@annually":
+			spec = "0 0 1 1 *"
+		case "@monthly":
+			spec = "0 0 1 * *"
+		case "@weekly":
+			spec = "0 0 * * 0"
+		case "@daily", "@midnight":
+			spec = "0 0 * * *"
+		case "@hourly":
+			spec = "0 * * * *"
+		default:
+			return nil, fmt.Errorf("unknown schedule descriptor '%s'", spec)
+		}
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields, got %d", spec, len(fields))
+	}
+
+	var (
+		schedule cronSchedule
+		err      error
+	)
+	schedule.minutes, _, err = parseCronField(fields[0], 0, 59, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minute field in cron expression '%s': %s", spec, err)
+	}
+	schedule.hours, _, err = parseCronField(fields[1], 0, 23, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hour field in cron expression '%s': %s", spec, err)
+	}
+	schedule.daysOfMonth, schedule.anyDayOfMonth, err = parseCronField(fields[2], 1, 31, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day of month field in cron expression '%s': %s", spec, err)
+	}
+	schedule.months, _, err = parseCronField(fields[3], 1, 12, monthNames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month field in cron expression '%s': %s", spec, err)
+	}
+	schedule.daysOfWeek, schedule.anyDayOfWeek, err = parseCronField(fields[4], 0, 7, dayOfWeekNames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day of week field in cron expression '%s': %s", spec, err)
+	}
+	// 7 is also Sunday
+	if schedule.daysOfWeek[7] {
+		schedule.daysOfWeek[0] = true
+	}
+
+	return schedule, nil
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayOfWeekNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// parseCronField parses a cron field and returns a slice where
+// the index is the value and the element is true if the value is in the field.
+// It also returns whether the field is a "*".
+func parseCronField(field string, minValue, maxValue int, names map[string]int) ([]bool, bool, error) {
+	values := make([]bool, maxValue+1)
+	isAny := field == "*"
+
+	for _, part := range strings.Split(field, ",") {
+		if part == "" {
+			return nil, false, fmt.Errorf("empty value in '%s'", field)
+		}
+
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return nil, false, fmt.Errorf("invalid step '%s'", stepPart)
+			}
+		}
+
+		var start, end int
+		if rangePart == "*" {
+			start, end = minValue, maxValue
+		} else {
+			startStr, endStr, isRange := strings.Cut(rangePart, "-")
+			var err error
+			start, err = parseCronValue(startStr, names)
+			if err != nil {
+				return nil, false, err
+			}
+			switch {
+			case isRange:
+				end, err = parseCronValue(endStr, names)
+				if err != nil {
+					return nil, false, err
+				}
+			case hasStep:
+				end = maxValue
+			default:
+				end = start
+			}
+		}
+
+		if start < minValue || end > maxValue || start > end {
+			return nil, false, fmt.Errorf("value '%s' out of range [%d, %d]", rangePart, minValue, maxValue)
+		}
+
+		for i := start; i <= end; i += step {
+			values[i] = true
+		}
+	}
+
+	return values, isAny, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if names != nil {
+		if v, ok := names[strings.ToLower(value)]; ok {
+			return v, nil
+		}
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", value)
+	}
+
+	return v, nil
+}
+
+// cronSchedule is a schedule based on a cron expression.
+type cronSchedule struct {
+	minutes       []bool
+	hours         []bool
+	daysOfMonth   []bool
+	months        []bool
+	daysOfWeek    []bool
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// Next returns the next time the cron expression matches after t, in t's location.
+// It returns a zero time if no time matches in the next five years (e.g. "0 0 30 2 *").
+func (s cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !s.months[t.Month()] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay follows the cron behavior: if both the day of month and
+// day of week fields are restricted, the day matches if any of them matches.
+func (s cronSchedule) matchDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth[t.Day()]
+	dayOfWeek := s.daysOfWeek[t.Weekday()]
+
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dayOfWeek
+	case s.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+// everySchedule is a schedule that runs in a fixed interval.
+type everySchedule struct {
+	interval time.Duration
+}
+
+// Next returns t plus the interval.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	base := time.Date(2024, time.March, 15, 10, 20, 30, 0, time.UTC) // Friday
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"*/30 * * * *", time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2024, time.March, 15, 11, 0, 0, 0, time.UTC)},
+		{"15 3 * * *", time.Date(2024, time.March, 16, 3, 15, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2024, time.March, 18, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2024, time.March, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1-5/2 * *", time.Date(2024, time.April, 1, 12, 0, 0, 0, time.UTC)},
+		{"0 12 20 * 1", time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)},
+		{"10,25,40 10 * * *", time.Date(2024, time.March, 15, 10, 25, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.March, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{"@every 45m", base.Add(45 * time.Minute)},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(test.spec)
+			if err != nil {
+				t.Fatalf("error parsing schedule: %v", err)
+			}
+			next := schedule.Next(base)
+			if !next.Equal(test.expected) {
+				t.Fatalf("expected next run at %s, got %s", test.expected, next)
+			}
+		})
+	}
+
+	t.Run("Should return zero time if the schedule never matches", func(t *testing.T) {
+		schedule, err := ParseSchedule("0 0 30 2 *")
+		if err != nil {
+			t.Fatalf("error parsing schedule: %v", err)
+		}
+		if next := schedule.Next(base); !next.IsZero() {
+			t.Fatalf("expected zero time, got %s", next)
+		}
+	})
+
+	invalidSpecs := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@sometimes", "@every 1ms", "@every soon"}
+	for _, spec := range invalidSpecs {
+		t.Run("Should not parse invalid spec '"+spec+"'", func(t *testing.T) {
+			_, err := ParseSchedule(spec)
+			if err == nil {
+				t.Fatalf("expected error parsing schedule '%s'", spec)
+			}
+		})
+	}
+}
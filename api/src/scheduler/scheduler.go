@@ -0,0 +1,338 @@
+// Package scheduler implements an in-process scheduler that runs background jobs
+// based on cron expressions, keeping the history of the jobs' runs.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+)
+
+// maxJobHistory is the maximum number of runs kept in a job's history.
+const maxJobHistory = 20
+
+// JobFunc is the function executed by a job.
+type JobFunc func(ctx context.Context) error
+
+// Run is a job's run.
+type Run struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// Duration of the run in seconds.
+	Duration float64 `json:"duration"`
+	// Error is the error message if the run failed, empty otherwise.
+	Error string `json:"error"`
+	// Manual is true if the run was manually triggered.
+	Manual bool `json:"manual"`
+}
+
+// Job is a job registered in the scheduler.
+type Job struct {
+	// NextRun is the next time the job will run. It's zero if the job is paused.
+	NextRun time.Time `json:"nextRun"`
+	// LastRun is the last finished run of the job.
+	LastRun *Run   `json:"lastRun"`
+	Name    string `json:"name"`
+	Spec    string `json:"schedule"`
+	History []*Run `json:"history"`
+	Paused  bool   `json:"paused"`
+	Running bool   `json:"running"`
+	fn      JobFunc
+	// trigger is used to manually run the job.
+	trigger chan struct{}
+	// wakeUp is used to make the job's goroutine recalculate when to run.
+	wakeUp   chan struct{}
+	schedule Schedule
+}
+
+// Scheduler runs jobs based on their schedules.
+// Jobs with the same name can't be registered twice, and a job never
+// runs concurrently with itself.
+type Scheduler struct {
+	jobs    map[string]*Job
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	started bool
+}
+
+// DefaultScheduler is the scheduler used by the API.
+var DefaultScheduler = NewScheduler()
+
+// NewScheduler returns a new scheduler without jobs.
+func NewScheduler() *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		jobs:   map[string]*Job{},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// AddJob registers a new job in the scheduler.
+// If the scheduler is already started, the job starts right away.
+func (s *Scheduler) AddJob(name, spec string, fn JobFunc) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job '%s' already exists in the scheduler", name)
+	}
+
+	job := &Job{
+		Name:     name,
+		Spec:     spec,
+		History:  []*Run{},
+		fn:       fn,
+		trigger:  make(chan struct{}, 1),
+		wakeUp:   make(chan struct{}, 1),
+		schedule: schedule,
+	}
+	s.jobs[name] = job
+
+	if s.started {
+		s.startJob(job)
+	}
+
+	return nil
+}
+
+// Start starts running the registered jobs in the background.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	for _, job := range s.jobs {
+		s.startJob(job)
+	}
+}
+
+// Stop stops the scheduler and waits for the running jobs to finish.
+// Running jobs have their context canceled.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// GetJobs returns a copy of all jobs in the scheduler, sorted by name.
+func (s *Scheduler) GetJobs() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job.copy())
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+
+	return jobs
+}
+
+// GetJob returns a copy of a job in the scheduler.
+func (s *Scheduler) GetJob(name string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return nil, errordefs.ErrJobNotFound
+	}
+
+	return job.copy(), nil
+}
+
+// TriggerJob runs a job right away, even if it's paused.
+// It doesn't change the job's next run.
+// If the job is already running, it returns an error.
+func (s *Scheduler) TriggerJob(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return errordefs.ErrJobNotFound
+	}
+	if !s.started {
+		return fmt.Errorf("scheduler is not started")
+	}
+	if job.Running {
+		return errordefs.ErrJobAlreadyRunning
+	}
+
+	select {
+	case job.trigger <- struct{}{}:
+	default:
+		return errordefs.ErrJobAlreadyRunning
+	}
+
+	return nil
+}
+
+// PauseJob pauses a job, it will not run until resumed.
+// Pausing a running job doesn't stop the current run.
+func (s *Scheduler) PauseJob(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return errordefs.ErrJobNotFound
+	}
+	job.Paused = true
+	job.NextRun = time.Time{}
+
+	return nil
+}
+
+// ResumeJob resumes a paused job.
+// The next run is calculated from the moment the job is resumed.
+func (s *Scheduler) ResumeJob(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return errordefs.ErrJobNotFound
+	}
+	if job.Paused {
+		job.Paused = false
+		job.NextRun = job.schedule.Next(time.Now())
+		// Wake up the job's goroutine to use the new next run
+		select {
+		case job.wakeUp <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// startJob starts the job's goroutine.
+// s.mu should be locked by the caller.
+func (s *Scheduler) startJob(job *Job) {
+	if !job.Paused {
+		job.NextRun = job.schedule.Next(time.Now())
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runJobLoop(job)
+	}()
+}
+
+func (s *Scheduler) runJobLoop(job *Job) {
+	for {
+		s.mu.Lock()
+		var wait <-chan time.Time
+		var timer *time.Timer
+		if !job.Paused && !job.NextRun.IsZero() {
+			timer = time.NewTimer(time.Until(job.NextRun))
+			wait = timer.C
+		}
+		s.mu.Unlock()
+
+		manual := false
+		select {
+		case <-s.ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-job.wakeUp:
+			if timer != nil {
+				timer.Stop()
+			}
+			continue
+		case <-wait:
+		case <-job.trigger:
+			manual = true
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+
+		s.mu.Lock()
+		if !manual && job.Paused {
+			s.mu.Unlock()
+			continue
+		}
+		job.Running = true
+		s.mu.Unlock()
+
+		run := s.runJob(job, manual)
+
+		s.mu.Lock()
+		job.Running = false
+		job.LastRun = run
+		job.History = append([]*Run{run}, job.History...)
+		if len(job.History) > maxJobHistory {
+			job.History = job.History[:maxJobHistory]
+		}
+		if !manual && !job.Paused {
+			job.NextRun = job.schedule.Next(time.Now())
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *Scheduler) runJob(job *Job, manual bool) (run *Run) {
+	run = &Run{
+		StartedAt: time.Now(),
+		Manual:    manual,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			run.Error = fmt.Sprintf("job panicked: %v", r)
+		}
+		run.FinishedAt = time.Now()
+		run.Duration = run.FinishedAt.Sub(run.StartedAt).Seconds()
+	}()
+
+	err := job.fn(s.ctx)
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	return run
+}
+
+// copy returns a copy of the job that can be safely used outside the scheduler.
+// The job's scheduler mutex should be locked by the caller.
+func (j *Job) copy() *Job {
+	job := &Job{
+		Name:    j.Name,
+		Spec:    j.Spec,
+		NextRun: j.NextRun,
+		Paused:  j.Paused,
+		Running: j.Running,
+		History: make([]*Run, len(j.History)),
+	}
+	for i, run := range j.History {
+		runCopy := *run
+		job.History[i] = &runCopy
+	}
+	if j.LastRun != nil {
+		lastRun := *j.LastRun
+		job.LastRun = &lastRun
+	}
+
+	return job
+}
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+)
+
+func waitForJobRuns(t *testing.T, s *Scheduler, name string, runs int) *Job {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := s.GetJob(name)
+		if err != nil {
+			t.Fatalf("error getting job: %v", err)
+		}
+		if len(job.History) >= runs && !job.Running {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job '%s' didn't run %d times in time", name, runs)
+
+	return nil
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("Should run job periodically and keep history", func(t *testing.T) {
+		s := NewScheduler()
+		defer s.Stop()
+
+		calls := 0
+		err := s.AddJob("test", "@every 1s", func(ctx context.Context) error {
+			calls++
+			if calls == 1 {
+				return fmt.Errorf("first run error")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("error adding job: %v", err)
+		}
+		s.Start()
+
+		job := waitForJobRuns(t, s, "test", 2)
+		if job.History[1].Error != "first run error" {
+			t.Fatalf("expected first run to have an error, got '%s'", job.History[1].Error)
+		}
+		if job.LastRun == nil || job.LastRun.Error != "" {
+			t.Fatalf("expected last run without error, got %v", job.LastRun)
+		}
+		if job.NextRun.IsZero() {
+			t.Fatal("expected next run to be set")
+		}
+	})
+	t.Run("Should not add job with the same name twice", func(t *testing.T) {
+		s := NewScheduler()
+		defer s.Stop()
+
+		fn := func(ctx context.Context) error { return nil }
+		if err := s.AddJob("test", "@daily", fn); err != nil {
+			t.Fatalf("error adding job: %v", err)
+		}
+		if err := s.AddJob("test", "@daily", fn); err == nil {
+			t.Fatal("expected error adding job with the same name")
+		}
+	})
+	t.Run("Should trigger paused job manually", func(t *testing.T) {
+		s := NewScheduler()
+		defer s.Stop()
+
+		err := s.AddJob("test", "@daily", func(ctx context.Context) error { return nil })
+		if err != nil {
+			t.Fatalf("error adding job: %v", err)
+		}
+		if err = s.PauseJob("test"); err != nil {
+			t.Fatalf("error pausing job: %v", err)
+		}
+		s.Start()
+
+		job, err := s.GetJob("test")
+		if err != nil {
+			t.Fatalf("error getting job: %v", err)
+		}
+		if !job.Paused || !job.NextRun.IsZero() {
+			t.Fatalf("expected job to be paused without next run, got paused=%v, next run=%s", job.Paused, job.NextRun)
+		}
+
+		if err = s.TriggerJob("test"); err != nil {
+			t.Fatalf("error triggering job: %v", err)
+		}
+		job = waitForJobRuns(t, s, "test", 1)
+		if !job.LastRun.Manual {
+			t.Fatal("expected last run to be manual")
+		}
+
+		if err = s.ResumeJob("test"); err != nil {
+			t.Fatalf("error resuming job: %v", err)
+		}
+		job, err = s.GetJob("test")
+		if err != nil {
+			t.Fatalf("error getting job: %v", err)
+		}
+		if job.Paused || job.NextRun.IsZero() {
+			t.Fatalf("expected job to be resumed with next run, got paused=%v, next run=%s", job.Paused, job.NextRun)
+		}
+	})
+	t.Run("Should return error for jobs not found", func(t *testing.T) {
+		s := NewScheduler()
+		defer s.Stop()
+		s.Start()
+
+		if _, err := s.GetJob("test"); err != errordefs.ErrJobNotFound {
+			t.Fatalf("expected job not found error, got %v", err)
+		}
+		if err := s.TriggerJob("test"); err != errordefs.ErrJobNotFound {
+			t.Fatalf("expected job not found error, got %v", err)
+		}
+		if err := s.PauseJob("test"); err != errordefs.ErrJobNotFound {
+			t.Fatalf("expected job not found error, got %v", err)
+		}
+		if err := s.ResumeJob("test"); err != errordefs.ErrJobNotFound {
+			t.Fatalf("expected job not found error, got %v", err)
+		}
+	})
+}
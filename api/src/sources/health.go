@@ -0,0 +1,168 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// SourceHealth is the health of a source, tracked in each GetMangaMetadata and SearchManga call.
+// The health is kept only in memory.
+type SourceHealth struct {
+	Source    string `json:"source"`
+	Successes int    `json:"successes"`
+	Failures  int    `json:"failures"`
+	// ConsecutiveFailures is the number of failures since the last success.
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt"`
+	LastFailureAt       *time.Time `json:"lastFailureAt"`
+	LastError           string     `json:"lastError"`
+	// AverageLatencyMs is the average duration of the calls in milliseconds.
+	AverageLatencyMs int64 `json:"averageLatencyMs"`
+	// Down is true if the source reached the consecutive failures of the circuit breaker.
+	// Only the failures because the source is unavailable are counted, see isSourceUnavailableError.
+	// The update job skips the down sources until DownUntil, when the source is tried again.
+	Down      bool       `json:"down"`
+	DownUntil *time.Time `json:"downUntil"`
+
+	totalLatency time.Duration
+	// consecutiveUnavailable is the number of failures because the source is unavailable
+	// since the source last responded.
+	consecutiveUnavailable int
+}
+
+var (
+	sourcesHealth   = map[string]*SourceHealth{}
+	sourcesHealthMu sync.Mutex
+)
+
+// getSourceHealth returns the health of the source. The caller must hold sourcesHealthMu.
+func getSourceHealth(source string) *SourceHealth {
+	health, ok := sourcesHealth[source]
+	if !ok {
+		health = &SourceHealth{Source: source}
+		sourcesHealth[source] = health
+	}
+
+	return health
+}
+
+// sourceUnavailableStatusCodes are the status codes of responses from sources that are unavailable.
+var sourceUnavailableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// isSourceUnavailableError returns true if the error means the source is unavailable:
+// a network error, a 429 status code, or a 5xx status code. Other errors, like a manga
+// with a dead URL or a page that can't be parsed, don't mean the source is down.
+func isSourceUnavailableError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// The scrapers return a *util.StatusCodeError
+	var statusCodeErr *util.StatusCodeError
+	if errors.As(err, &statusCodeErr) {
+		return slices.Contains(sourceUnavailableStatusCodes, statusCodeErr.StatusCode)
+	}
+	// The sources' API clients return "non-200 status code -> (<code>)" errors
+	for _, statusCode := range sourceUnavailableStatusCodes {
+		if util.ErrorContains(err, fmt.Sprintf("non-200 status code -> (%d)", statusCode)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordSourceCall records the result of a call to the source in the source's health.
+// The errors of mangas not found in the source are not failures of the source.
+// Only the errors that mean the source is unavailable count toward the circuit breaker.
+func recordSourceCall(source string, start time.Time, err error) {
+	sourcesHealthMu.Lock()
+	defer sourcesHealthMu.Unlock()
+
+	now := time.Now()
+	health := getSourceHealth(source)
+	health.totalLatency += now.Sub(start)
+	health.AverageLatencyMs = (health.totalLatency / time.Duration(health.Successes+health.Failures+1)).Milliseconds()
+
+	if err == nil || util.ErrorContains(err, errordefs.ErrMangaNotFound.Error()) {
+		health.Successes++
+		health.ConsecutiveFailures = 0
+		health.consecutiveUnavailable = 0
+		health.LastSuccessAt = &now
+		health.Down = false
+		health.DownUntil = nil
+		return
+	}
+
+	health.Failures++
+	health.ConsecutiveFailures++
+	health.LastFailureAt = &now
+	health.LastError = err.Error()
+	if !isSourceUnavailableError(err) {
+		// The source responded, so it's not down
+		health.consecutiveUnavailable = 0
+		return
+	}
+	health.consecutiveUnavailable++
+	failuresToDown := config.GlobalConfigs.SourcesHealth.CircuitBreakerFailures
+	if failuresToDown > 0 && health.consecutiveUnavailable >= failuresToDown {
+		downUntil := now.Add(config.GlobalConfigs.SourcesHealth.CircuitBreakerCooldown)
+		health.Down = true
+		health.DownUntil = &downUntil
+	}
+}
+
+// IsSourceDown returns true if the source is down and should be skipped.
+// When a down source's cooldown passes, the first call returns false so the
+// source is tried again, and the next calls return true until the source
+// succeeds or the cooldown passes again.
+func IsSourceDown(source string) bool {
+	sourcesHealthMu.Lock()
+	defer sourcesHealthMu.Unlock()
+
+	health, ok := sourcesHealth[source]
+	if !ok || !health.Down {
+		return false
+	}
+	now := time.Now()
+	if now.Before(*health.DownUntil) {
+		return true
+	}
+	downUntil := now.Add(config.GlobalConfigs.SourcesHealth.CircuitBreakerCooldown)
+	health.DownUntil = &downUntil
+
+	return false
+}
+
+// GetSourcesHealth returns the health of all sources, sorted by source name.
+// The sources without calls have empty health.
+func GetSourcesHealth() []SourceHealth {
+	sourcesHealthMu.Lock()
+	defer sourcesHealthMu.Unlock()
+
+	healths := make([]SourceHealth, 0, len(Sources))
+	for source := range Sources {
+		healths = append(healths, *getSourceHealth(source))
+	}
+	slices.SortFunc(healths, func(a, b SourceHealth) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+
+	return healths
+}
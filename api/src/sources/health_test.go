@@ -0,0 +1,120 @@
+package sources
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/errordefs"
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+func TestSourceHealth(t *testing.T) {
+	config.GlobalConfigs.SourcesHealth.CircuitBreakerFailures = 3
+	config.GlobalConfigs.SourcesHealth.CircuitBreakerCooldown = time.Hour
+	resetHealth := func() {
+		sourcesHealthMu.Lock()
+		sourcesHealth = map[string]*SourceHealth{}
+		sourcesHealthMu.Unlock()
+	}
+	getHealth := func(source string) SourceHealth {
+		for _, health := range GetSourcesHealth() {
+			if health.Source == source {
+				return health
+			}
+		}
+		t.Fatalf("source '%s' not found in the sources health", source)
+		return SourceHealth{}
+	}
+
+	t.Run("Should record the successes and failures", func(t *testing.T) {
+		resetHealth()
+		start := time.Now().Add(-100 * time.Millisecond)
+		recordSourceCall("mangadex", start, nil)
+		recordSourceCall("mangadex", start, fmt.Errorf("timeout"))
+		recordSourceCall("mangadex", start, util.AddErrorContext("error", errordefs.ErrMangaNotFound))
+
+		health := getHealth("mangadex")
+		if health.Successes != 2 || health.Failures != 1 || health.ConsecutiveFailures != 0 || health.LastError != "timeout" {
+			t.Fatalf("unexpected health: %+v", health)
+		}
+		if health.LastSuccessAt == nil || health.LastFailureAt == nil || health.AverageLatencyMs < 100 {
+			t.Fatalf("unexpected health times: %+v", health)
+		}
+		if empty := getHealth("rawkuma"); empty.Successes != 0 || empty.Failures != 0 || empty.Down {
+			t.Fatalf("expected a source without calls to have empty health, got %+v", empty)
+		}
+	})
+	t.Run("Should open the circuit after consecutive failures", func(t *testing.T) {
+		resetHealth()
+		for i := 0; i < 3; i++ {
+			if IsSourceDown("rawkuma") {
+				t.Fatalf("expected the source to be down only after 3 failures, it's down after %d", i)
+			}
+			recordSourceCall("rawkuma", time.Now(), fmt.Errorf("non-200 status code -> (503)"))
+		}
+		if !IsSourceDown("rawkuma") || !getHealth("rawkuma").Down {
+			t.Fatalf("expected the source to be down")
+		}
+
+		recordSourceCall("rawkuma", time.Now(), nil)
+		if IsSourceDown("rawkuma") || getHealth("rawkuma").Down {
+			t.Fatalf("expected the source to recover after a success")
+		}
+	})
+	t.Run("Should try a down source once after the cooldown", func(t *testing.T) {
+		resetHealth()
+		for i := 0; i < 3; i++ {
+			recordSourceCall("klmanga", time.Now(), fmt.Errorf("Bad Gateway"))
+		}
+		sourcesHealthMu.Lock()
+		past := time.Now().Add(-time.Second)
+		sourcesHealth["klmanga"].DownUntil = &past
+		sourcesHealthMu.Unlock()
+
+		if IsSourceDown("klmanga") {
+			t.Fatalf("expected the source to be tried after the cooldown")
+		}
+		if !IsSourceDown("klmanga") {
+			t.Fatalf("expected the source to be down while it's tried")
+		}
+	})
+	t.Run("Should not open the circuit if the source is available", func(t *testing.T) {
+		resetHealth()
+		for i := 0; i < 3; i++ {
+			recordSourceCall("mangahub", time.Now(), fmt.Errorf("non-200 status code -> (503)"))
+			recordSourceCall("mangahub", time.Now(), fmt.Errorf("error parsing the manga page"))
+		}
+		health := getHealth("mangahub")
+		if IsSourceDown("mangahub") || health.Down || health.ConsecutiveFailures != 6 {
+			t.Fatalf("expected the source to not be down, got %+v", health)
+		}
+	})
+}
+
+func TestIsSourceUnavailableError(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{&net.DNSError{Err: "no such host", Name: "mangadex.org"}, true},
+		{util.AddErrorContext("error", &url.Error{Op: "Get", URL: "https://mangadex.org", Err: fmt.Errorf("connection refused")}), true},
+		{util.AddErrorContext("error", fmt.Errorf("non-200 status code -> (429)")), true},
+		{util.AddErrorContext("error", fmt.Errorf("non-200 status code -> (502). Body: ")), true},
+		{util.AddErrorContext("error", &util.StatusCodeError{Err: fmt.Errorf("Service Unavailable"), StatusCode: 503}), true},
+		{util.AddErrorContext("error", &util.StatusCodeError{Err: fmt.Errorf("Too Many Requests"), StatusCode: 429}), true},
+		{util.AddErrorContext("error", fmt.Errorf("non-200 status code -> (404)")), false},
+		{util.AddErrorContext("error", &util.StatusCodeError{Err: fmt.Errorf("Forbidden"), StatusCode: 403}), false},
+		{util.AddErrorContext("error", fmt.Errorf("Service Unavailable")), false},
+		{util.AddErrorContext("error parsing chapter 'Internal Server Error'", fmt.Errorf("chapter not found")), false},
+		{fmt.Errorf("error parsing the manga page"), false},
+	}
+	for _, tc := range testCases {
+		if unavailable := isSourceUnavailableError(tc.err); unavailable != tc.expected {
+			t.Fatalf("expected error '%v' unavailable to be %v, got %v", tc.err, tc.expected, unavailable)
+		}
+	}
+}
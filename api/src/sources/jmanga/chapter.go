@@ -82,7 +82,7 @@ func (s *Source) getChapterMetadataByChapter(mangaURL string, chapter string) (*
 		chapterFound = true
 	})
 
-	err := s.c.Visit(mangaURL)
+	err := util.VisitSourcePage(s.c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, errordefs.ErrMangaNotFound
@@ -123,7 +123,7 @@ func (s *Source) GetLastChapterMetadata(mangaURL string, _ string) (*manga.Chapt
 		}
 	})
 
-	err := s.c.Visit(mangaURL)
+	err := util.VisitSourcePage(s.c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -167,7 +167,7 @@ func (s *Source) GetChaptersMetadata(mangaURL, _ string) ([]*manga.Chapter, erro
 		chapters = append(chapters, chapterAdd)
 	})
 
-	err := s.c.Visit(mangaURL)
+	err := util.VisitSourcePage(s.c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -6,6 +6,8 @@ import (
 	"regexp"
 
 	"github.com/gocolly/colly/v2"
+
+	"github.com/diogovalentte/mantium/api/src/util"
 )
 
 var baseSiteURL = "https://jmanga.ltd"
@@ -22,7 +24,8 @@ func (Source) GetName() string {
 var userAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:30.0) Gecko/20100101 Firefox/30.0"
 
 func newCollector() *colly.Collector {
-	c := colly.NewCollector(
+	c := util.NewSourcesCollector(
+		"jmanga",
 		colly.UserAgent(userAgent),
 	)
 
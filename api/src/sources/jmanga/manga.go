@@ -2,7 +2,6 @@ package jmanga
 
 import (
 	"net/url"
-	"time"
 
 	"github.com/gocolly/colly/v2"
 
@@ -36,7 +35,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 		var coverImg []byte
 		var resized bool
 		var err error
-		coverImg, resized, err = util.GetImageFromURL(coverURL, 3, 1*time.Second)
+		coverImg, resized, err = util.GetImageFromURL("jmanga", coverURL)
 		if err == nil {
 			mangaReturn.CoverImgURL = coverURL
 			mangaReturn.CoverImgResized = resized
@@ -62,7 +61,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 		}
 	})
 
-	err := s.c.Visit(mangaURL)
+	err := util.VisitSourcePage(s.c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -125,7 +124,7 @@ func (s *Source) Search(term string, limit int) ([]*models.MangaSearchResult, er
 
 	term = url.QueryEscape(term)
 	mangaURL := baseSiteURL + "/?q=" + term
-	err := s.c.Visit(mangaURL)
+	err := util.VisitSourcePage(s.c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
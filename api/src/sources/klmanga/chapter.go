@@ -63,7 +63,7 @@ func (s *Source) getChapterMetadataByURL(chapterURL string) (*manga.Chapter, err
 		chapterReturn.Name = chapterName
 	})
 
-	err := c.Visit(chapterURL)
+	err := util.VisitSourcePage(c, chapterURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, errordefs.ErrChapterNotFound
@@ -109,7 +109,7 @@ func (s *Source) getChapterMetadataByChapter(mangaURL string, chapter string) (*
 		chapterReturn.Name = chapterName
 	})
 
-	err := c.Visit(mangaURL)
+	err := util.VisitSourcePage(c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, errordefs.ErrMangaNotFound
@@ -148,7 +148,7 @@ func (s *Source) GetLastChapterMetadata(mangaURL string, _ string) (*manga.Chapt
 		chapterReturn.Name = chapterName
 	})
 
-	err := c.Visit(mangaURL)
+	err := util.VisitSourcePage(c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -192,7 +192,7 @@ func (s *Source) GetChaptersMetadata(mangaURL, _ string) ([]*manga.Chapter, erro
 		chapters = append(chapters, chapterAdd)
 	})
 
-	err := c.Visit(mangaURL)
+	err := util.VisitSourcePage(c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/gocolly/colly/v2"
 
@@ -42,7 +41,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 		var coverImg []byte
 		var resized bool
 		var err error
-		coverImg, resized, err = util.GetImageFromURL(coverURL, 3, 1*time.Second)
+		coverImg, resized, err = util.GetImageFromURL("klmanga", coverURL)
 		if err == nil {
 			mangaReturn.CoverImgURL = coverURL
 			mangaReturn.CoverImgResized = resized
@@ -68,7 +67,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 		}
 	})
 
-	err := c.Visit(mangaURL)
+	err := util.VisitSourcePage(c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -134,7 +133,7 @@ func (s *Source) Search(term string, limit int) ([]*models.MangaSearchResult, er
 		})
 
 		mangaURL := fmt.Sprintf("%s/page/%d/?s=%s", baseSiteURL, pageNumber, term)
-		err := c.Visit(mangaURL)
+		err := util.VisitSourcePage(c, mangaURL)
 		if err != nil {
 			if err.Error() == "Not Found" {
 				return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -19,7 +19,9 @@ type Client struct {
 
 // NewMangadexClient creates a new Mangadex API client
 func NewMangadexClient() *Client {
-	client := http.Client{}
+	client := http.Client{
+		Transport: util.NewSourcesTransport("mangadex", nil),
+	}
 
 	header := http.Header{}
 	header.Set("Content-Type", "application/json")
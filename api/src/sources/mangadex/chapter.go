@@ -2,10 +2,12 @@ package mangadex
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 
 	"github.com/diogovalentte/mantium/api/src/errordefs"
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/sources/models"
 	"github.com/diogovalentte/mantium/api/src/util"
 )
 
@@ -66,6 +68,7 @@ func (s *Source) getChapterMetadataByURL(chapterURL string) (*manga.Chapter, err
 		}
 	}
 
+	chapterReturn.Volume = attributes.Volume
 	chapterReturn.UpdatedAt, err = util.GetRFC3339Datetime(attributes.PublishAt)
 	if err != nil {
 		return nil, util.AddErrorContext(errordefs.ErrChapterAttributesNotFound.Message, err)
@@ -86,7 +89,12 @@ type getChapterAPIResponse struct {
 }
 
 // GetLastChapterMetadata returns the last chapter of a manga by its URL
-func (s *Source) GetLastChapterMetadata(mangaURL, _ string) (*manga.Chapter, error) {
+func (s *Source) GetLastChapterMetadata(mangaURL, mangaInternalID string) (*manga.Chapter, error) {
+	return s.GetLastChapterMetadataWithFilter(mangaURL, mangaInternalID, nil)
+}
+
+// GetLastChapterMetadataWithFilter returns the last chapter of a manga by its URL that matches the filter
+func (s *Source) GetLastChapterMetadataWithFilter(mangaURL, _ string, filter *models.ChapterFilter) (*manga.Chapter, error) {
 	s.checkClient()
 
 	errorContext := "error while getting last chapter metadata of manga with URL '%s'"
@@ -96,25 +104,39 @@ func (s *Source) GetLastChapterMetadata(mangaURL, _ string) (*manga.Chapter, err
 		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), err)
 	}
 
-	// URL gets the last chapter of the manga
-	mangaAPIURL := fmt.Sprintf("%s/manga/%s/feed?contentRating[]=safe&contentRating[]=suggestive&contentRating[]=erotica&contentRating[]=pornographic&translatedLanguage[]=en&order[chapter]=desc&limit=1&offset=0", baseAPIURL, mangaID)
-	var feedAPIResp getMangaFeedAPIResponse
-	_, err = s.client.Request("GET", mangaAPIURL, nil, &feedAPIResp)
-	if err != nil {
-		if util.ErrorContains(err, "non-200 status code -> (404)") {
-			return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), errordefs.ErrChapterNotFound)
-		}
-		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), err)
+	// Without groups, the first chapter of the feed is the last chapter.
+	// Else, the feed is requested until a chapter from the groups is found.
+	requestLimit := 1
+	if filter != nil && len(filter.Groups) > 0 {
+		requestLimit = 100
 	}
+	var chapterData *mangaFeedChapter
+	for requestOffset := 0; chapterData == nil; requestOffset += requestLimit {
+		mangaAPIURL := getMangaFeedURL(mangaID, filter, requestLimit, requestOffset)
+		var feedAPIResp getMangaFeedAPIResponse
+		_, err = s.client.Request("GET", mangaAPIURL, nil, &feedAPIResp)
+		if err != nil {
+			if util.ErrorContains(err, "non-200 status code -> (404)") {
+				return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), errordefs.ErrChapterNotFound)
+			}
+			return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), err)
+		}
 
-	if len(feedAPIResp.Data) == 0 {
-		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), errordefs.ErrChapterNotFound)
+		for i := range feedAPIResp.Data {
+			if filter.MatchesGroups(getScanlationGroups(feedAPIResp.Data[i].Relationships)...) {
+				chapterData = &feedAPIResp.Data[i]
+				break
+			}
+		}
+		if chapterData == nil && requestOffset+requestLimit >= feedAPIResp.Total {
+			return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), errordefs.ErrChapterNotFound)
+		}
 	}
 
 	chapterReturn := &manga.Chapter{}
-	chapterReturn.URL = fmt.Sprintf("%s/chapter/%s", baseSiteURL, feedAPIResp.Data[0].ID)
+	chapterReturn.URL = fmt.Sprintf("%s/chapter/%s", baseSiteURL, chapterData.ID)
 
-	attributes := &feedAPIResp.Data[0].Attributes
+	attributes := &chapterData.Attributes
 
 	if attributes.Chapter == "" && attributes.Title == "" {
 		chapterReturn.Chapter = attributes.Chapter
@@ -133,6 +155,7 @@ func (s *Source) GetLastChapterMetadata(mangaURL, _ string) (*manga.Chapter, err
 		}
 	}
 
+	chapterReturn.Volume = attributes.Volume
 	chapterReturn.UpdatedAt, err = util.GetRFC3339Datetime(attributes.PublishAt)
 	if err != nil {
 		return nil, util.AddErrorContext(fmt.Sprintf(errorContext, mangaURL), util.AddErrorContext(errordefs.ErrChapterAttributesNotFound.Message, err))
@@ -142,7 +165,12 @@ func (s *Source) GetLastChapterMetadata(mangaURL, _ string) (*manga.Chapter, err
 }
 
 // GetChaptersMetadata returns the chapters of a manga by its URL
-func (s *Source) GetChaptersMetadata(mangaURL, _ string) ([]*manga.Chapter, error) {
+func (s *Source) GetChaptersMetadata(mangaURL, mangaInternalID string) ([]*manga.Chapter, error) {
+	return s.GetChaptersMetadataWithFilter(mangaURL, mangaInternalID, nil)
+}
+
+// GetChaptersMetadataWithFilter returns the chapters of a manga by its URL that match the filter
+func (s *Source) GetChaptersMetadataWithFilter(mangaURL, _ string, filter *models.ChapterFilter) ([]*manga.Chapter, error) {
 	s.checkClient()
 
 	errorContext := "error while getting chapters metadata"
@@ -151,7 +179,7 @@ func (s *Source) GetChaptersMetadata(mangaURL, _ string) ([]*manga.Chapter, erro
 	errChan := make(chan error)
 	done := make(chan struct{})
 
-	go generateMangaFeed(s, mangaURL, chaptersChan, errChan)
+	go generateMangaFeed(s, mangaURL, filter, chaptersChan, errChan)
 
 	var chapters []*manga.Chapter
 	go func() {
@@ -178,7 +206,8 @@ func (s *Source) GetChaptersMetadata(mangaURL, _ string) ([]*manga.Chapter, erro
 // It sends an error to the error channel if something goes wrong.
 // It closes the chapters channel when there is no more chapters to send.
 // It requests the mangas from the API using the chapter for ordering.
-func generateMangaFeed(s *Source, mangaURL string, chaptersChan chan<- *manga.Chapter, errChan chan<- error) {
+// Only the chapters that match the filter are sent.
+func generateMangaFeed(s *Source, mangaURL string, filter *models.ChapterFilter, chaptersChan chan<- *manga.Chapter, errChan chan<- error) {
 	defer close(chaptersChan)
 
 	mangaID, err := getMangaID(mangaURL)
@@ -192,7 +221,7 @@ func generateMangaFeed(s *Source, mangaURL string, chaptersChan chan<- *manga.Ch
 	totalChapters := 1
 
 	for totalChapters >= requestOffset {
-		mangaAPIURL := fmt.Sprintf("%s/manga/%s/feed?contentRating[]=safe&contentRating[]=suggestive&contentRating[]=erotica&contentRating[]=pornographic&translatedLanguage[]=en&order[chapter]=desc&limit=%d&offset=%d", baseAPIURL, mangaID, requestLimit, requestOffset)
+		mangaAPIURL := getMangaFeedURL(mangaID, filter, requestLimit, requestOffset)
 		var feedAPIResp getMangaFeedAPIResponse
 		_, err = s.client.Request("GET", mangaAPIURL, nil, &feedAPIResp)
 		if err != nil {
@@ -206,6 +235,10 @@ func generateMangaFeed(s *Source, mangaURL string, chaptersChan chan<- *manga.Ch
 		totalChapters = feedAPIResp.Total
 		requestOffset += requestLimit
 		for _, chapterReq := range feedAPIResp.Data {
+			if !filter.MatchesGroups(getScanlationGroups(chapterReq.Relationships)...) {
+				continue
+			}
+
 			chapterReturn := &manga.Chapter{}
 			chapterReturn.URL = fmt.Sprintf("%s/chapter/%s", baseSiteURL, chapterReq.ID)
 
@@ -228,6 +261,7 @@ func generateMangaFeed(s *Source, mangaURL string, chaptersChan chan<- *manga.Ch
 				}
 			}
 
+			chapterReturn.Volume = attributes.Volume
 			chapterReturn.UpdatedAt, err = util.GetRFC3339Datetime(attributes.PublishAt)
 			if err != nil {
 				errChan <- util.AddErrorContext(errordefs.ErrChapterAttributesNotFound.Message, err)
@@ -240,17 +274,58 @@ func generateMangaFeed(s *Source, mangaURL string, chaptersChan chan<- *manga.Ch
 }
 
 type getMangaFeedAPIResponse struct {
-	Result   string `json:"result"`
-	Response string `json:"response"`
-	Data     []struct {
-		ID            string                `json:"id"`
-		Type          string                `json:"type"`
-		Relationships []genericRelationship `json:"relationships"`
-		Attributes    chapterAttributes     `json:"attributes"`
+	Result   string             `json:"result"`
+	Response string             `json:"response"`
+	Data     []mangaFeedChapter `json:"data"`
+	Limit    int                `json:"limit"`
+	Offset   int                `json:"offset"`
+	Total    int                `json:"total"`
+}
+
+type mangaFeedChapter struct {
+	ID            string                `json:"id"`
+	Type          string                `json:"type"`
+	Relationships []genericRelationship `json:"relationships"`
+	Attributes    chapterAttributes     `json:"attributes"`
+}
+
+// getMangaFeedURL returns the API URL of the manga feed ordered by chapter, with the chapters
+// in the filter languages, or in the default translated languages if the filter doesn't have languages.
+// If the filter has groups, the scanlation groups are included in the chapters' relationships.
+func getMangaFeedURL(mangaID string, filter *models.ChapterFilter, limit, offset int) string {
+	params := url.Values{}
+	params.Add("contentRating[]", "safe")
+	params.Add("contentRating[]", "suggestive")
+	params.Add("contentRating[]", "erotica")
+	params.Add("contentRating[]", "pornographic")
+	for _, language := range getTranslatedLanguages(filter) {
+		params.Add("translatedLanguage[]", language)
+	}
+	if filter != nil && len(filter.Groups) > 0 {
+		params.Add("includes[]", "scanlation_group")
+	}
+	params.Add("order[chapter]", "desc")
+	params.Add("limit", fmt.Sprintf("%d", limit))
+	params.Add("offset", fmt.Sprintf("%d", offset))
+
+	return fmt.Sprintf("%s/manga/%s/feed?%s", baseAPIURL, mangaID, params.Encode())
+}
+
+// getScanlationGroups returns the IDs and names of the scanlation groups in the chapter's relationships.
+// The names are only available if the scanlation groups were included in the request.
+func getScanlationGroups(relationships []genericRelationship) []string {
+	var groups []string
+	for _, relationship := range relationships {
+		if relationship.Type != "scanlation_group" {
+			continue
+		}
+		groups = append(groups, relationship.ID)
+		if name, ok := relationship.Attributes["name"].(string); ok {
+			groups = append(groups, name)
+		}
 	}
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
-	Total  int `json:"total"`
+
+	return groups
 }
 
 // getChapterID returns the ID of a chapter given its URL.
@@ -2,11 +2,14 @@ package mangadex
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/diogovalentte/mantium/api/src/config"
 	"github.com/diogovalentte/mantium/api/src/errordefs"
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/sources/models"
 	"github.com/diogovalentte/mantium/api/src/util"
 )
 
@@ -20,6 +23,7 @@ var chapterTestTable = []chapterTestType{
 	{
 		expected: &manga.Chapter{
 			Chapter:   "155",
+			Volume:    "27",
 			Name:      "Proof of Life",
 			URL:       "https://mangadex.org/chapter/87b7b182-e930-4f97-86b5-e243f1645514",
 			UpdatedAt: time.Date(2020, 6, 30, 4, 51, 45, 0, time.UTC), // in the site it's 01-19-2016 (maybe it uses JS or it have to wait a bit to update)
@@ -30,6 +34,7 @@ var chapterTestTable = []chapterTestType{
 	{
 		expected: &manga.Chapter{
 			Chapter:   "249",
+			Volume:    "22",
 			Name:      "Resolution",
 			URL:       "https://mangadex.org/chapter/885f6206-7713-4c3d-be91-2e53ac17e2a0",
 			UpdatedAt: time.Date(2018, 2, 5, 12, 14, 22, 0, time.UTC), // in the site it's 01-19-2016 (maybe it uses JS or it have to wait a bit to update)
@@ -240,3 +245,56 @@ func TestGetChapterID(t *testing.T) {
 		}
 	})
 }
+
+func TestGetMangaFeedURL(t *testing.T) {
+	t.Run("Should use the default languages without a filter", func(t *testing.T) {
+		feedURL := getMangaFeedURL("abc", nil, 1, 0)
+		if !strings.Contains(feedURL, "translatedLanguage%5B%5D=en") || strings.Contains(feedURL, "scanlation_group") {
+			t.Fatalf("unexpected feed URL: %s", feedURL)
+		}
+	})
+	t.Run("Should use the filter languages and include the scanlation groups", func(t *testing.T) {
+		feedURL := getMangaFeedURL("abc", &models.ChapterFilter{Groups: []string{"Group"}, Languages: []string{"pt-br", "es"}}, 100, 200)
+		for _, expected := range []string{"translatedLanguage%5B%5D=pt-br", "translatedLanguage%5B%5D=es", "includes%5B%5D=scanlation_group", "limit=100", "offset=200"} {
+			if !strings.Contains(feedURL, expected) {
+				t.Fatalf("expected feed URL to contain %s, got %s", expected, feedURL)
+			}
+		}
+		if strings.Contains(feedURL, "translatedLanguage%5B%5D=en") {
+			t.Fatalf("expected feed URL to not contain the default language, got %s", feedURL)
+		}
+	})
+	t.Run("Should use the configured default languages without filter languages", func(t *testing.T) {
+		defaultLanguages := config.GlobalConfigs.MangaDex.TranslatedLanguages
+		config.GlobalConfigs.MangaDex.TranslatedLanguages = []string{"fr"}
+		t.Cleanup(func() { config.GlobalConfigs.MangaDex.TranslatedLanguages = defaultLanguages })
+
+		feedURL := getMangaFeedURL("abc", &models.ChapterFilter{Groups: []string{"Group"}}, 1, 0)
+		if !strings.Contains(feedURL, "translatedLanguage%5B%5D=fr") || strings.Contains(feedURL, "translatedLanguage%5B%5D=en") {
+			t.Fatalf("unexpected feed URL: %s", feedURL)
+		}
+	})
+}
+
+func TestGetScanlationGroups(t *testing.T) {
+	relationships := []genericRelationship{
+		{ID: "1", Type: "manga"},
+		{ID: "2", Type: "scanlation_group", Attributes: map[string]any{"name": "Group A"}},
+		{ID: "3", Type: "scanlation_group"},
+	}
+	groups := getScanlationGroups(relationships)
+	if !reflect.DeepEqual(groups, []string{"2", "Group A", "3"}) {
+		t.Fatalf("unexpected groups: %v", groups)
+	}
+
+	filter := &models.ChapterFilter{Groups: []string{"group a"}}
+	if !filter.MatchesGroups(groups...) {
+		t.Fatalf("expected groups %v to match the filter", groups)
+	}
+	if filter.MatchesGroups(getScanlationGroups(relationships[2:])...) {
+		t.Fatalf("expected only group 3 to not match the filter")
+	}
+	if !(*models.ChapterFilter)(nil).MatchesGroups() {
+		t.Fatalf("expected a nil filter to match all chapters")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/diogovalentte/mantium/api/src/errordefs"
 	"github.com/diogovalentte/mantium/api/src/manga"
@@ -14,7 +13,13 @@ import (
 )
 
 // GetMangaMetadata returns the metadata of a manga given its URL
-func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
+func (s *Source) GetMangaMetadata(mangaURL, mangaInternalID string) (*manga.Manga, error) {
+	return s.GetMangaMetadataWithFilter(mangaURL, mangaInternalID, nil)
+}
+
+// GetMangaMetadataWithFilter returns the metadata of a manga given its URL,
+// with the last released chapter that matches the filter
+func (s *Source) GetMangaMetadataWithFilter(mangaURL, _ string, filter *models.ChapterFilter) (*manga.Manga, error) {
 	s.checkClient()
 
 	errorContext := "error while getting manga metadata"
@@ -53,7 +58,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 		}
 	}
 
-	lastReleasedChapter, err := s.GetLastChapterMetadata(mangaURL, "")
+	lastReleasedChapter, err := s.GetLastChapterMetadataWithFilter(mangaURL, "", filter)
 	if err != nil {
 		if !util.ErrorContains(err, errordefs.ErrChapterNotFound.Error()) {
 			return nil, util.AddErrorContext(errorContext, err)
@@ -77,7 +82,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 	}
 	if coverFileName != "" {
 		coverURL := fmt.Sprintf("%s/covers/%s/%s", baseUploadsURL, mangadexMangaID, coverFileName)
-		coverImg, resized, err := util.GetImageFromURL(coverURL, 3, 1*time.Second)
+		coverImg, resized, err := util.GetImageFromURL("mangadex", coverURL)
 		if err == nil {
 			mangaReturn.CoverImgURL = coverURL
 			mangaReturn.CoverImgResized = resized
@@ -120,6 +125,9 @@ func (s *Source) Search(term string, limit int) ([]*models.MangaSearchResult, er
 	params.Add("contentRating[]", "suggestive")
 	params.Add("contentRating[]", "erotica")
 	params.Add("contentRating[]", "pornographic")
+	for _, language := range getTranslatedLanguages(nil) {
+		params.Add("availableTranslatedLanguage[]", language)
+	}
 
 	searchURL := baseURL + "?" + params.Encode()
 
@@ -137,6 +145,7 @@ func (s *Source) Search(term string, limit int) ([]*models.MangaSearchResult, er
 		mangaSearchResult.Description = mangaData.Attributes.Description.get()
 		mangaSearchResult.Status = mangaData.Attributes.Status
 		mangaSearchResult.Year = mangaData.Attributes.Year
+		mangaSearchResult.Languages = mangaData.Attributes.AvailableTranslatedLanguages
 		mangaSearchResult.LastChapter = mangaData.Attributes.LastChapter
 		if mangaSearchResult.LastChapter == "0" || mangaSearchResult.LastChapter == "" {
 			if mangaData.Attributes.LatestUploadedChapter != "" {
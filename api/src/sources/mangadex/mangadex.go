@@ -2,11 +2,19 @@
 // Some of the code of this package is based/copy from https://github.com/darylhjd/mangodex/
 package mangadex
 
+import (
+	"github.com/diogovalentte/mantium/api/src/config"
+	"github.com/diogovalentte/mantium/api/src/sources/models"
+)
+
 var (
 	baseSiteURL    = "https://mangadex.org"
 	baseAPIURL     = "https://api.mangadex.org"
 	baseUploadsURL = "https://uploads.mangadex.org"
 	mangadexClient = NewMangadexClient()
+	// defaultTranslatedLanguages are the languages of the chapters if the
+	// MangaDex translated languages are not configured.
+	defaultTranslatedLanguages = []string{"en"}
 )
 
 // Source is the implementation of the manga.Source interface for the MangaDex source
@@ -23,3 +31,16 @@ func (s *Source) checkClient() {
 		s.client = mangadexClient
 	}
 }
+
+// getTranslatedLanguages returns the languages of the filter, or the
+// configured default translated languages if the filter doesn't have languages.
+func getTranslatedLanguages(filter *models.ChapterFilter) []string {
+	if filter != nil && len(filter.Languages) > 0 {
+		return filter.Languages
+	}
+	if len(config.GlobalConfigs.MangaDex.TranslatedLanguages) > 0 {
+		return config.GlobalConfigs.MangaDex.TranslatedLanguages
+	}
+
+	return defaultTranslatedLanguages
+}
@@ -82,6 +82,8 @@ type mangaAttributes struct {
 	AltTitles             []localisedStrings `json:"altTitles"`
 	Year                  int                `json:"year"`
 	LatestUploadedChapter string             `json:"latestUploadedChapter"`
+	// AvailableTranslatedLanguages are the languages of the manga's chapters
+	AvailableTranslatedLanguages []string `json:"availableTranslatedLanguages"`
 }
 
 type coverAttributes map[string]any
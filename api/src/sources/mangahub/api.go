@@ -23,11 +23,11 @@ type Client struct {
 // NewMangaHubClient creates a new MangaHub API client
 func NewMangaHubClient() *Client {
 	client := http.Client{
-		Transport: &http.Transport{
+		Transport: util.NewSourcesTransport("mangahub", &http.Transport{
 			TLSClientConfig: &tls.Config{
 				MaxVersion: tls.VersionTLS12,
 			},
-		},
+		}),
 	}
 
 	header := http.Header{}
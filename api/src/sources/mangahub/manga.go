@@ -5,7 +5,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/diogovalentte/mantium/api/src/errordefs"
 	"github.com/diogovalentte/mantium/api/src/manga"
@@ -56,7 +55,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 	// Cover Image
 	if mangaAPIResp.Data.Manga.Image != "" {
 		coverImgURL := baseUploadsURL + "/" + mangaAPIResp.Data.Manga.Image
-		coverImg, resized, err := util.GetImageFromURL(coverImgURL, 3, 1*time.Second)
+		coverImg, resized, err := util.GetImageFromURL("mangahub", coverImgURL)
 		if err == nil {
 			mangaReturn.CoverImgURL = coverImgURL
 			mangaReturn.CoverImgResized = resized
@@ -61,7 +61,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 	}
 
 	if coverImgURL != "" {
-		coverImg, resized, err := util.GetImageFromURL(coverImgURL, 3, 1*time.Second)
+		coverImg, resized, err := util.GetImageFromURL("mangaplus", coverImgURL)
 		if err == nil {
 			mangaReturn.CoverImgURL = coverImgURL
 			mangaReturn.CoverImgResized = resized
@@ -6,9 +6,14 @@ import (
 
 	"github.com/diogovalentte/mantium/api/src/errordefs"
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/sources/sourcestest"
 	"github.com/diogovalentte/mantium/api/src/util"
 )
 
+func TestMain(m *testing.M) {
+	sourcestest.Main(m)
+}
+
 type mangaTestType struct {
 	expected *manga.Manga
 	url      string
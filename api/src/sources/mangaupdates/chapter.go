@@ -9,6 +9,7 @@ import (
 
 	"github.com/diogovalentte/mantium/api/src/errordefs"
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/sources/models"
 	"github.com/diogovalentte/mantium/api/src/util"
 )
 
@@ -30,7 +31,7 @@ func (s *Source) GetChapterMetadata(mangaURL, mangaInternalID, chapter, _, chapt
 	errChan := make(chan error)
 	done := make(chan struct{})
 
-	go s.generateMangaChapters(mangaInternalID, chaptersChan, errChan, done)
+	go s.generateMangaChapters(mangaInternalID, nil, chaptersChan, errChan, done)
 
 	var returnChapter *manga.Chapter
 	if chapterInternalID != "" {
@@ -69,7 +70,13 @@ func (s *Source) GetChapterMetadata(mangaURL, mangaInternalID, chapter, _, chapt
 	}
 }
 
+// GetLastChapterMetadata returns the last released chapter of a manga
 func (s *Source) GetLastChapterMetadata(mangaURL, mangaInternalID string) (*manga.Chapter, error) {
+	return s.GetLastChapterMetadataWithFilter(mangaURL, mangaInternalID, nil)
+}
+
+// GetLastChapterMetadataWithFilter returns the last released chapter of a manga that matches the filter
+func (s *Source) GetLastChapterMetadataWithFilter(mangaURL, mangaInternalID string, filter *models.ChapterFilter) (*manga.Chapter, error) {
 	s.checkClient()
 
 	errorContext := "error while getting last chapter metadata of manga with URL '%s' and internal ID '%s'"
@@ -86,7 +93,7 @@ func (s *Source) GetLastChapterMetadata(mangaURL, mangaInternalID string) (*mang
 	errChan := make(chan error)
 	done := make(chan struct{})
 
-	go s.generateMangaChapters(mangaInternalID, chaptersChan, errChan, done)
+	go s.generateMangaChapters(mangaInternalID, filter, chaptersChan, errChan, done)
 
 	var returnChapter *manga.Chapter
 	go func() {
@@ -110,6 +117,11 @@ func (s *Source) GetLastChapterMetadata(mangaURL, mangaInternalID string) (*mang
 
 // GetChaptersMetadata returns the chapters of a manga
 func (s *Source) GetChaptersMetadata(mangaURL, mangaInternalID string) ([]*manga.Chapter, error) {
+	return s.GetChaptersMetadataWithFilter(mangaURL, mangaInternalID, nil)
+}
+
+// GetChaptersMetadataWithFilter returns the chapters of a manga that match the filter
+func (s *Source) GetChaptersMetadataWithFilter(mangaURL, mangaInternalID string, filter *models.ChapterFilter) ([]*manga.Chapter, error) {
 	s.checkClient()
 
 	errorContext := "error while getting chapters metadata"
@@ -126,7 +138,7 @@ func (s *Source) GetChaptersMetadata(mangaURL, mangaInternalID string) ([]*manga
 	errChan := make(chan error)
 	done := make(chan struct{})
 
-	go s.generateMangaChapters(mangaInternalID, chaptersChan, errChan, done)
+	go s.generateMangaChapters(mangaInternalID, filter, chaptersChan, errChan, done)
 
 	var chapters []*manga.Chapter
 	go func() {
@@ -147,7 +159,8 @@ func (s *Source) GetChaptersMetadata(mangaURL, mangaInternalID string) ([]*manga
 // generateMangaChapters generates the chapters of a manga and sends them to the channel.
 // It sends an error to the error channel if something goes wrong.
 // It closes the chapters channel when there is no more chapters to send.
-func (s *Source) generateMangaChapters(mangaInternalID string, chaptersChan chan *manga.Chapter, errChan chan error, done chan struct{}) {
+// Only the releases of the filter groups are sent. The releases don't have languages.
+func (s *Source) generateMangaChapters(mangaInternalID string, filter *models.ChapterFilter, chaptersChan chan *manga.Chapter, errChan chan error, done chan struct{}) {
 	defer close(chaptersChan)
 
 	releasesAPIURL := fmt.Sprintf("%s/v1/releases/search", baseAPIURL)
@@ -178,6 +191,9 @@ func (s *Source) generateMangaChapters(mangaInternalID string, chaptersChan chan
 		}
 
 		for _, record := range chaptersAPIResp.Results {
+			if !filter.MatchesGroups(record.Record.groups()...) {
+				continue
+			}
 			chaptersChan <- getChapterFromResp(record.Record, mangaInternalID)
 		}
 
@@ -202,8 +218,23 @@ type getChaptersAPIResponse struct {
 type releaseAPIResp struct {
 	Title       string `json:"title"`
 	Chapter     string `json:"chapter"`
+	Volume      string `json:"volume"`
 	ReleaseDate string `json:"release_date"`
-	ID          int    `json:"id"`
+	Groups      []struct {
+		Name    string `json:"name"`
+		GroupID int64  `json:"group_id"`
+	} `json:"groups"`
+	ID int `json:"id"`
+}
+
+// groups returns the IDs and names of the release groups.
+func (r releaseAPIResp) groups() []string {
+	groups := make([]string, 0, len(r.Groups)*2)
+	for _, group := range r.Groups {
+		groups = append(groups, strconv.FormatInt(group.GroupID, 10), group.Name)
+	}
+
+	return groups
 }
 
 func getChapterFromResp(release releaseAPIResp, mangaInternalID string) *manga.Chapter {
@@ -217,6 +248,7 @@ func getChapterFromResp(release releaseAPIResp, mangaInternalID string) *manga.C
 	chapter := &manga.Chapter{
 		Name:       release.Title,
 		Chapter:    release.Chapter,
+		Volume:     release.Volume,
 		UpdatedAt:  releaseDate,
 		URL:        url,
 		InternalID: strconv.Itoa(release.ID),
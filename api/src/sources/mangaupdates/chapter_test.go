@@ -1,11 +1,13 @@
 package mangaupdates
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/sources/models"
 )
 
 type chapterTestType struct {
@@ -249,3 +251,21 @@ func TestGetChaptersMetadata(t *testing.T) {
 		}
 	})
 }
+
+func TestReleaseGroups(t *testing.T) {
+	var release releaseAPIResp
+	err := json.Unmarshal([]byte(`{"chapter": "10", "groups": [{"name": "Group A", "group_id": 123}, {"name": "Group B", "group_id": 456}]}`), &release)
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	groups := release.groups()
+	if !reflect.DeepEqual(groups, []string{"123", "Group A", "456", "Group B"}) {
+		t.Fatalf("unexpected groups: %v", groups)
+	}
+	if !(&models.ChapterFilter{Groups: []string{"456"}}).MatchesGroups(groups...) {
+		t.Fatalf("expected the release to match the group ID")
+	}
+	if (&models.ChapterFilter{Groups: []string{"Group C"}}).MatchesGroups(groups...) {
+		t.Fatalf("expected the release to not match another group")
+	}
+}
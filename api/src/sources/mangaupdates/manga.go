@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/gocolly/colly/v2"
 
@@ -18,6 +17,12 @@ import (
 
 // GetMangaMetadata returns the metadata of a manga given its URL.
 func (s *Source) GetMangaMetadata(mangaURL, mangaInternalID string) (*manga.Manga, error) {
+	return s.GetMangaMetadataWithFilter(mangaURL, mangaInternalID, nil)
+}
+
+// GetMangaMetadataWithFilter returns the metadata of a manga given its URL,
+// with the last released chapter that matches the filter.
+func (s *Source) GetMangaMetadataWithFilter(mangaURL, mangaInternalID string, filter *models.ChapterFilter) (*manga.Manga, error) {
 	s.checkClient()
 
 	errorContext := "error while getting manga metadata"
@@ -42,7 +47,7 @@ func (s *Source) GetMangaMetadata(mangaURL, mangaInternalID string) (*manga.Mang
 	mangaReturn.URL = mangaAPIResp.URL
 	mangaReturn.InternalID = strconv.Itoa(mangaAPIResp.ID)
 
-	lastReleasedChapter, err := s.GetLastChapterMetadata(mangaURL, mangaInternalID)
+	lastReleasedChapter, err := s.GetLastChapterMetadataWithFilter(mangaURL, mangaInternalID, filter)
 	if err != nil {
 		if !util.ErrorContains(err, errordefs.ErrChapterNotFound.Message) {
 			return nil, util.AddErrorContext(errorContext, err)
@@ -61,7 +66,7 @@ func (s *Source) GetMangaMetadata(mangaURL, mangaInternalID string) (*manga.Mang
 		}
 	}
 	if coverURL != "" {
-		coverImg, resized, err := util.GetImageFromURL(coverURL, 3, 1*time.Second)
+		coverImg, resized, err := util.GetImageFromURL("mangaupdates", coverURL)
 		if err == nil {
 			mangaReturn.CoverImgURL = coverURL
 			mangaReturn.CoverImgResized = resized
@@ -144,7 +149,8 @@ type seriesAPIResp struct {
 func (s *Source) getMangaIDFromURL(mangaURL string) (string, error) {
 	errorContext := "error while getting manga ID from URL"
 
-	c := colly.NewCollector(
+	c := util.NewSourcesCollector(
+		"mangaupdates",
 		colly.AllowURLRevisit(),
 	)
 
@@ -161,7 +167,7 @@ func (s *Source) getMangaIDFromURL(mangaURL string) (string, error) {
 		return
 	})
 
-	err := c.Visit(mangaURL)
+	err := util.VisitSourcePage(c, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return "", util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -6,8 +6,13 @@ import (
 	"time"
 
 	"github.com/diogovalentte/mantium/api/src/manga"
+	"github.com/diogovalentte/mantium/api/src/sources/sourcestest"
 )
 
+func TestMain(m *testing.M) {
+	sourcestest.Main(m)
+}
+
 type mangasTestT struct {
 	expected        *manga.Manga
 	url             string
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/diogovalentte/mantium/api/src/manga"
+import (
+	"strings"
+
+	"github.com/diogovalentte/mantium/api/src/manga"
+)
 
 // Source is the interface for a manga source
 type Source interface {
@@ -18,6 +22,60 @@ type Source interface {
 	GetName() string
 }
 
+// FilterableSource is implemented by sources with chapters from multiple groups and
+// languages, which can get only the chapters that match a chapter filter.
+type FilterableSource interface {
+	Source
+	// GetMangaMetadataWithFilter returns a manga with the last released chapter that matches the filter
+	GetMangaMetadataWithFilter(mangaURL, mangaInternalID string, filter *ChapterFilter) (*manga.Manga, error)
+	// GetLastChapterMetadataWithFilter returns the last released chapter that matches the filter
+	GetLastChapterMetadataWithFilter(mangaURL, mangaInternalID string, filter *ChapterFilter) (*manga.Chapter, error)
+	// GetChaptersMetadataWithFilter returns all chapters of a manga that match the filter
+	GetChaptersMetadataWithFilter(mangaURL, mangaInternalID string, filter *ChapterFilter) ([]*manga.Chapter, error)
+}
+
+// ChapterFilter filters the chapters of a manga by their groups and translated languages.
+// Empty fields don't filter the chapters.
+type ChapterFilter struct {
+	// Groups are the names or IDs in the source of the groups, compared case-insensitively.
+	Groups []string
+	// Languages are the codes of the translated languages, like "en" or "pt-br".
+	// Sources that have a default language use it if there are no languages.
+	Languages []string
+}
+
+// IsEmpty returns true if the filter doesn't filter any chapter.
+func (f *ChapterFilter) IsEmpty() bool {
+	return f == nil || (len(f.Groups) == 0 && len(f.Languages) == 0)
+}
+
+// MatchesGroups returns true if any of the chapter groups' names or IDs
+// is one of the filter groups, or if the filter doesn't have groups.
+func (f *ChapterFilter) MatchesGroups(groups ...string) bool {
+	if f == nil || len(f.Groups) == 0 {
+		return true
+	}
+	for _, group := range groups {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+		for _, filterGroup := range f.Groups {
+			if strings.EqualFold(group, filterGroup) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// NewChapterFilter returns the chapter filter of a manga, which gets only
+// the chapters of its preferred groups in its preferred languages.
+func NewChapterFilter(m *manga.Manga) *ChapterFilter {
+	return &ChapterFilter{Groups: m.PreferredGroups(), Languages: m.PreferredLanguages()}
+}
+
 type MangaSearchResult struct {
 	URL            string
 	Name           string
@@ -28,7 +86,10 @@ type MangaSearchResult struct {
 	LastChapter    string
 	LastChapterURL string
 	InternalID     string
-	Year           int
+	// Languages are the translated languages of the manga's chapters,
+	// only set by sources with chapters in multiple languages.
+	Languages []string
+	Year      int
 }
 
 var DefaultCoverImgURL = "https://i.imgur.com/jMy7evE.jpeg"
@@ -18,7 +18,9 @@ type Client struct {
 
 // newAPIClient creates a new Rawkuma API client
 func newAPIClient() *Client {
-	client := http.Client{}
+	client := http.Client{
+		Transport: util.NewSourcesTransport("rawkuma", nil),
+	}
 
 	kuma := &Client{
 		client: &client,
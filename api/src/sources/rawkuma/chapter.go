@@ -49,7 +49,7 @@ func (s *Source) getChapterMetadataByURL(chapterURL string) (*manga.Chapter, err
 		chapterReturn.Name = "Chapter " + chapterReturn.Chapter
 	})
 
-	err := s.col.Visit(chapterURL)
+	err := util.VisitSourcePage(s.col, chapterURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, errordefs.ErrChapterNotFound
@@ -101,7 +101,7 @@ func (s *Source) GetChaptersMetadata(mangaURL, mangaInternalID string) ([]*manga
 			mangaInternalID = HTMLMangaID[1]
 		})
 
-		err := s.col.Visit(mangaURL)
+		err := util.VisitSourcePage(s.col, mangaURL)
 		if err != nil {
 			if err.Error() == "Not Found" {
 				return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
@@ -157,7 +157,7 @@ func getChapterList(internalMangaID string) ([]*manga.Chapter, error) {
 		chapters = append(chapters, chapter)
 	})
 
-	err = s.col.Visit(fmt.Sprintf("%s%d", chapterListURL, mangaID))
+	err = util.VisitSourcePage(s.col, fmt.Sprintf("%s%d", chapterListURL, mangaID))
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, errordefs.ErrMangaNotFound
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
@@ -42,7 +41,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 		}
 		coverURL := e.Attr("src")
 
-		coverImg, resized, err := util.GetImageFromURL(coverURL, 3, 1*time.Second)
+		coverImg, resized, err := util.GetImageFromURL("rawkuma", coverURL)
 		if err == nil {
 			mangaReturn.CoverImgURL = coverURL
 			mangaReturn.CoverImgResized = resized
@@ -70,7 +69,7 @@ func (s *Source) GetMangaMetadata(mangaURL, _ string) (*manga.Manga, error) {
 		mangaReturn.LastReleasedChapter.Type = 1
 	})
 
-	err := s.col.Visit(mangaURL)
+	err := util.VisitSourcePage(s.col, mangaURL)
 	if err != nil {
 		if err.Error() == "Not Found" {
 			return nil, util.AddErrorContext(errorContext, errordefs.ErrMangaNotFound)
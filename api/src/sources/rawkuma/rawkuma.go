@@ -3,6 +3,8 @@ package rawkuma
 
 import (
 	"github.com/gocolly/colly/v2"
+
+	"github.com/diogovalentte/mantium/api/src/util"
 )
 
 var baseSiteURL = "https://rawkuma.net"
@@ -20,7 +22,8 @@ func (Source) GetName() string {
 var userAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:30.0) Gecko/20100101 Firefox/30.0"
 
 func newCollector() *colly.Collector {
-	c := colly.NewCollector(
+	c := util.NewSourcesCollector(
+		"rawkuma",
 		colly.UserAgent(userAgent),
 	)
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/diogovalentte/mantium/api/src/db"
 	"github.com/diogovalentte/mantium/api/src/manga"
@@ -73,6 +74,14 @@ func GetSources() map[string]models.Source {
 
 // GetMangaMetadata gets the metadata of a manga using a source
 func GetMangaMetadata(mangaURL, internalID string) (*manga.Manga, error) {
+	return GetMangaMetadataWithFilter(mangaURL, internalID, nil)
+}
+
+// GetMangaMetadataWithFilter gets the metadata of a manga using a source.
+// If the source has chapters from multiple groups or languages, the last released
+// chapter is the last chapter that matches the filter. The filter can be nil.
+// The result is recorded in the source's health, see GetSourcesHealth.
+func GetMangaMetadataWithFilter(mangaURL, internalID string, filter *models.ChapterFilter) (*manga.Manga, error) {
 	contextError := "error while getting metadata of manga with URL '%s' and internal ID '%s' from source"
 
 	source, err := GetSource(mangaURL)
@@ -81,7 +90,9 @@ func GetMangaMetadata(mangaURL, internalID string) (*manga.Manga, error) {
 	}
 	contextError = fmt.Sprintf("(%s) %s", source.GetName(), contextError)
 
-	manga, err := getManga(mangaURL, internalID, source)
+	start := time.Now()
+	manga, err := getManga(mangaURL, internalID, filter, source)
+	recordSourceCall(source.GetName(), start, err)
 	if err != nil {
 		return nil, util.AddErrorContext(fmt.Sprintf(contextError, mangaURL, internalID), err)
 	}
@@ -89,7 +100,8 @@ func GetMangaMetadata(mangaURL, internalID string) (*manga.Manga, error) {
 	return manga, nil
 }
 
-// SearchManga searches for a manga using a source
+// SearchManga searches for a manga using a source.
+// The result is recorded in the source's health, see GetSourcesHealth.
 func SearchManga(term, sourceName string, limit int) ([]*models.MangaSearchResult, error) {
 	contextError := "error while searching '%s' in '%s'"
 
@@ -99,7 +111,9 @@ func SearchManga(term, sourceName string, limit int) ([]*models.MangaSearchResul
 	}
 	contextError = fmt.Sprintf("(%s) %s", source.GetName(), contextError)
 
+	start := time.Now()
 	results, err := searchManga(term, limit, source)
+	recordSourceCall(source.GetName(), start, err)
 	if err != nil {
 		return nil, util.AddErrorContext(fmt.Sprintf(contextError, term, source), err)
 	}
@@ -129,6 +143,13 @@ func GetChapterMetadata(mangaURL, mangaInternalID, chapter, chapterURL, chapterI
 
 // GetMangaChapters gets the chapters of a manga using a source
 func GetMangaChapters(mangaURL, mangaInternalID string) ([]*manga.Chapter, error) {
+	return GetMangaChaptersWithFilter(mangaURL, mangaInternalID, nil)
+}
+
+// GetMangaChaptersWithFilter gets the chapters of a manga using a source.
+// If the source has chapters from multiple groups or languages, only
+// the chapters that match the filter are returned. The filter can be nil.
+func GetMangaChaptersWithFilter(mangaURL, mangaInternalID string, filter *models.ChapterFilter) ([]*manga.Chapter, error) {
 	contextError := "error while getting chapters from manga with URL '%s' and internal ID '%s' from source"
 
 	source, err := GetSource(mangaURL)
@@ -137,7 +158,7 @@ func GetMangaChapters(mangaURL, mangaInternalID string) ([]*manga.Chapter, error
 	}
 	contextError = fmt.Sprintf("(%s) %s", source.GetName(), contextError)
 
-	chapters, err := getChapters(mangaURL, mangaInternalID, source)
+	chapters, err := getChapters(mangaURL, mangaInternalID, filter, source)
 	if err != nil {
 		return nil, util.AddErrorContext(fmt.Sprintf(contextError, mangaURL, mangaInternalID), err)
 	}
@@ -192,7 +213,10 @@ func urlToSource(urlString string) (string, error) {
 	return "", util.AddErrorContext(fmt.Sprintf(errorContext, urlString), fmt.Errorf("source not found"))
 }
 
-func getManga(mangaURL, mangaInternalID string, source models.Source) (*manga.Manga, error) {
+func getManga(mangaURL, mangaInternalID string, filter *models.ChapterFilter, source models.Source) (*manga.Manga, error) {
+	if filterableSource, ok := source.(models.FilterableSource); ok && !filter.IsEmpty() {
+		return filterableSource.GetMangaMetadataWithFilter(mangaURL, mangaInternalID, filter)
+	}
 	return source.GetMangaMetadata(mangaURL, mangaInternalID)
 }
 
@@ -204,6 +228,9 @@ func getChapter(mangaURL, mangaInternalID, chapter, chapterURL, chapterInternalI
 	return source.GetChapterMetadata(mangaURL, mangaInternalID, chapter, chapterURL, chapterInternalID)
 }
 
-func getChapters(mangaURL, mangaInternalID string, source models.Source) ([]*manga.Chapter, error) {
+func getChapters(mangaURL, mangaInternalID string, filter *models.ChapterFilter, source models.Source) ([]*manga.Chapter, error) {
+	if filterableSource, ok := source.(models.FilterableSource); ok && !filter.IsEmpty() {
+		return filterableSource.GetChaptersMetadataWithFilter(mangaURL, mangaInternalID, filter)
+	}
 	return source.GetChaptersMetadata(mangaURL, mangaInternalID)
 }
@@ -0,0 +1,26 @@
+// Package sourcestest has the helpers shared by the sources' tests.
+package sourcestest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/diogovalentte/mantium/api/src/util"
+)
+
+// FixturesDir is the directory of the source's fixtures, relative to the source's package.
+const FixturesDir = "testdata/fixtures"
+
+// Main sets up the sources' recorder with the source's fixtures and runs the tests.
+// It should be called by the TestMain of each source's tests.
+// To record the fixtures, run the tests with SOURCES_RECORDER_MODE=record.
+func Main(m *testing.M) {
+	err := util.SetupSourcesRecorder(FixturesDir)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	exitCode := m.Run()
+	os.Exit(exitCode)
+}
@@ -0,0 +1,239 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// RecorderMode is the mode of a Recorder.
+type RecorderMode string
+
+const (
+	// RecorderModeReplay returns the recorded responses, and fails the requests without a fixture.
+	RecorderModeReplay RecorderMode = "replay"
+	// RecorderModeRecord makes the requests and records the responses as fixtures.
+	RecorderModeRecord RecorderMode = "record"
+	// RecorderModeOff makes the requests without recording them.
+	RecorderModeOff RecorderMode = "off"
+)
+
+// RecorderModeEnv is the environment variable used to set the mode of the sources' recorder in the tests.
+const RecorderModeEnv = "SOURCES_RECORDER_MODE"
+
+// Recorder records the HTTP responses as fixtures, and replays them, so the sources can be tested offline.
+type Recorder struct {
+	// Dir is the directory of the fixtures, usually the package's testdata directory.
+	Dir  string
+	Mode RecorderMode
+	mu   sync.Mutex
+}
+
+// NewRecorder returns a recorder of the fixtures in the directory.
+func NewRecorder(dir string, mode RecorderMode) (*Recorder, error) {
+	switch mode {
+	case RecorderModeReplay, RecorderModeRecord, RecorderModeOff:
+	default:
+		return nil, fmt.Errorf("invalid recorder mode '%s', should be '%s', '%s', or '%s'", mode, RecorderModeReplay, RecorderModeRecord, RecorderModeOff)
+	}
+
+	return &Recorder{Dir: dir, Mode: mode}, nil
+}
+
+// fixture is a recorded request and its response.
+type fixture struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"requestBody,omitempty"`
+	StatusCode  int         `json:"statusCode"`
+	Header      http.Header `json:"header"`
+	Body        string      `json:"body,omitempty"`
+	// BodyBase64 is the response body if it's not valid UTF-8, like images.
+	BodyBase64 []byte `json:"bodyBase64,omitempty"`
+}
+
+// RoundTrip replays or records the request, depending on the recorder mode.
+// The requests are made using the base transport, or http.DefaultTransport if it's nil.
+func (r *Recorder) RoundTrip(req *http.Request, base http.RoundTripper) (*http.Response, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if r.Mode == RecorderModeOff {
+		return base.RoundTrip(req)
+	}
+
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, AddErrorContext("error while reading request body", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(requestBody)), nil
+		}
+	}
+	fixturePath := filepath.Join(r.Dir, fixtureName(req.Method, req.URL.String(), requestBody))
+
+	if r.Mode == RecorderModeReplay {
+		f, err := readFixture(fixturePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("no fixture '%s' for the request, record it from the live site by running the source's tests with %s=%s, like '%s=%s go test ./src/sources/...', and commit the fixtures", fixturePath, RecorderModeEnv, RecorderModeRecord, RecorderModeEnv, RecorderModeRecord)
+			}
+			return nil, AddErrorContext(fmt.Sprintf("error while reading fixture '%s'", fixturePath), err)
+		}
+		return f.response(req), nil
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, AddErrorContext("error while reading response body", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	f := &fixture{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestBody: string(requestBody),
+		StatusCode:  resp.StatusCode,
+		Header:      resp.Header,
+	}
+	if utf8.Valid(body) {
+		f.Body = string(body)
+	} else {
+		f.BodyBase64 = body
+	}
+	err = r.writeFixture(fixturePath, f)
+	if err != nil {
+		return nil, AddErrorContext(fmt.Sprintf("error while writing fixture '%s'", fixturePath), err)
+	}
+
+	return resp, nil
+}
+
+func (f *fixture) response(req *http.Request) *http.Response {
+	body := []byte(f.Body)
+	if f.BodyBase64 != nil {
+		body = f.BodyBase64
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode)),
+		StatusCode:    f.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        f.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func readFixture(path string) (*fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f fixture
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (r *Recorder) writeFixture(path string, f *fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+var fixtureNameRegex = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// fixtureName returns the file name of the request's fixture, like
+// "GET_api.mangadex.org_manga_abc_1a2b3c4d5e6f.json". The hash
+// of the method, URL, and body differentiates requests with the same path.
+func fixtureName(method, rawURL string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + rawURL + "\n"))
+	hash.Write(body)
+
+	readable := rawURL
+	readable = strings.TrimPrefix(readable, "https://")
+	readable = strings.TrimPrefix(readable, "http://")
+	if i := strings.IndexAny(readable, "?#"); i >= 0 {
+		readable = readable[:i]
+	}
+	readable = strings.Trim(fixtureNameRegex.ReplaceAllString(readable, "_"), "_")
+	if len(readable) > 80 {
+		readable = readable[:80]
+	}
+
+	return fmt.Sprintf("%s_%s_%s.json", method, readable, hex.EncodeToString(hash.Sum(nil))[:12])
+}
+
+var (
+	sourcesRecorder   *Recorder
+	sourcesRecorderMu sync.RWMutex
+)
+
+// SetSourcesRecorder sets the recorder of the sources' requests. If nil, the requests are made normally.
+func SetSourcesRecorder(r *Recorder) {
+	sourcesRecorderMu.Lock()
+	defer sourcesRecorderMu.Unlock()
+	sourcesRecorder = r
+}
+
+// SetupSourcesRecorder sets a recorder of the fixtures in the directory as the sources' recorder.
+// The mode is read from the SOURCES_RECORDER_MODE environment variable and defaults to replay,
+// so the tests never request the live sites unless asked to. The requests without a fixture fail.
+// It should be called in the TestMain of the sources' tests.
+func SetupSourcesRecorder(dir string) error {
+	mode := RecorderMode(os.Getenv(RecorderModeEnv))
+	if mode == "" {
+		mode = RecorderModeReplay
+	}
+	r, err := NewRecorder(dir, mode)
+	if err != nil {
+		return AddErrorContext("error while setting up the sources recorder", err)
+	}
+	SetSourcesRecorder(r)
+
+	return nil
+}
+
+func getSourcesRecorder() *Recorder {
+	sourcesRecorderMu.RLock()
+	defer sourcesRecorderMu.RUnlock()
+	return sourcesRecorder
+}
@@ -0,0 +1,155 @@
+package util
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Run("Should record the responses and replay them offline", func(t *testing.T) {
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(r.URL.Query().Get("q") + string(body)))
+		}))
+		dir := t.TempDir()
+
+		recorder, err := NewRecorder(dir, RecorderModeRecord)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client := &http.Client{Transport: &recorderClientTransport{recorder}}
+		for _, body := range []string{"a", "b"} {
+			resp, err := client.Post(server.URL+"/search?q=term", "text/plain", strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			respBody, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(respBody) != "term"+body {
+				t.Fatalf("unexpected recorded response body: %s", respBody)
+			}
+		}
+		server.Close()
+
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 fixtures, got %d", len(entries))
+		}
+
+		recorder.Mode = RecorderModeReplay
+		for _, body := range []string{"b", "a"} {
+			resp, err := client.Post(server.URL+"/search?q=term", "text/plain", strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			respBody, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusCreated || resp.Header.Get("Content-Type") != "text/plain" || string(respBody) != "term"+body {
+				t.Fatalf("unexpected replayed response: %d %v %s", resp.StatusCode, resp.Header, respBody)
+			}
+		}
+		if requests != 2 {
+			t.Fatalf("expected only the recorded requests to reach the server, got %d requests", requests)
+		}
+	})
+	t.Run("Should fail requests without a fixture in replay mode", func(t *testing.T) {
+		recorder, err := NewRecorder(t.TempDir(), RecorderModeReplay)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client := &http.Client{Transport: &recorderClientTransport{recorder}}
+		_, err = client.Get("https://example.com/manga")
+		if err == nil || !strings.Contains(err.Error(), RecorderModeEnv) {
+			t.Fatalf("expected a missing fixture error, got %v", err)
+		}
+	})
+	t.Run("Should not accept an invalid mode", func(t *testing.T) {
+		_, err := NewRecorder(t.TempDir(), "replay-all")
+		if err == nil {
+			t.Fatalf("expected an invalid mode error")
+		}
+	})
+}
+
+func TestSourcesTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("live"))
+	}))
+	defer server.Close()
+
+	// The client is created before the recorder is set, like the sources' package-level clients
+	client := &http.Client{Transport: NewSourcesTransport("", nil)}
+	recorder, err := NewRecorder(t.TempDir(), RecorderModeReplay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	SetSourcesRecorder(recorder)
+	defer SetSourcesRecorder(nil)
+
+	_, err = client.Get(server.URL)
+	if err == nil {
+		t.Fatalf("expected the request to be replayed by the recorder")
+	}
+
+	SetSourcesRecorder(nil)
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "live" {
+		t.Fatalf("unexpected response body: %s", body)
+	}
+}
+
+func TestFixtureName(t *testing.T) {
+	name := fixtureName("GET", "https://api.mangadex.org/manga/abc/feed?limit=1", nil)
+	if !strings.HasPrefix(name, "GET_api.mangadex.org_manga_abc_feed_") || !strings.HasSuffix(name, ".json") {
+		t.Fatalf("unexpected fixture name: %s", name)
+	}
+	if name == fixtureName("GET", "https://api.mangadex.org/manga/abc/feed?limit=2", nil) {
+		t.Fatalf("expected requests with different queries to have different fixtures")
+	}
+	if fixtureName("POST", "https://api.mangaupdates.com/v1/releases/search", []byte(`{"page":1}`)) == fixtureName("POST", "https://api.mangaupdates.com/v1/releases/search", []byte(`{"page":2}`)) {
+		t.Fatalf("expected requests with different bodies to have different fixtures")
+	}
+}
+
+// recorderClientTransport uses the recorder as an HTTP client transport.
+type recorderClientTransport struct {
+	recorder *Recorder
+}
+
+func (t *recorderClientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.recorder.RoundTrip(req, nil)
+}
+
+func TestSetupSourcesRecorder(t *testing.T) {
+	t.Setenv(RecorderModeEnv, "")
+	t.Cleanup(func() { SetSourcesRecorder(nil) })
+
+	dir := t.TempDir()
+	if err := SetupSourcesRecorder(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mode := getSourcesRecorder().Mode; mode != RecorderModeReplay {
+		t.Fatalf("expected the recorder to replay the fixtures by default, got %s", mode)
+	}
+
+	t.Setenv(RecorderModeEnv, string(RecorderModeOff))
+	if err := SetupSourcesRecorder(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mode := getSourcesRecorder().Mode; mode != RecorderModeOff {
+		t.Fatalf("expected the recorder mode to be read from %s, got %s", RecorderModeEnv, mode)
+	}
+}
@@ -0,0 +1,374 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// SourcesHTTPConfigs are the configurations of the sources' HTTP requests.
+type SourcesHTTPConfigs struct {
+	// Timeout is the timeout of each request attempt, including reading the response body.
+	Timeout time.Duration
+	// UserAgent, if not empty, replaces the user agent of all requests.
+	UserAgent string
+	// RequestsPerSecond is the rate limit of the requests to each domain.
+	RequestsPerSecond float64
+	// Burst is the number of requests to a domain that can be made at once before being rate limited.
+	Burst int
+	// DomainRequestsPerSecond overrides the RequestsPerSecond of domains, like "api.mangadex.org".
+	DomainRequestsPerSecond map[string]float64
+	// Retries is the number of times the requests that fail with a network error,
+	// a 429 status code, or a temporary 5xx status code are retried.
+	Retries int
+	// BackoffBase is the delay before the first retry. It's doubled in each retry, up to BackoffMax,
+	// and a random jitter is applied to it. The Retry-After header takes precedence over it.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// MaxRetryAfter is the longest Retry-After waited before retrying. If a domain asks to wait
+	// longer than it, the requests to the domain fail until the Retry-After passes.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultSourcesHTTPConfigs returns the default configurations of the sources' HTTP requests.
+func DefaultSourcesHTTPConfigs() *SourcesHTTPConfigs {
+	return &SourcesHTTPConfigs{
+		Timeout:                 30 * time.Second,
+		RequestsPerSecond:       2,
+		Burst:                   3,
+		DomainRequestsPerSecond: map[string]float64{},
+		Retries:                 3,
+		BackoffBase:             time.Second,
+		BackoffMax:              30 * time.Second,
+		MaxRetryAfter:           time.Minute,
+	}
+}
+
+var (
+	sourcesHTTPConfigs   = DefaultSourcesHTTPConfigs()
+	domainLimiters       = map[string]*domainLimiter{}
+	sourcesHTTPConfigsMu sync.RWMutex
+)
+
+// SetSourcesHTTPConfigs sets the configurations of the sources' HTTP requests.
+// The rate limits of the domains are reset.
+func SetSourcesHTTPConfigs(configs *SourcesHTTPConfigs) {
+	sourcesHTTPConfigsMu.Lock()
+	defer sourcesHTTPConfigsMu.Unlock()
+	sourcesHTTPConfigs = configs
+	domainLimiters = map[string]*domainLimiter{}
+}
+
+// GetSourcesHTTPConfigs returns the configurations of the sources' HTTP requests.
+func GetSourcesHTTPConfigs() *SourcesHTTPConfigs {
+	sourcesHTTPConfigsMu.RLock()
+	defer sourcesHTTPConfigsMu.RUnlock()
+	return sourcesHTTPConfigs
+}
+
+// getDomainLimiter returns the rate limiter of the domain, shared by all the sources' clients.
+func getDomainLimiter(domain string) *domainLimiter {
+	sourcesHTTPConfigsMu.Lock()
+	defer sourcesHTTPConfigsMu.Unlock()
+
+	limiter, ok := domainLimiters[domain]
+	if !ok {
+		rate := sourcesHTTPConfigs.RequestsPerSecond
+		if domainRate, ok := sourcesHTTPConfigs.DomainRequestsPerSecond[domain]; ok {
+			rate = domainRate
+		}
+		burst := float64(max(sourcesHTTPConfigs.Burst, 1))
+		limiter = &domainLimiter{domain: domain, rate: rate, burst: burst, tokens: burst, last: time.Now()}
+		domainLimiters[domain] = limiter
+	}
+
+	return limiter
+}
+
+// domainLimiter is a token bucket rate limiter of the requests to a domain.
+type domainLimiter struct {
+	domain string
+	// rate is the number of tokens added per second. If 0, the requests are not rate limited.
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// blockedUntil is set when the domain responds with a Retry-After header.
+	blockedUntil time.Time
+	mu           sync.Mutex
+}
+
+// wait waits until a request can be made to the domain, or the context is done.
+// If the domain is blocked for longer than maxBlock, it returns an error without waiting.
+func (l *domainLimiter) wait(ctx context.Context, maxBlock time.Duration) error {
+	l.mu.Lock()
+	now := time.Now()
+	var delay time.Duration
+	if l.blockedUntil.After(now) {
+		delay = l.blockedUntil.Sub(now)
+		if delay > maxBlock {
+			l.mu.Unlock()
+			return fmt.Errorf("requests to '%s' are rate limited until %s", l.domain, l.blockedUntil.Format(time.RFC3339))
+		}
+	}
+	if l.rate > 0 {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		// The token is reserved now, so the requests are made in the order they arrive
+		l.tokens--
+		if l.tokens < 0 {
+			delay = max(delay, time.Duration(-l.tokens/l.rate*float64(time.Second)))
+		}
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.rate > 0 {
+			l.tokens++
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// block blocks the requests to the domain until the time.
+func (l *domainLimiter) block(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// sourcesTransport makes the requests of a source with the sources' recorder, if it's set.
+// The replayed requests are not rate limited.
+type sourcesTransport struct {
+	source string
+	base   http.RoundTripper
+}
+
+// NewSourcesTransport returns the transport that the source's HTTP clients and collectors should use.
+// The requests are rate limited and retried using the sources' HTTP configurations (see SourcesHTTPConfigs),
+// and made through the source's proxy (see SourcesProxies).
+// The requests are made using the base transport, or http.DefaultTransport if it's nil. If the base
+// transport is an *http.Transport, a copy of it using the source's proxy is used.
+// The sources' recorder is checked in each request, so the clients created
+// before the recorder is set, like the package-level clients, also use it.
+func NewSourcesTransport(source string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = sourcesBaseTransport
+	} else if t, ok := base.(*http.Transport); ok {
+		base = newProxyTransport(t)
+	}
+
+	return &sourcesTransport{source: source, base: &rateLimitedTransport{base: base}}
+}
+
+func (t *sourcesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.WithContext(context.WithValue(req.Context(), sourceContextKey{}, t.source))
+	if r := getSourcesRecorder(); r != nil {
+		return r.RoundTrip(req, t.base)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// rateLimitedTransport rate limits the requests by domain and retries the failed requests
+// with exponential backoff, using the sources' HTTP configurations.
+type rateLimitedTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	configs := GetSourcesHTTPConfigs()
+	limiter := getDomainLimiter(strings.ToLower(req.URL.Hostname()))
+	canRewind := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		err := limiter.wait(req.Context(), configs.MaxRetryAfter)
+		if err != nil {
+			return nil, err
+		}
+
+		attemptReq, cancel := newAttemptRequest(req, configs)
+		if attempt > 0 && req.GetBody != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, AddErrorContext("error while rewinding request body", err)
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			cancel()
+		} else {
+			resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+		}
+
+		retry, retryAfter := shouldRetry(req.Context(), resp, err)
+		if retryAfter > 0 {
+			limiter.block(time.Now().Add(retryAfter))
+		}
+		if !retry || attempt >= configs.Retries || !canRewind || retryAfter > configs.MaxRetryAfter {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		// The Retry-After is waited by the limiter
+		if retryAfter == 0 {
+			timer := time.NewTimer(backoff(attempt, configs.BackoffBase, configs.BackoffMax))
+			select {
+			case <-req.Context().Done():
+				timer.Stop()
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// newAttemptRequest returns a copy of the request with the attempt's timeout and the configured user agent.
+// The returned cancel function should be called when the attempt's response body is closed.
+func newAttemptRequest(req *http.Request, configs *SourcesHTTPConfigs) (*http.Request, context.CancelFunc) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if configs.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, configs.Timeout)
+	}
+	attemptReq := req.Clone(ctx)
+	if configs.UserAgent != "" {
+		attemptReq.Header.Set("User-Agent", configs.UserAgent)
+	}
+
+	return attemptReq, cancel
+}
+
+// shouldRetry returns whether a request should be retried and how long the server asked to wait before retrying.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) (bool, time.Duration) {
+	if err != nil {
+		return ctx.Err() == nil, 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true, parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true, 0
+	default:
+		return false, 0
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value, in seconds or as an HTTP date.
+// Returns 0 if the value is empty or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+
+	return 0
+}
+
+// backoff returns the delay before a retry, which is doubled in each attempt up to
+// maxDelay. The delay is randomly chosen between half and all of it.
+func backoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// cancelOnCloseBody cancels the request's context when the response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// NewSourcesCollector returns a colly collector that makes its requests with the source's transport.
+// Colly's own request timeout is disabled, as the transport times out each attempt.
+func NewSourcesCollector(source string, options ...colly.CollectorOption) *colly.Collector {
+	c := colly.NewCollector(options...)
+	c.WithTransport(NewSourcesTransport(source, nil))
+	c.SetRequestTimeout(0)
+	c.OnError(func(r *colly.Response, _ error) {
+		if r.StatusCode != 0 && r.Ctx != nil {
+			r.Ctx.Put(statusCodeContextKey, r.StatusCode)
+		}
+	})
+
+	return c
+}
+
+// statusCodeContextKey is the key of the response status code in the colly
+// request context when the response has an error status code.
+const statusCodeContextKey = "mantium_status_code"
+
+// StatusCodeError is the error returned by VisitSourcePage when the page
+// responds with an error status code. Its message is colly's error message,
+// which is the status text, like "Not Found".
+type StatusCodeError struct {
+	Err        error
+	StatusCode int
+}
+
+func (e *StatusCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusCodeError) Unwrap() error {
+	return e.Err
+}
+
+// VisitSourcePage visits the URL with a collector created by NewSourcesCollector.
+// If the page responds with an error status code, it returns a *StatusCodeError.
+func VisitSourcePage(c *colly.Collector, url string) error {
+	ctx := colly.NewContext()
+	err := c.Request(http.MethodGet, url, nil, ctx, nil)
+	if err != nil {
+		if statusCode, ok := ctx.GetAny(statusCodeContextKey).(int); ok {
+			return &StatusCodeError{Err: err, StatusCode: statusCode}
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package util
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// setTestSourcesHTTPConfigs sets sources' HTTP configurations with short delays for the test.
+func setTestSourcesHTTPConfigs(t *testing.T, modify func(*SourcesHTTPConfigs)) {
+	configs := DefaultSourcesHTTPConfigs()
+	configs.RequestsPerSecond = 0
+	configs.BackoffBase = time.Millisecond
+	configs.BackoffMax = 10 * time.Millisecond
+	if modify != nil {
+		modify(configs)
+	}
+	SetSourcesHTTPConfigs(configs)
+	t.Cleanup(func() { SetSourcesHTTPConfigs(DefaultSourcesHTTPConfigs()) })
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	t.Run("Should retry temporary errors", func(t *testing.T) {
+		setTestSourcesHTTPConfigs(t, nil)
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if requests.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write(body)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewSourcesTransport("", nil)}
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("body"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK || string(body) != "body" || requests.Load() != 3 {
+			t.Fatalf("unexpected response after %d requests: %d %s", requests.Load(), resp.StatusCode, body)
+		}
+	})
+	t.Run("Should return the last response after the retries", func(t *testing.T) {
+		setTestSourcesHTTPConfigs(t, func(c *SourcesHTTPConfigs) { c.Retries = 2 })
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewSourcesTransport("", nil)}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable || requests.Load() != 3 {
+			t.Fatalf("expected 3 requests and the last status code, got %d requests and %d", requests.Load(), resp.StatusCode)
+		}
+	})
+	t.Run("Should not retry client errors", func(t *testing.T) {
+		setTestSourcesHTTPConfigs(t, nil)
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewSourcesTransport("", nil)}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if requests.Load() != 1 {
+			t.Fatalf("expected 1 request, got %d", requests.Load())
+		}
+	})
+	t.Run("Should wait the Retry-After of 429 responses", func(t *testing.T) {
+		setTestSourcesHTTPConfigs(t, nil)
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewSourcesTransport("", nil)}
+		start := time.Now()
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || time.Since(start) < time.Second {
+			t.Fatalf("expected the request to be retried after 1 second, got %d after %s", resp.StatusCode, time.Since(start))
+		}
+	})
+	t.Run("Should fail fast while the domain asks to wait longer than the max Retry-After", func(t *testing.T) {
+		setTestSourcesHTTPConfigs(t, nil)
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewSourcesTransport("", nil)}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("expected the 429 response, got %d", resp.StatusCode)
+		}
+		_, err = client.Get(server.URL)
+		if err == nil || !strings.Contains(err.Error(), "rate limited") || requests.Load() != 1 {
+			t.Fatalf("expected a rate limited error without a request, got %v after %d requests", err, requests.Load())
+		}
+	})
+	t.Run("Should rate limit the requests to the domain", func(t *testing.T) {
+		setTestSourcesHTTPConfigs(t, func(c *SourcesHTTPConfigs) {
+			c.RequestsPerSecond = 20
+			c.Burst = 1
+		})
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		defer server.Close()
+
+		// Different clients share the domain's rate limit
+		start := time.Now()
+		for range 4 {
+			client := &http.Client{Transport: NewSourcesTransport("", nil)}
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+		}
+		if elapsed := time.Since(start); elapsed < 140*time.Millisecond {
+			t.Fatalf("expected the requests to take at least 150ms, took %s", elapsed)
+		}
+	})
+	t.Run("Should time out each attempt and set the user agent", func(t *testing.T) {
+		setTestSourcesHTTPConfigs(t, func(c *SourcesHTTPConfigs) {
+			c.Timeout = 50 * time.Millisecond
+			c.Retries = 1
+			c.UserAgent = "mantium"
+		})
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) == 1 {
+				time.Sleep(200 * time.Millisecond)
+			}
+			w.Write([]byte(r.UserAgent()))
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewSourcesTransport("", nil)}
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("User-Agent", "source")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "mantium" || requests.Load() != 2 || req.Header.Get("User-Agent") != "source" {
+			t.Fatalf("unexpected response after %d requests: %s", requests.Load(), body)
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := map[string]time.Duration{
+		"":                              0,
+		"30":                            30 * time.Second,
+		"-1":                            0,
+		"Mon, 01 Jan 2024 00:02:00 GMT": 2 * time.Minute,
+		"invalid":                       0,
+	}
+	for value, expected := range testCases {
+		if d := parseRetryAfter(value, now); d != expected {
+			t.Fatalf("expected Retry-After '%s' to be %s, got %s", value, expected, d)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := range 10 {
+		expected := min(time.Second<<attempt, 30*time.Second)
+		if d := backoff(attempt, time.Second, 30*time.Second); d < expected/2 || d > expected {
+			t.Fatalf("expected the backoff of attempt %d to be between %s and %s, got %s", attempt, expected/2, expected, d)
+		}
+	}
+}
+
+func TestVisitSourcePage(t *testing.T) {
+	setTestSourcesHTTPConfigs(t, func(c *SourcesHTTPConfigs) { c.Retries = 0 })
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/not-found":
+			w.WriteHeader(http.StatusNotFound)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte("<html></html>"))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Should return a status code error with the status code", func(t *testing.T) {
+		for path, statusCode := range map[string]int{"/not-found": http.StatusNotFound, "/unavailable": http.StatusServiceUnavailable} {
+			err := VisitSourcePage(NewSourcesCollector(""), server.URL+path)
+			var statusCodeErr *StatusCodeError
+			if !errors.As(err, &statusCodeErr) {
+				t.Fatalf("expected a status code error visiting '%s', got: %v", path, err)
+			}
+			if statusCodeErr.StatusCode != statusCode || err.Error() != http.StatusText(statusCode) {
+				t.Fatalf("expected status code %d and error '%s', got %d and '%s'", statusCode, http.StatusText(statusCode), statusCodeErr.StatusCode, err.Error())
+			}
+		}
+	})
+	t.Run("Should not return an error if the page is found", func(t *testing.T) {
+		err := VisitSourcePage(NewSourcesCollector(""), server.URL+"/manga")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
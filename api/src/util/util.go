@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -71,56 +72,37 @@ var (
 
 // GetImageFromURL downloads an image from a URL and tries to resize it.
 // If the image is not resized, it returns the original image.
-func GetImageFromURL(url string, retries int, retryInterval time.Duration) (imgBytes []byte, resized bool, err error) {
+// The image is downloaded using the source's transport, which retries the failed requests, see NewSourcesTransport.
+func GetImageFromURL(source, url string) (imgBytes []byte, resized bool, err error) {
 	contextError := "error downloading image '%s'"
 
 	httpClient := &http.Client{
-		Timeout: 10 * time.Second,
+		Transport: NewSourcesTransport(source, nil),
 	}
 
-	imageBytes := make([]byte, 0)
-	for i := 0; i < retries; i++ {
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			if i == retries-1 {
-				return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), AddErrorContext("error while creating request", err))
-			}
-			time.Sleep(retryInterval)
-			continue
-		}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), AddErrorContext("error while creating request", err))
+	}
 
-		req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:30.0) Gecko/20100101 Firefox/30.0")
-		req.Header.Set("Sec-Fetch-Dest", "document")
-		req.Header.Set("Sec-Fetch-Mode", "navigate")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:30.0) Gecko/20100101 Firefox/30.0")
+	req.Header.Set("Sec-Fetch-Dest", "document")
+	req.Header.Set("Sec-Fetch-Mode", "navigate")
 
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			if i == retries-1 {
-				return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), AddErrorContext("error while executing request", err))
-			}
-			time.Sleep(retryInterval)
-			continue
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			if i == retries-1 {
-				defer resp.Body.Close()
-				body, _ := io.ReadAll(resp.Body)
-				return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), fmt.Errorf("non-200 status code -> (%d). Body: %s", resp.StatusCode, string(body)))
-			}
-			time.Sleep(retryInterval)
-			continue
-		}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), AddErrorContext("error while executing request", err))
+	}
+	defer resp.Body.Close()
 
-		imageBytes, err = io.ReadAll(resp.Body)
-		if err != nil {
-			if i == retries-1 {
-				return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), AddErrorContext("could not read the image data from request body", err))
-			}
-			time.Sleep(retryInterval)
-			continue
-		}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), fmt.Errorf("non-200 status code -> (%d). Body: %s", resp.StatusCode, string(body)))
+	}
+
+	imageBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resized, AddErrorContext(fmt.Sprintf(contextError, url), AddErrorContext("could not read the image data from request body", err))
 	}
 
 	if strings.HasSuffix(url, ".webp") {
@@ -242,44 +224,20 @@ func GetRFC3339Datetime(date string) (time.Time, error) {
 	return parsedDate, nil
 }
 
-// RequestUpdateMangasMetadata sends a request to the server to update all mangas metadata
-func RequestUpdateMangasMetadata(notify bool) (*http.Response, error) {
-	contextErrror := "error requesting to update mangas metadata (notify is %v)"
-
-	client := &http.Client{}
-
-	apiPort := os.Getenv("API_PORT")
-	if apiPort == "" {
-		apiPort = "8080"
-	}
-
-	url := fmt.Sprintf("http://localhost:%s/v1/mangas/metadata", apiPort)
-	if notify {
-		url += "?notify=true"
-	}
-	req, err := http.NewRequest("PATCH", url, nil)
-	if err != nil {
-		return nil, AddErrorContext(fmt.Sprintf(contextErrror, notify), err)
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return resp, AddErrorContext(fmt.Sprintf(contextErrror, notify), err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return resp, AddErrorContext(fmt.Sprintf(contextErrror, notify), fmt.Errorf("non-200 status code -> (%d)", resp.StatusCode))
-	}
-
-	return resp, nil
-}
-
 // FileExists checks if a file exists at the given path.
 func FileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil || !os.IsNotExist(err)
 }
 
+// languageCodeRegex matches translated language codes, like "en", "pt-br", or "es-la".
+var languageCodeRegex = regexp.MustCompile(`^[a-z]{2,3}(-[a-z]{2,4})?$`)
+
+// IsLanguageCode checks if a string is a translated language code, like "en", "pt-br", or "es-la".
+func IsLanguageCode(language string) bool {
+	return languageCodeRegex.MatchString(language)
+}
+
 // GetDomain extracts the domain from a given URL.
 func GetDomain(rawURL string) (string, error) {
 	parsed, err := url.Parse(rawURL)
@@ -1,7 +1,7 @@
 from typing import Any
 from urllib.parse import urljoin
 
-import requests
+from src.api.session import session
 from src.exceptions import APIException
 
 
@@ -20,7 +20,7 @@ class CustomMangaAPIClient:
         url = f"{self.base_custom_manga_url}{path}"
         url = f"{url}?id={manga_id}&url={manga_url}&name={name}"
 
-        res = requests.patch(url)
+        res = session.patch(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -44,7 +44,7 @@ class CustomMangaAPIClient:
         url = f"{self.base_custom_manga_url}{path}"
         url = f"{url}?id={manga_id}&url={manga_url}&new_url={new_url}"
 
-        res = requests.patch(url)
+        res = session.patch(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -92,7 +92,7 @@ class CustomMangaAPIClient:
                 "get_first": last_released_chapter_url_get_first,
             }
 
-        res = requests.patch(url, json=request_body)
+        res = session.patch(url, json=request_body)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -117,7 +117,7 @@ class CustomMangaAPIClient:
         url = f"{self.base_custom_manga_url}{path}"
         url = f"{url}?id={manga_id}&{'&cover_img_url=%s' % cover_img_url if cover_img_url else ''}{'&use_mantium_default_img=%s' % str(use_mantium_default_img).lower() if use_mantium_default_img else ''}"
 
-        res = requests.patch(url, files={"cover_img": cover_img})
+        res = session.patch(url, files={"cover_img": cover_img})
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -2,7 +2,7 @@ from datetime import datetime, timezone
 from typing import Any
 from urllib.parse import urljoin
 
-import requests
+from src.api.session import session
 import src.util.defaults as defaults
 from src.exceptions import APIException
 from src.util.util import get_updated_at_datetime
@@ -17,7 +17,7 @@ class MangaAPIClient:
         url = self.base_manga_url
         url = f"{url}s"
 
-        res = requests.get(url)
+        res = session.get(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -82,7 +82,7 @@ class MangaAPIClient:
             f"{url}?id={manga_id}&url={manga_url}&manga_internal_id={manga_internal_id}"
         )
 
-        res = requests.get(url)
+        res = session.get(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -108,7 +108,7 @@ class MangaAPIClient:
             "source": source,
         }
 
-        res = requests.post(url, json=request_body)
+        res = session.post(url, json=request_body)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
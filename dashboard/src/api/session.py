@@ -0,0 +1,12 @@
+import os
+
+import requests
+
+# Session used by the API clients to send requests to the API.
+# If the API authentication is enabled, the API_TOKEN environment
+# variable should be set to an API token with the "write" or "admin" scope.
+session = requests.Session()
+
+api_token = os.environ.get("API_TOKEN", "")
+if api_token != "":
+    session.headers["Authorization"] = f"Bearer {api_token}"
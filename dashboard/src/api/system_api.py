@@ -1,4 +1,5 @@
 import requests
+from src.api.session import session
 from src.exceptions import APIException
 
 
@@ -14,7 +15,7 @@ class DashboardAPIClient:
         url = self.base_api_url + "/v1/health"
 
         try:
-            res = requests.get(url)
+            res = session.get(url)
         except requests.exceptions.ConnectionError:
             raise Exception(
                 "error while checking the health of the API at "
@@ -40,7 +41,7 @@ class DashboardAPIClient:
         """
         url = self.base_api_url + "/v1/dashboard/last_update"
 
-        res = requests.get(url)
+        res = session.get(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -62,7 +63,7 @@ class DashboardAPIClient:
         """
         url = self.base_api_url + "/v1/dashboard/configs"
 
-        res = requests.get(url)
+        res = session.get(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -87,7 +88,7 @@ class DashboardAPIClient:
         """
         url = self.base_api_url + "/v1/dashboard/configs"
 
-        res = requests.post(url, json=configs)
+        res = session.post(url, json=configs)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -107,7 +108,7 @@ class DashboardAPIClient:
         """
         url = self.base_api_url + "/v1/dashboard/last_background_error"
 
-        res = requests.get(url)
+        res = session.get(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -129,7 +130,7 @@ class DashboardAPIClient:
         """
         url = self.base_api_url + "/v1/dashboard/last_background_error"
 
-        res = requests.delete(url)
+        res = session.delete(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
@@ -149,7 +150,7 @@ class DashboardAPIClient:
         """
         url = self.base_api_url + "/v1/dashboard/updated_message"
 
-        res = requests.get(url)
+        res = session.get(url)
 
         if res.status_code not in self.acceptable_status_codes:
             raise APIException(
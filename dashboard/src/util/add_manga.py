@@ -524,6 +524,15 @@ def show_search_result_manga(
         "float: right;",
     )
 
+    if manga.get("Languages"):
+        languages = tag_content_format.format(", ".join(manga["Languages"]))
+        tagger(
+            "<strong>Languages:</strong>",
+            languages,
+            defaults.chapter_link_tag_background_color,
+            "float: right;",
+        )
+
     st.caption(manga["Description"])
 
     def on_click():
@@ -11,6 +11,7 @@ from src.exceptions import APIException
 from src.util.add_manga import show_add_custom_manga_form, show_search_manga_term_form
 from src.util.util import (
     centered_container,
+    get_chapter_label,
     get_logger,
     get_relative_time,
     get_source_name_and_colors,
@@ -711,7 +712,7 @@ def show_multimanga_manga(
 
     chapter_tag_content = f"""
         <a href="{manga["LastReleasedChapter"]["URL"]}" target="_blank" style="text-decoration: none; color: {defaults.chapter_link_tag_text_color}">
-            <span>{f'Ch. {manga["LastReleasedChapter"]["Chapter"]}' if manga["LastReleasedChapter"]["Chapter"] != "" else "N/A"}</span>
+            <span>{get_chapter_label(manga["LastReleasedChapter"])}</span>
         </a>
     """
 
@@ -170,6 +170,17 @@ def set_is_dialog_open():
     ss["is_dialog_open"] = False
 
 
+def get_chapter_label(chapter: dict) -> str:
+    """Returns the chapter label shown in the dashboard, like "Vol. 3 Ch. 20", or "N/A" if the chapter is empty."""
+    if chapter["Chapter"] == "":
+        return "N/A"
+    label = f'Ch. {chapter["Chapter"]}'
+    if chapter.get("Volume", "") != "":
+        label = f'Vol. {chapter["Volume"]} {label}'
+
+    return label
+
+
 def is_unread_chapter(last_read_chapter: str, last_released_chapter: str) -> bool:
     """Returns True if the multimanga has unread chapters, False otherwise."""
 